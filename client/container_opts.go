@@ -330,3 +330,20 @@ func WithSpec(s *oci.Spec, opts ...oci.SpecOpts) NewContainerOpts {
 func WithoutRefreshedMetadata(i *InfoConfig) {
 	i.Refresh = false
 }
+
+// RegenerateSpec builds a fresh runtime spec for the container from the
+// image, reapplying the overrides recorded by
+// oci.WithImageConfigAndOverrides. This supports recreating a container
+// with a newer image while keeping the user's configuration.
+func (c *Client) RegenerateSpec(ctx context.Context, container Container, image Image, extraOpts ...oci.SpecOpts) (*oci.Spec, error) {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides, _, err := oci.RecordedSpecOverrides(info.Labels)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recorded spec overrides on container %q: %w", container.ID(), err)
+	}
+	opts := append([]oci.SpecOpts{oci.WithImageConfigAndOverrides(image, overrides)}, extraOpts...)
+	return oci.GenerateSpec(ctx, c, &info, opts...)
+}
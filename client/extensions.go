@@ -0,0 +1,90 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/typeurl/v2"
+
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+// Extension names are expected to be unique per embedder, conventionally
+// "<reverse-domain>/<name>". RegisterExtension reserves the name process
+// wide so two components of one binary cannot silently overwrite each
+// other's extension data.
+var (
+	extensionsMu sync.Mutex
+	extensions   = map[string]struct{}{}
+)
+
+// RegisterExtension reserves an extension name for a typed value. The
+// value's type must be registered with typeurl (protobuf types are by
+// default; other types via typeurl.Register), which provides the codec
+// used by GetExtension and WithSetExtension. It panics when the name is
+// already taken, surfacing conflicts at startup instead of as corrupted
+// extension data.
+func RegisterExtension(name string) {
+	extensionsMu.Lock()
+	defer extensionsMu.Unlock()
+	if _, ok := extensions[name]; ok {
+		panic(fmt.Sprintf("container extension %q already registered", name))
+	}
+	extensions[name] = struct{}{}
+}
+
+// GetExtension decodes the named extension of the container into v,
+// which must be of the type the extension was stored with.
+func GetExtension(container containers.Container, name string, v interface{}) error {
+	data, ok := container.Extensions[name]
+	if !ok {
+		return fmt.Errorf("extension %q: %w", name, errdefs.ErrNotFound)
+	}
+	if err := typeurl.UnmarshalTo(data, v); err != nil {
+		return fmt.Errorf("failed to decode extension %q: %w", name, err)
+	}
+	return nil
+}
+
+// WithSetExtension returns an UpdateContainerOpts storing v as the named
+// extension, encoded through typeurl.
+func WithSetExtension(name string, v interface{}) UpdateContainerOpts {
+	return func(ctx context.Context, client *Client, c *containers.Container) error {
+		data, err := typeurl.MarshalAny(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode extension %q: %w", name, err)
+		}
+		if c.Extensions == nil {
+			c.Extensions = map[string]typeurl.Any{}
+		}
+		c.Extensions[name] = data
+		return nil
+	}
+}
+
+// LoadExtension loads and decodes the named extension of the container.
+func LoadExtension(ctx context.Context, container Container, name string, v interface{}) error {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return err
+	}
+	return GetExtension(info, name, v)
+}
@@ -165,10 +165,15 @@ func (c *Client) Import(ctx context.Context, reader io.Reader, opts ...ImportOpt
 		is   = c.ImageService()
 	)
 
+	importProvenance := images.WithProvenance(nil, images.Provenance{
+		Source: "import",
+		Digest: index.Digest.String(),
+	})
 	if iopts.indexName != "" {
 		imgs = append(imgs, images.Image{
 			Name:   iopts.indexName,
 			Target: index,
+			Labels: importProvenance,
 		})
 	}
 	var platformMatcher = c.platform
@@ -200,6 +205,7 @@ func (c *Client) Import(ctx context.Context, reader io.Reader, opts ...ImportOpt
 				imgs = append(imgs, images.Image{
 					Name:   name,
 					Target: m,
+					Labels: importProvenance,
 				})
 			}
 			if iopts.skipDgstRef != nil {
@@ -213,6 +219,7 @@ func (c *Client) Import(ctx context.Context, reader io.Reader, opts ...ImportOpt
 					imgs = append(imgs, images.Image{
 						Name:   ref,
 						Target: m,
+						Labels: importProvenance,
 					})
 				}
 			}
@@ -147,7 +147,21 @@ func (p *process) Kill(ctx context.Context, s syscall.Signal, opts ...KillOpts)
 		ExecID:      p.id,
 		All:         i.All,
 	})
-	return errdefs.FromGRPC(err)
+	if err != nil {
+		return errdefs.FromGRPC(err)
+	}
+	if i.EscalationGrace > 0 && s != syscall.SIGKILL {
+		return waitExitOrEscalate(ctx, p.Status, func(ctx context.Context, sig syscall.Signal) error {
+			_, err := p.task.client.TaskService().Kill(ctx, &tasks.KillRequest{
+				Signal:      uint32(sig),
+				ContainerID: p.task.id,
+				ExecID:      p.id,
+				All:         i.All,
+			})
+			return errdefs.FromGRPC(err)
+		}, i.EscalationGrace)
+	}
+	return nil
 }
 
 func (p *process) Wait(ctx context.Context) (<-chan ExitStatus, error) {
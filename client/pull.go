@@ -287,7 +287,11 @@ func (c *Client) fetch(ctx context.Context, rCtx *RemoteContext, ref string, lim
 	return images.Image{
 		Name:   name,
 		Target: desc,
-		Labels: rCtx.Labels,
+		Labels: images.WithProvenance(rCtx.Labels, images.Provenance{
+			Source: "pull",
+			Ref:    ref,
+			Digest: desc.Digest.String(),
+		}),
 	}, nil
 }
 
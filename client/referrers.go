@@ -0,0 +1,132 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/remotes"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+)
+
+// ReferrerSubjectLabel records the subject digest on fetched referrer
+// artifact content, providing the back-reference from a signature, SBOM
+// or attestation to the image it belongs to.
+const ReferrerSubjectLabel = "containerd.io/referrer.subject"
+
+// Referrers lists the artifacts referring to the image using the OCI
+// referrers API. The resolver must support referrers listing, which the
+// default docker resolver does.
+func (c *Client) Referrers(ctx context.Context, ref, artifactType string, opts ...RemoteOpt) ([]ocispec.Descriptor, error) {
+	fetchCtx := defaultRemoteContext()
+	for _, o := range opts {
+		if err := o(c, fetchCtx); err != nil {
+			return nil, err
+		}
+	}
+	img, err := c.ImageService().Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := fetchCtx.Resolver.(docker.ReferrersLister)
+	if !ok {
+		return nil, fmt.Errorf("resolver does not support the OCI referrers API")
+	}
+	return lister.Referrers(ctx, ref, img.Target.Digest, artifactType)
+}
+
+// PullReferrers lists and pulls the artifacts referring to the image into
+// the content store, labelling each artifact manifest with the subject
+// digest, so signatures, SBOMs and attestations travel with the image.
+func (c *Client) PullReferrers(ctx context.Context, ref, artifactType string, opts ...RemoteOpt) ([]ocispec.Descriptor, error) {
+	fetchCtx := defaultRemoteContext()
+	for _, o := range opts {
+		if err := o(c, fetchCtx); err != nil {
+			return nil, err
+		}
+	}
+	img, err := c.ImageService().Get(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	lister, ok := fetchCtx.Resolver.(docker.ReferrersLister)
+	if !ok {
+		return nil, fmt.Errorf("resolver does not support the OCI referrers API")
+	}
+	descs, err := lister.Referrers(ctx, ref, img.Target.Digest, artifactType)
+	if err != nil {
+		return nil, err
+	}
+	if len(descs) == 0 {
+		return nil, nil
+	}
+
+	ctx, done, err := c.WithLease(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done(ctx)
+
+	fetcher, err := fetchCtx.Resolver.Fetcher(ctx, refWithDigest(ref, img.Target.Digest))
+	if err != nil {
+		return nil, err
+	}
+	store := c.ContentStore()
+	handler := images.Handlers(
+		remotes.FetchHandler(store, fetcher),
+		images.ChildrenHandler(store),
+	)
+	for _, desc := range descs {
+		if desc.Annotations == nil {
+			desc.Annotations = map[string]string{}
+		}
+		if err := images.Dispatch(ctx, handler, nil, desc); err != nil {
+			return nil, fmt.Errorf("failed to pull referrer %s: %w", desc.Digest, err)
+		}
+		info, err := store.Info(ctx, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		if info.Labels == nil {
+			info.Labels = map[string]string{}
+		}
+		info.Labels[ReferrerSubjectLabel] = img.Target.Digest.String()
+		if _, err := store.Update(ctx, info, "labels."+ReferrerSubjectLabel); err != nil {
+			return nil, err
+		}
+	}
+	return descs, nil
+}
+
+// refWithDigest replaces any tag or digest on the reference with the
+// digest, keeping the repository part.
+func refWithDigest(ref string, dgst digest.Digest) string {
+	name := ref
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndexByte(name, ':'); i >= 0 && !strings.Contains(name[i:], "/") {
+		name = name[:i]
+	}
+	return name + "@" + dgst.String()
+}
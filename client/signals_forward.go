@@ -0,0 +1,112 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+)
+
+// Signalable is anything host signals can be forwarded to, i.e. tasks and
+// exec processes.
+type Signalable interface {
+	Kill(context.Context, syscall.Signal, ...KillOpts) error
+}
+
+type forwardSignalsConfig struct {
+	allowed map[os.Signal]struct{}
+	denied  map[os.Signal]struct{}
+}
+
+// ForwardSignalsOpt configures ForwardSignals.
+type ForwardSignalsOpt func(*forwardSignalsConfig)
+
+// WithAllowedSignals restricts forwarding to the listed signals.
+func WithAllowedSignals(sigs ...os.Signal) ForwardSignalsOpt {
+	return func(c *forwardSignalsConfig) {
+		c.allowed = make(map[os.Signal]struct{}, len(sigs))
+		for _, s := range sigs {
+			c.allowed[s] = struct{}{}
+		}
+	}
+}
+
+// WithDeniedSignals drops the listed signals instead of forwarding them.
+func WithDeniedSignals(sigs ...os.Signal) ForwardSignalsOpt {
+	return func(c *forwardSignalsConfig) {
+		if c.denied == nil {
+			c.denied = make(map[os.Signal]struct{}, len(sigs))
+		}
+		for _, s := range sigs {
+			c.denied[s] = struct{}{}
+		}
+	}
+}
+
+// ForwardSignals proxies signals received by this process to the task or
+// exec process until the returned stop function is called. Go runtime
+// internal signals (such as SIGURG, used by the scheduler for preemption)
+// are never forwarded, which hand-rolled forwarding loops frequently get
+// wrong. Allow and deny lists further restrict what is forwarded.
+func ForwardSignals(ctx context.Context, process Signalable, opts ...ForwardSignalsOpt) (stop func()) {
+	var config forwardSignalsConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	sigc := make(chan os.Signal, 128)
+	signal.Notify(sigc)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for s := range sigc {
+			if isRuntimeSignal(s) {
+				log.G(ctx).Debugf("not forwarding runtime internal signal %s", s)
+				continue
+			}
+			if _, denied := config.denied[s]; denied {
+				continue
+			}
+			if config.allowed != nil {
+				if _, ok := config.allowed[s]; !ok {
+					continue
+				}
+			}
+			sig, ok := s.(syscall.Signal)
+			if !ok {
+				continue
+			}
+			if err := process.Kill(ctx, sig); err != nil {
+				if errdefs.IsNotFound(err) {
+					log.G(ctx).WithError(err).Debugf("not forwarding signal %s", s)
+					return
+				}
+				log.G(ctx).WithError(err).Errorf("forward signal %s", s)
+			}
+		}
+	}()
+	return func() {
+		signal.Stop(sigc)
+		close(sigc)
+		<-done
+	}
+}
@@ -240,9 +240,64 @@ func (t *task) Kill(ctx context.Context, s syscall.Signal, opts ...KillOpts) err
 	if err != nil {
 		return errdefs.FromGRPC(err)
 	}
+	if i.EscalationGrace > 0 && s != syscall.SIGKILL {
+		// Wait on the process the signal was delivered to: the exec
+		// process when an exec id was given, the init process otherwise.
+		statusFn := t.Status
+		if i.ExecID != "" {
+			p, err := t.LoadProcess(ctx, i.ExecID, nil)
+			if err != nil {
+				if errdefs.IsNotFound(err) {
+					// The exec process already exited and was cleaned up.
+					return nil
+				}
+				return err
+			}
+			statusFn = p.Status
+		}
+		return waitExitOrEscalate(ctx, statusFn, func(ctx context.Context, sig syscall.Signal) error {
+			_, err := t.client.TaskService().Kill(ctx, &tasks.KillRequest{
+				Signal:      uint32(sig),
+				ContainerID: t.id,
+				ExecID:      i.ExecID,
+				All:         i.All,
+			})
+			return errdefs.FromGRPC(err)
+		}, i.EscalationGrace)
+	}
 	return nil
 }
 
+// waitExitOrEscalate waits up to the escalation grace period for the
+// signalled process to stop and then sends SIGKILL through the kill
+// callback. Task.Kill and Process.Kill funnel through this single
+// implementation so clients share one escalation loop; it runs on the
+// client until the shim task API grows native escalation support.
+func waitExitOrEscalate(ctx context.Context, status func(context.Context) (Status, error), kill func(context.Context, syscall.Signal) error, grace time.Duration) error {
+	waitCtx, cancel := context.WithTimeout(ctx, grace)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		st, err := status(waitCtx)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return nil
+			}
+		} else if st.Status == Stopped {
+			return nil
+		}
+		select {
+		case <-waitCtx.Done():
+			if err := kill(ctx, syscall.SIGKILL); err != nil && !errdefs.IsNotFound(err) {
+				return err
+			}
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
 func (t *task) Pause(ctx context.Context) error {
 	_, err := t.client.TaskService().Pause(ctx, &tasks.PauseTaskRequest{
 		ContainerID: t.id,
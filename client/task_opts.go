@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"syscall"
+	"time"
 
 	"github.com/containerd/containerd/api/types"
 	"github.com/containerd/containerd/api/types/runc/options"
@@ -187,11 +188,28 @@ type KillInfo struct {
 	All bool
 	// ExecID is the ID of a process to kill
 	ExecID string
+	// EscalationGrace, when non-zero, makes Kill wait this long for the
+	// process to exit after delivering the signal and then escalate to
+	// SIGKILL, replacing the divergent kill loops clients implement.
+	EscalationGrace time.Duration
 }
 
 // KillOpts allows options to be set for the killing of a process
 type KillOpts func(context.Context, *KillInfo) error
 
+// WithKillEscalation delivers the requested signal, waits up to grace for
+// the signalled process (the exec process when an exec id is set, the
+// init process otherwise) to exit and then sends SIGKILL. Combine with
+// WithKillAll to signal the entire cgroup instead of only the init
+// process. The wait currently runs on the client and moves into the shim
+// once the task API supports escalation natively.
+func WithKillEscalation(grace time.Duration) KillOpts {
+	return func(ctx context.Context, i *KillInfo) error {
+		i.EscalationGrace = grace
+		return nil
+	}
+}
+
 // WithKillAll kills all processes for a task
 func WithKillAll(ctx context.Context, i *KillInfo) error {
 	i.All = true
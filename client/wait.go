@@ -0,0 +1,143 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	eventstypes "github.com/containerd/containerd/api/events"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	tasktypes "github.com/containerd/containerd/api/types/task"
+	"github.com/containerd/errdefs"
+	"github.com/containerd/typeurl/v2"
+
+	"github.com/containerd/containerd/v2/pkg/protobuf"
+)
+
+// TaskExit describes one task exit delivered by WaitTasks.
+type TaskExit struct {
+	// ContainerID of the exited task.
+	ContainerID string
+	// ExitStatus of the task's init process.
+	ExitStatus uint32
+	// ExitedAt is when the init process exited.
+	ExitedAt time.Time
+}
+
+// WaitTasks waits on a set of tasks over a single event subscription and
+// delivers their exits as a stream, so supervisors do not need one
+// goroutine and Wait channel per container. Tasks that already exited
+// when WaitTasks is called are delivered immediately. Ids whose task is
+// unknown (already deleted, or never created) produce a not found error
+// on the error channel and are removed from the wait set, so the caller
+// is never left hanging on an exit that cannot arrive. The exit channel
+// is closed once every remaining task has exited; errors from the event
+// stream are delivered on the error channel as well.
+func (c *Client) WaitTasks(ctx context.Context, ids ...string) (<-chan TaskExit, <-chan error) {
+	var (
+		exits = make(chan TaskExit)
+		// Buffered for one error per unknown id plus a stream failure.
+		errs = make(chan error, len(ids)+1)
+	)
+
+	waiting := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		waiting[id] = struct{}{}
+	}
+
+	// Subscribe before the initial status scan so no exit between scan
+	// and subscription is lost.
+	evCh, evErrs := c.Subscribe(ctx, `topic=="/tasks/exit"`)
+
+	go func() {
+		defer close(exits)
+
+		deliver := func(exit TaskExit) bool {
+			if _, ok := waiting[exit.ContainerID]; !ok {
+				return len(waiting) > 0
+			}
+			delete(waiting, exit.ContainerID)
+			select {
+			case exits <- exit:
+			case <-ctx.Done():
+				return false
+			}
+			return len(waiting) > 0
+		}
+
+		// Deliver tasks that have already stopped, and surface unknown
+		// ids as errors: a deleted task will never produce an exit event,
+		// so silently waiting on it would hang the caller forever.
+		for id := range waiting {
+			resp, err := c.TaskService().Get(ctx, &tasksapi.GetRequest{ContainerID: id})
+			if err != nil {
+				delete(waiting, id)
+				errs <- fmt.Errorf("task %q: %w", id, errdefs.FromGRPC(err))
+				if len(waiting) == 0 {
+					return
+				}
+				continue
+			}
+			p := resp.GetProcess()
+			if p.GetStatus() == tasktypes.Status_STOPPED {
+				if !deliver(TaskExit{
+					ContainerID: id,
+					ExitStatus:  p.GetExitStatus(),
+					ExitedAt:    protobuf.FromTimestamp(p.GetExitedAt()),
+				}) {
+					return
+				}
+			}
+		}
+
+		for {
+			select {
+			case envelope := <-evCh:
+				if envelope == nil || envelope.Event == nil {
+					continue
+				}
+				v, err := typeurl.UnmarshalAny(envelope.Event)
+				if err != nil {
+					continue
+				}
+				e, ok := v.(*eventstypes.TaskExit)
+				if !ok || e.ID != e.ContainerID {
+					// Only init process exits end a task.
+					continue
+				}
+				if !deliver(TaskExit{
+					ContainerID: e.ContainerID,
+					ExitStatus:  e.ExitStatus,
+					ExitedAt:    protobuf.FromTimestamp(e.ExitedAt),
+				}) {
+					return
+				}
+			case err := <-evErrs:
+				if err != nil {
+					errs <- err
+				}
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return exits, errs
+}
@@ -194,6 +194,15 @@ func (e *execProcess) start(ctx context.Context) (err error) {
 		}
 		e.io = pio
 	}
+	extraFiles, err := openExtraFiles(&e.spec)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		for _, f := range extraFiles {
+			f.Close()
+		}
+	}()
 	opts := &runc.ExecOpts{
 		PidFile: pidFile.Path(),
 		Detach:  true,
@@ -204,7 +213,12 @@ func (e *execProcess) start(ctx context.Context) (err error) {
 	if socket != nil {
 		opts.ConsoleSocket = socket
 	}
-	if err := e.parent.runtime.Exec(ctx, e.parent.id, e.spec, opts); err != nil {
+	if len(extraFiles) > 0 {
+		err = runcExecPreserveFDs(ctx, e.parent.runtime, e.parent.id, e.spec, opts, extraFiles)
+	} else {
+		err = e.parent.runtime.Exec(ctx, e.parent.id, e.spec, opts)
+	}
+	if err != nil {
 		close(e.waitBlock)
 		return e.parent.runtimeError(err, "OCI runtime exec failed")
 	}
@@ -0,0 +1,99 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// preserveFDPrefix marks environment entries of an exec process spec that
+// request host files or sockets to be forwarded into the process, socket
+// activation style:
+//
+//	CONTAINERD_PRESERVE_FD_<n>=<host path>
+//
+// The shim opens each path (connecting to unix sockets) and passes the
+// files to runc with --preserve-fds. Entries are forwarded in ascending
+// <n> order, and each entry's value is rewritten to the file descriptor
+// number the process sees, starting at 3.
+const preserveFDPrefix = "CONTAINERD_PRESERVE_FD_"
+
+// openExtraFiles opens the extra files requested through the process
+// spec's environment. The caller owns the returned files and closes them
+// after handing them to runc.
+func openExtraFiles(spec *specs.Process) ([]*os.File, error) {
+	type request struct {
+		index  int
+		envPos int
+		path   string
+	}
+	var requests []request
+	for i, kv := range spec.Env {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, preserveFDPrefix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(name, preserveFDPrefix))
+		if err != nil {
+			return nil, fmt.Errorf("invalid extra fd env entry %q: %w", name, err)
+		}
+		requests = append(requests, request{index: n, envPos: i, path: value})
+	}
+	if len(requests) == 0 {
+		return nil, nil
+	}
+	sort.Slice(requests, func(i, j int) bool { return requests[i].index < requests[j].index })
+
+	var files []*os.File
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	for ordinal, req := range requests {
+		f, err := openHostFD(req.path)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("failed to open extra fd %d from %q: %w", req.index, req.path, err)
+		}
+		files = append(files, f)
+		// The first preserved fd shows up as fd 3 in the process.
+		spec.Env[req.envPos] = fmt.Sprintf("%s%d=%d", preserveFDPrefix, req.index, 3+ordinal)
+	}
+	return files, nil
+}
+
+// openHostFD opens a host path for forwarding, connecting when the path
+// is a unix socket.
+func openHostFD(path string) (*os.File, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		conn, err := net.Dial("unix", path)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		return conn.(*net.UnixConn).File()
+	}
+	return os.OpenFile(path, os.O_RDWR, 0)
+}
@@ -0,0 +1,85 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenExtraFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("data"), 0600))
+
+	socket := filepath.Join(dir, "sock")
+	l, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	spec := &specs.Process{
+		Env: []string{
+			"PATH=/bin",
+			"CONTAINERD_PRESERVE_FD_1=" + socket,
+			"CONTAINERD_PRESERVE_FD_0=" + file,
+		},
+	}
+	files, err := openExtraFiles(spec)
+	require.NoError(t, err)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	// Files are forwarded in index order and the env values are rewritten
+	// to the fd numbers the process sees.
+	require.Len(t, files, 2)
+	assert.Equal(t, "PATH=/bin", spec.Env[0])
+	assert.Equal(t, "CONTAINERD_PRESERVE_FD_1=4", spec.Env[1])
+	assert.Equal(t, "CONTAINERD_PRESERVE_FD_0=3", spec.Env[2])
+}
+
+func TestOpenExtraFilesNone(t *testing.T) {
+	spec := &specs.Process{Env: []string{"PATH=/bin"}}
+	files, err := openExtraFiles(spec)
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestOpenExtraFilesErrors(t *testing.T) {
+	_, err := openExtraFiles(&specs.Process{Env: []string{"CONTAINERD_PRESERVE_FD_x=/p"}})
+	assert.Error(t, err)
+
+	_, err = openExtraFiles(&specs.Process{Env: []string{"CONTAINERD_PRESERVE_FD_0=/does/not/exist"}})
+	assert.Error(t, err)
+}
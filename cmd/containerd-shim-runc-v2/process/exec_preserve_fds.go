@@ -0,0 +1,132 @@
+//go:build !windows
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+
+	runc "github.com/containerd/go-runc"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// runcExecPreserveFDs invokes `runc exec` with --preserve-fds, passing
+// the extra files to the exec'd process. go-runc's ExecOpts does not
+// carry extra files (only its CreateOpts does), so the command is built
+// here with go-runc's exported configuration and process monitor; this
+// must stay behaviorally in sync with (*runc.Runc).Exec and goes away
+// once the upstream ExecOpts learns ExtraFiles.
+func runcExecPreserveFDs(ctx context.Context, r *runc.Runc, id string, spec specs.Process, opts *runc.ExecOpts, extraFiles []*os.File) error {
+	f, err := os.CreateTemp(os.Getenv("XDG_RUNTIME_DIR"), "runc-process")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	err = json.NewEncoder(f).Encode(spec)
+	f.Close()
+	if err != nil {
+		return err
+	}
+
+	args := runcGlobalArgs(r)
+	args = append(args, "exec", "--process", f.Name(), "--preserve-fds", strconv.Itoa(len(extraFiles)))
+	if opts.ConsoleSocket != nil {
+		args = append(args, "--console-socket", opts.ConsoleSocket.Path())
+	}
+	if opts.Detach {
+		args = append(args, "--detach")
+	}
+	if opts.PidFile != "" {
+		abs, err := filepath.Abs(opts.PidFile)
+		if err != nil {
+			return err
+		}
+		args = append(args, "--pid-file", abs)
+	}
+	args = append(args, opts.ExtraArgs...)
+	args = append(args, id)
+
+	command := r.Command
+	if command == "" {
+		command = runc.DefaultCommand
+	}
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.ExtraFiles = extraFiles
+	cmd.SysProcAttr = &syscall.SysProcAttr{
+		Setpgid:   r.Setpgid,
+		Pdeathsig: r.PdeathSignal,
+	}
+	if opts.IO != nil {
+		opts.Set(cmd)
+	}
+
+	ec, err := runc.Monitor.Start(cmd)
+	if err != nil {
+		return err
+	}
+	if opts.Started != nil {
+		opts.Started <- cmd.Process.Pid
+	}
+	if opts.IO != nil {
+		if c, ok := opts.IO.(runc.StartCloser); ok {
+			if err := c.CloseAfterStart(); err != nil {
+				return err
+			}
+		}
+	}
+	status, err := runc.Monitor.Wait(cmd, ec)
+	if err == nil && status != 0 {
+		err = fmt.Errorf("%s did not terminate successfully: exit status %d", command, status)
+	}
+	return err
+}
+
+// runcGlobalArgs mirrors go-runc's unexported (*Runc).args.
+func runcGlobalArgs(r *runc.Runc) []string {
+	var out []string
+	if r.Root != "" {
+		out = append(out, "--root", r.Root)
+	}
+	if r.Debug {
+		out = append(out, "--debug")
+	}
+	if r.Log != "" {
+		out = append(out, "--log", r.Log)
+	}
+	if r.LogFormat != "" {
+		out = append(out, "--log-format", string(r.LogFormat))
+	}
+	if r.SystemdCgroup {
+		out = append(out, "--systemd-cgroup")
+	}
+	if r.Rootless != nil {
+		// nil stands for "auto" (differs from explicit "false")
+		out = append(out, "--rootless="+strconv.FormatBool(*r.Rootless))
+	}
+	if len(r.ExtraArgs) > 0 {
+		out = append(out, r.ExtraArgs...)
+	}
+	return out
+}
@@ -56,6 +56,9 @@ type processIO struct {
 	uri   *url.URL
 	copy  bool
 	stdio stdio.Stdio
+	// criLogPath, when set, frames stdout/stderr into the file in the
+	// CRI log format instead of writing raw bytes.
+	criLogPath string
 }
 
 func (p *processIO) Close() error {
@@ -74,6 +77,13 @@ func (p *processIO) Copy(ctx context.Context, wg *sync.WaitGroup) error {
 		return nil
 	}
 	var cwg sync.WaitGroup
+	if p.criLogPath != "" {
+		if err := copyFramedPipes(ctx, p.IO(), p.stdio.Stdin, p.criLogPath, wg, &cwg); err != nil {
+			return fmt.Errorf("unable to copy framed pipes: %w", err)
+		}
+		cwg.Wait()
+		return nil
+	}
 	if err := copyPipes(ctx, p.IO(), p.stdio.Stdin, p.stdio.Stdout, p.stdio.Stderr, wg, &cwg); err != nil {
 		return fmt.Errorf("unable to copy pipes: %w", err)
 	}
@@ -118,8 +128,15 @@ func createIO(ctx context.Context, id string, ioUID, ioGID int, stdio stdio.Stdi
 			return nil, err
 		}
 		f.Close()
-		pio.stdio.Stdout = filePath
-		pio.stdio.Stderr = filePath
+		if u.Query().Get("framing") == "cri" {
+			// Frame each line with timestamp, stream name and
+			// partial/full tag so the file is ingestible by Kubernetes
+			// log pipelines.
+			pio.criLogPath = filePath
+		} else {
+			pio.stdio.Stdout = filePath
+			pio.stdio.Stderr = filePath
+		}
 		pio.copy = true
 		pio.io, err = runc.NewPipeIO(ioUID, ioGID, withConditionalIO(stdio))
 	default:
@@ -213,6 +230,11 @@ func copyPipes(ctx context.Context, rio runc.IO, stdin, stdout, stderr string, w
 	if stdin == "" {
 		return nil
 	}
+	return copyStdin(ctx, rio, stdin, cwg)
+}
+
+// copyStdin copies the stdin fifo into the process's stdin pipe.
+func copyStdin(ctx context.Context, rio runc.IO, stdin string, cwg *sync.WaitGroup) error {
 	f, err := fifo.OpenFifo(context.Background(), stdin, syscall.O_RDONLY|syscall.O_NONBLOCK, 0)
 	if err != nil {
 		return fmt.Errorf("containerd-shim: opening %s failed: %s", stdin, err)
@@ -0,0 +1,147 @@
+//go:build !windows
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	runc "github.com/containerd/go-runc"
+	"github.com/containerd/log"
+)
+
+// criLogFile serializes CRI-format log lines from multiple streams into
+// one file:
+//
+//	<RFC3339Nano timestamp> <stream> <P|F> <content>
+//
+// making standalone containerd logs ingestible by existing Kubernetes log
+// pipelines.
+type criLogFile struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// maxCRILineSize splits longer writes into partial ("P") lines, matching
+// the CRI logging convention.
+const maxCRILineSize = 16 * 1024
+
+// writeFrame writes one framed line.
+func (c *criLogFile) writeFrame(stream string, line []byte, partial bool) error {
+	tag := "F"
+	if partial {
+		tag = "P"
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.f.WriteString(time.Now().Format(time.RFC3339Nano) + " " + stream + " " + tag + " "); err != nil {
+		return err
+	}
+	if _, err := c.f.Write(line); err != nil {
+		return err
+	}
+	_, err := c.f.WriteString("\n")
+	return err
+}
+
+// criStreamWriter frames a single stream into the shared log file,
+// buffering until newlines and tagging oversized chunks as partial.
+type criStreamWriter struct {
+	dst    *criLogFile
+	stream string
+	buf    bytes.Buffer
+}
+
+func (w *criStreamWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	for {
+		data := w.buf.Bytes()
+		idx := bytes.IndexByte(data, '\n')
+		if idx < 0 {
+			if w.buf.Len() >= maxCRILineSize {
+				if err := w.dst.writeFrame(w.stream, data, true); err != nil {
+					return len(p), err
+				}
+				w.buf.Reset()
+			}
+			return len(p), nil
+		}
+		if err := w.dst.writeFrame(w.stream, data[:idx], false); err != nil {
+			return len(p), err
+		}
+		w.buf.Next(idx + 1)
+	}
+}
+
+// Close flushes any trailing unterminated line as a partial frame.
+func (w *criStreamWriter) Close() error {
+	if w.buf.Len() > 0 {
+		return w.dst.writeFrame(w.stream, w.buf.Bytes(), true)
+	}
+	return nil
+}
+
+// copyFramedPipes copies process output into the CRI framed log file.
+func copyFramedPipes(ctx context.Context, rio runc.IO, stdin, path string, wg, cwg *sync.WaitGroup) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	dst := &criLogFile{f: f}
+	var streams sync.WaitGroup
+	for _, s := range []struct {
+		name string
+		r    io.Reader
+	}{
+		{name: "stdout", r: rio.Stdout()},
+		{name: "stderr", r: rio.Stderr()},
+	} {
+		if s.r == nil {
+			continue
+		}
+		w := &criStreamWriter{dst: dst, stream: s.name}
+		wg.Add(1)
+		cwg.Add(1)
+		streams.Add(1)
+		go func(name string, r io.Reader, w *criStreamWriter) {
+			cwg.Done()
+			p := bufPool.Get().(*[]byte)
+			defer bufPool.Put(p)
+			if _, err := io.CopyBuffer(w, r, *p); err != nil {
+				log.G(ctx).Warnf("error copying %s", name)
+			}
+			w.Close()
+			streams.Done()
+			wg.Done()
+		}(s.name, s.r, w)
+	}
+	go func() {
+		streams.Wait()
+		f.Close()
+	}()
+
+	if stdin != "" {
+		return copyStdin(ctx, rio, stdin, cwg)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+//go:build !windows
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCRIStreamWriterFraming(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "container.log")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := &criLogFile{f: f}
+	w := &criStreamWriter{dst: dst, stream: "stdout"}
+
+	// Lines split over writes are reassembled; a trailing unterminated
+	// line flushes as partial on close.
+	if _, err := w.Write([]byte("hello ")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("world\nsecond line\ntrailing")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 3 framed lines, got %d: %q", len(lines), lines)
+	}
+	for i, expected := range []struct {
+		tag     string
+		content string
+	}{
+		{tag: "F", content: "hello world"},
+		{tag: "F", content: "second line"},
+		{tag: "P", content: "trailing"},
+	} {
+		fields := strings.SplitN(lines[i], " ", 4)
+		if len(fields) != 4 {
+			t.Fatalf("malformed line %q", lines[i])
+		}
+		if fields[1] != "stdout" || fields[2] != expected.tag || fields[3] != expected.content {
+			t.Errorf("line %d: expected stdout %s %q, got %q", i, expected.tag, expected.content, lines[i])
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package task
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/containerd/errdefs"
+)
+
+// fdBudgetEnv configures the shim's open file descriptor budget. When the
+// shim's fd count reaches the budget, new execs are refused with an
+// unavailable error instead of letting leaked probe or logger fds exhaust
+// the process fd limit and break IO for every container in the pod. A
+// zero or unset value applies defaultFDBudget; a negative value disables
+// enforcement.
+//
+// The variable is set through the shim manager's configuration, the
+// supported knob for shim process settings:
+//
+//	[plugins."io.containerd.shim.v1.manager"]
+//	  env = ["CONTAINERD_SHIM_FD_BUDGET=8192"]
+//
+// The budget is enforced per shim process rather than per task: file
+// descriptors are a process-wide resource, so per-task attribution would
+// require accounting every fd the shim opens on a task's behalf. Each
+// enforcement publishes a TaskFDBudgetExceeded event naming the task
+// that hit the limit, which gives the attribution operators need.
+const fdBudgetEnv = "CONTAINERD_SHIM_FD_BUDGET"
+
+// defaultFDBudget leaves ample room for regular task IO while stopping
+// runaway leaks well before common RLIMIT_NOFILE values.
+const defaultFDBudget = 4096
+
+var (
+	fdBudgetOnce  sync.Once
+	fdBudgetValue int
+)
+
+func fdBudget() int {
+	fdBudgetOnce.Do(func() {
+		fdBudgetValue = defaultFDBudget
+		if v := os.Getenv(fdBudgetEnv); v != "" {
+			if n, err := strconv.Atoi(v); err == nil {
+				fdBudgetValue = n
+			}
+		}
+	})
+	return fdBudgetValue
+}
+
+// openFDCount returns the number of open file descriptors of the shim.
+func openFDCount() (int, error) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// checkFDBudget refuses new fd-consuming work when the shim is at its fd
+// budget, returning the observed count and limit for the enforcement
+// event.
+func checkFDBudget() (open, budget int, _ error) {
+	budget = fdBudget()
+	if budget <= 0 {
+		return 0, budget, nil
+	}
+	open, err := openFDCount()
+	if err != nil {
+		return 0, budget, nil
+	}
+	if open >= budget {
+		return open, budget, fmt.Errorf("shim has %d open files, exceeding the budget of %d: %w", open, budget, errdefs.ErrUnavailable)
+	}
+	return open, budget, nil
+}
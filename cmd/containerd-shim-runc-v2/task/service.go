@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/containerd/cgroups/v3"
 	"github.com/containerd/cgroups/v3/cgroup1"
@@ -389,6 +390,17 @@ func (s *service) Delete(ctx context.Context, r *taskAPI.DeleteRequest) (*taskAP
 
 // Exec an additional process inside the container
 func (s *service) Exec(ctx context.Context, r *taskAPI.ExecProcessRequest) (*ptypes.Empty, error) {
+	if open, budget, err := checkFDBudget(); err != nil {
+		log.G(ctx).WithError(err).Warnf("refusing exec %q for container %q", r.ExecID, r.ID)
+		s.send(&runtime.TaskFDBudgetExceeded{
+			ContainerID: r.ID,
+			ExecID:      r.ExecID,
+			OpenFDs:     open,
+			Budget:      budget,
+			Timestamp:   time.Now().UTC(),
+		})
+		return nil, errdefs.ToGRPC(err)
+	}
 	container, err := s.getContainer(r.ID)
 	if err != nil {
 		return nil, err
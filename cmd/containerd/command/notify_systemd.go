@@ -20,15 +20,48 @@ package command
 
 import (
 	"context"
+	"time"
 
 	sd "github.com/coreos/go-systemd/v22/daemon"
 
+	"github.com/containerd/containerd/v2/internal/liveness"
 	"github.com/containerd/log"
 )
 
 // notifyReady notifies systemd that the daemon is ready to serve requests
+// and starts petting the watchdog when one is configured.
 func notifyReady(ctx context.Context) error {
-	return sdNotify(ctx, sd.SdNotifyReady)
+	if err := sdNotify(ctx, sd.SdNotifyReady); err != nil {
+		return err
+	}
+	startWatchdog(ctx)
+	return nil
+}
+
+// startWatchdog pets the systemd watchdog while every registered internal
+// loop reports progress, so a deadlocked loop leads to a watchdog restart
+// instead of a silently wedged daemon.
+func startWatchdog(ctx context.Context) {
+	interval, err := sd.SdWatchdogEnabled(false)
+	if err != nil || interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if healthy, stale := liveness.Healthy(); healthy {
+					sdNotify(ctx, sd.SdNotifyWatchdog)
+				} else {
+					log.G(ctx).Errorf("not petting systemd watchdog, stale loops: %v", stale)
+				}
+			}
+		}
+	}()
 }
 
 // notifyStopping notifies systemd that the daemon is about to be stopped
@@ -91,10 +91,41 @@ type Config struct {
 	ProxyPlugins map[string]ProxyPlugin `toml:"proxy_plugins"`
 	// Timeouts specified as a duration
 	Timeouts map[string]string `toml:"timeouts"`
+	// RequestTimeouts maps full gRPC service or method name prefixes to
+	// default deadlines enforced server-side when clients do not set one,
+	// e.g. "/containerd.services.content.v1.Content/Write" = "1h".
+	RequestTimeouts map[string]string `toml:"request_timeouts"`
 	// Imports are additional file path list to config files that can overwrite main config file fields
 	Imports []string `toml:"imports"`
 	// StreamProcessors configuration
 	StreamProcessors map[string]StreamProcessor `toml:"stream_processors"`
+	// Shutdown configures how running tasks are handled when the daemon
+	// shuts down
+	Shutdown ShutdownConfig `toml:"shutdown"`
+}
+
+// ShutdownConfig configures how running tasks are handled when the daemon
+// receives a termination signal.
+type ShutdownConfig struct {
+	// Policy selects the task handling applied on shutdown:
+	//  - "leave-running" (default) leaves shims and tasks running so they
+	//    can be reconnected on restart
+	//  - "sigterm" sends SIGTERM to all tasks and waits up to GracePeriod
+	//    for them to exit
+	//  - "checkpoint" checkpoints tasks where the runtime supports it,
+	//    leaving tasks running when checkpointing fails
+	Policy string `toml:"policy"`
+	// GracePeriod is the duration to wait for tasks to exit after they
+	// have been signalled with the "sigterm" policy.
+	//
+	// The string is in the golang duration format, see:
+	//   https://golang.org/pkg/time/#ParseDuration
+	GracePeriod string `toml:"grace_period"`
+	// Deadline is the maximum total duration spent handling tasks before
+	// shutdown proceeds regardless. A zero value means no deadline.
+	Deadline string `toml:"deadline"`
+	// NamespacePolicies overrides Policy for tasks in specific namespaces.
+	NamespacePolicies map[string]string `toml:"namespace_policies"`
 }
 
 // StreamProcessor provides configuration for diff content processors
@@ -202,6 +233,26 @@ type GRPCConfig struct {
 	GID            int    `toml:"gid"`
 	MaxRecvMsgSize int    `toml:"max_recv_message_size"`
 	MaxSendMsgSize int    `toml:"max_send_message_size"`
+	// AdditionalSockets serves the API on extra unix sockets, each with
+	// its own ownership and an optional service allowlist, e.g. an
+	// introspection-only socket for monitoring agents or a CRI-only
+	// socket for the kubelet.
+	AdditionalSockets []SocketConfig `toml:"additional_sockets"`
+}
+
+// SocketConfig describes one additional API socket.
+type SocketConfig struct {
+	// Address is the unix socket path.
+	Address string `toml:"address"`
+	// UID owning the socket.
+	UID int `toml:"uid"`
+	// GID owning the socket.
+	GID int `toml:"gid"`
+	// AllowedServices lists gRPC service name prefixes served on this
+	// socket (e.g. "containerd.services.introspection.v1",
+	// "runtime.v1.RuntimeService"). Everything else is rejected with
+	// permission denied. Empty serves the full API.
+	AllowedServices []string `toml:"allowed_services"`
 }
 
 // TTRPCConfig provides TTRPC configuration for the socket
@@ -321,11 +372,17 @@ func LoadConfig(ctx context.Context, path string, out *Config) error {
 func loadConfigFile(ctx context.Context, path string) (*Config, error) {
 	config := &Config{}
 
-	f, err := os.Open(path)
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	// Resolve ${env:...} and ${file:...} secret references before
+	// decoding.
+	raw, err = interpolateSecrets(raw)
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
+	f := bytes.NewReader(raw)
 
 	if err := toml.NewDecoder(f).DisallowUnknownFields().Decode(config); err != nil {
 		var serr *toml.StrictMissingError
@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// secretRefRe matches the secret reference forms resolved at config load
+// time:
+//
+//	${env:FOO}              the value of the environment variable FOO
+//	${file:/run/secrets/x}  the trimmed contents of the file
+//
+// so credentials such as registry passwords and TLS keys do not have to
+// be written plaintext into config.toml.
+var secretRefRe = regexp.MustCompile(`\$\{(env|file):([^}]+)\}`)
+
+// interpolateSecrets resolves secret references in the raw TOML before it
+// is decoded. Unresolvable references are errors, so a missing secret
+// fails the load instead of silently configuring an empty credential.
+func interpolateSecrets(data []byte) ([]byte, error) {
+	var firstErr error
+	out := secretRefRe.ReplaceAllFunc(data, func(match []byte) []byte {
+		groups := secretRefRe.FindSubmatch(match)
+		kind, key := string(groups[1]), string(groups[2])
+		switch kind {
+		case "env":
+			value, ok := os.LookupEnv(key)
+			if !ok {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("config references undefined environment variable %q", key)
+				}
+				return match
+			}
+			return []byte(value)
+		case "file":
+			content, err := os.ReadFile(key)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("config references unreadable secret file %q: %w", key, err)
+				}
+				return match
+			}
+			return []byte(strings.TrimSpace(string(content)))
+		}
+		return match
+	})
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
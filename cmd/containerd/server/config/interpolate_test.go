@@ -0,0 +1,53 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateSecrets(t *testing.T) {
+	t.Setenv("CONFIG_TEST_PASSWORD", "hunter2")
+	secret := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(secret, []byte("sekret\n"), 0600))
+
+	out, err := interpolateSecrets([]byte(`password = "${env:CONFIG_TEST_PASSWORD}"
+token = "${file:` + secret + `}"
+plain = "value"`))
+	require.NoError(t, err)
+	assert.Equal(t, `password = "hunter2"
+token = "sekret"
+plain = "value"`, string(out))
+}
+
+func TestInterpolateSecretsErrors(t *testing.T) {
+	_, err := interpolateSecrets([]byte(`password = "${env:CONFIG_TEST_UNDEFINED_VARIABLE}"`))
+	assert.Error(t, err)
+
+	_, err = interpolateSecrets([]byte(`token = "${file:/does/not/exist}"`))
+	assert.Error(t, err)
+
+	// Unknown kinds are left untouched.
+	out, err := interpolateSecrets([]byte(`value = "${vault:foo}"`))
+	assert.NoError(t, err)
+	assert.Equal(t, `value = "${vault:foo}"`, string(out))
+}
@@ -0,0 +1,103 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	"google.golang.org/grpc"
+)
+
+// parseRequestTimeouts parses the request_timeouts config section, mapping
+// full gRPC method or service prefixes to durations.
+func parseRequestTimeouts(ctx context.Context, config map[string]string) map[string]time.Duration {
+	if len(config) == 0 {
+		return nil
+	}
+	timeouts := make(map[string]time.Duration, len(config))
+	for prefix, value := range config {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.G(ctx).WithError(err).Errorf("failed to parse request timeout %q for %q", value, prefix)
+			continue
+		}
+		timeouts[prefix] = d
+	}
+	return timeouts
+}
+
+// requestTimeout returns the configured timeout with the longest prefix
+// matching the full method, e.g. "/containerd.services.content.v1.Content"
+// matches every content service method.
+func requestTimeout(timeouts map[string]time.Duration, fullMethod string) time.Duration {
+	var (
+		best    string
+		timeout time.Duration
+	)
+	for prefix, d := range timeouts {
+		if strings.HasPrefix(fullMethod, prefix) && len(prefix) > len(best) {
+			best = prefix
+			timeout = d
+		}
+	}
+	return timeout
+}
+
+// deadlineUnaryInterceptor enforces the configured default deadline on
+// unary requests whose clients did not set one.
+func deadlineUnaryInterceptor(timeouts map[string]time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := ctx.Deadline(); !ok {
+			if d := requestTimeout(timeouts, info.FullMethod); d > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, d)
+				defer cancel()
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// deadlineStreamInterceptor enforces the configured default deadline on
+// streaming requests whose clients did not set one, bounding how long
+// abandoned attach streams and ingest writers can hold server resources.
+func deadlineStreamInterceptor(timeouts map[string]time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		if _, ok := ctx.Deadline(); !ok {
+			if d := requestTimeout(timeouts, info.FullMethod); d > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, d)
+				defer cancel()
+				ss = &deadlineServerStream{ServerStream: ss, ctx: ctx}
+			}
+		}
+		return handler(srv, ss)
+	}
+}
+
+type deadlineServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *deadlineServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,93 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestRequestTimeoutPrefixMatch(t *testing.T) {
+	timeouts := map[string]time.Duration{
+		"/containerd.services.content.v1.Content":       time.Minute,
+		"/containerd.services.content.v1.Content/Write": time.Hour,
+	}
+
+	for _, tc := range []struct {
+		method string
+		want   time.Duration
+	}{
+		{method: "/containerd.services.content.v1.Content/Write", want: time.Hour},
+		{method: "/containerd.services.content.v1.Content/Read", want: time.Minute},
+		{method: "/containerd.services.tasks.v1.Tasks/Get", want: 0},
+	} {
+		if got := requestTimeout(timeouts, tc.method); got != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.method, tc.want, got)
+		}
+	}
+}
+
+func TestDeadlineUnaryInterceptor(t *testing.T) {
+	interceptor := deadlineUnaryInterceptor(map[string]time.Duration{
+		"/containerd.services.content.v1.Content": time.Minute,
+	})
+
+	// A deadline is applied when the client did not set one.
+	_, err := interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/containerd.services.content.v1.Content/Read"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				t.Error("expected a deadline to be enforced")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A client supplied deadline is kept.
+	clientCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	clientDeadline, _ := clientCtx.Deadline()
+	_, err = interceptor(clientCtx, nil,
+		&grpc.UnaryServerInfo{FullMethod: "/containerd.services.content.v1.Content/Read"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			if deadline, _ := ctx.Deadline(); !deadline.Equal(clientDeadline) {
+				t.Error("expected the client deadline to be kept")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Unconfigured services are untouched.
+	_, err = interceptor(context.Background(), nil,
+		&grpc.UnaryServerInfo{FullMethod: "/containerd.services.tasks.v1.Tasks/Get"},
+		func(ctx context.Context, req interface{}) (interface{}, error) {
+			if _, ok := ctx.Deadline(); ok {
+				t.Error("expected no deadline for unconfigured service")
+			}
+			return nil, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"github.com/containerd/ttrpc"
 	"github.com/docker/go-metrics"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
+	"github.com/opencontainers/go-digest"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
@@ -54,6 +55,8 @@ import (
 	"github.com/containerd/containerd/v2/core/diff"
 	diffproxy "github.com/containerd/containerd/v2/core/diff/proxy"
 	sbproxy "github.com/containerd/containerd/v2/core/sandbox/proxy"
+	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/core/snapshots/probe"
 	ssproxy "github.com/containerd/containerd/v2/core/snapshots/proxy"
 	"github.com/containerd/containerd/v2/defaults"
 	"github.com/containerd/containerd/v2/pkg/deprecation"
@@ -152,15 +155,19 @@ func New(ctx context.Context, config *srvconfig.Config) (*Server, error) {
 	prometheusServerMetrics := grpc_prometheus.NewServerMetrics(prometheusServerMetricsOpts...)
 	prometheus.MustRegister(prometheusServerMetrics)
 
+	requestTimeouts := parseRequestTimeouts(ctx, config.RequestTimeouts)
+
 	serverOpts := []grpc.ServerOption{
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.ChainStreamInterceptor(
 			streamNamespaceInterceptor,
 			prometheusServerMetrics.StreamServerInterceptor(),
+			deadlineStreamInterceptor(requestTimeouts),
 		),
 		grpc.ChainUnaryInterceptor(
 			unaryNamespaceInterceptor,
 			prometheusServerMetrics.UnaryServerInterceptor(),
+			deadlineUnaryInterceptor(requestTimeouts),
 		),
 	}
 	if config.GRPC.MaxRecvMsgSize > 0 {
@@ -282,7 +289,14 @@ func New(ctx context.Context, config *srvconfig.Config) (*Server, error) {
 			}
 			initContext.Config = pc
 		}
+		initStart := time.Now()
 		result := p.Init(initContext)
+		// Record the init duration in the plugin exports so slow daemon
+		// startups are diagnosable through the introspection API.
+		if result.Meta.Exports == nil {
+			result.Meta.Exports = map[string]string{}
+		}
+		result.Meta.Exports["init_duration"] = time.Since(initStart).String()
 		if err := initialized.Add(result); err != nil {
 			return nil, fmt.Errorf("could not add plugin result to plugin set: %w", err)
 		}
@@ -332,6 +346,20 @@ func New(ctx context.Context, config *srvconfig.Config) (*Server, error) {
 			return nil, err
 		}
 	}
+	if len(config.GRPC.AdditionalSockets) > 0 {
+		extraServers, err := serveExtraSockets(ctx, config, serverOpts, func(srv *grpc.Server) error {
+			for _, service := range grpcServices {
+				if err := service.Register(srv); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		s.extraServers = extraServers
+		if err != nil {
+			return nil, err
+		}
+	}
 	for _, service := range ttrpcServices {
 		if err := service.RegisterTTRPC(ttrpcServer); err != nil {
 			return nil, err
@@ -377,6 +405,7 @@ type Server struct {
 	grpcServer              *grpc.Server
 	ttrpcServer             *ttrpc.Server
 	tcpServer               *grpc.Server
+	extraServers            []*grpc.Server
 	config                  *srvconfig.Config
 	plugins                 []*plugin.Plugin
 	ready                   sync.WaitGroup
@@ -431,6 +460,10 @@ func (s *Server) ServeDebug(l net.Listener) error {
 // Stop the containerd server canceling any open connections
 func (s *Server) Stop() {
 	s.grpcServer.Stop()
+	for _, srv := range s.extraServers {
+		srv.Stop()
+	}
+	s.shutdownTasks(context.Background())
 	for i := len(s.plugins) - 1; i >= 0; i-- {
 		p := s.plugins[i]
 		instance, err := p.Instance()
@@ -475,12 +508,57 @@ func LoadPlugins(ctx context.Context, config *srvconfig.Config) ([]plugin.Regist
 	}
 	// load additional plugins that don't automatically register themselves
 	registry.Register(&plugin.Registration{
-		Type: plugins.ContentPlugin,
-		ID:   "content",
+		Type:   plugins.ContentPlugin,
+		ID:     "content",
+		Config: &local.StoreConfig{},
 		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
 			root := ic.Properties[plugins.PropertyRootDir]
 			ic.Meta.Exports["root"] = root
-			return local.NewStore(root)
+			cfg := ic.Config.(*local.StoreConfig)
+			var opts []local.StoreOpt
+			if cfg.ShardPrefixLength > 0 {
+				opts = append(opts, local.WithShardPrefixLength(cfg.ShardPrefixLength))
+			}
+			if len(cfg.AcceptedDigestAlgorithms) > 0 {
+				algorithms := make([]digest.Algorithm, 0, len(cfg.AcceptedDigestAlgorithms))
+				for _, alg := range cfg.AcceptedDigestAlgorithms {
+					a := digest.Algorithm(alg)
+					if !a.Available() {
+						return nil, fmt.Errorf("accepted digest algorithm %q is not registered", alg)
+					}
+					algorithms = append(algorithms, a)
+				}
+				opts = append(opts, local.WithAcceptedAlgorithms(algorithms))
+			}
+			store, err := local.NewStore(root, opts...)
+			if err != nil {
+				return nil, err
+			}
+			if cfg.IngestSweepAge != "" {
+				age, err := time.ParseDuration(cfg.IngestSweepAge)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse ingest_sweep_age %q: %w", cfg.IngestSweepAge, err)
+				}
+				if sweeper, ok := store.(interface {
+					CleanupIngests(context.Context, time.Duration) (int64, error)
+				}); ok {
+					// Sweep stale ingests at startup and then
+					// periodically, reclaiming space from aborted pulls.
+					go func() {
+						for {
+							if _, err := sweeper.CleanupIngests(ic.Context, age); err != nil {
+								log.G(ic.Context).WithError(err).Error("ingest sweep failed")
+							}
+							select {
+							case <-time.After(age / 2):
+							case <-ic.Context.Done():
+								return
+							}
+						}
+					}()
+				}
+			}
+			return store, nil
 		},
 	})
 
@@ -547,7 +625,17 @@ func LoadPlugins(ctx context.Context, config *srvconfig.Config) ([]plugin.Regist
 				if err != nil {
 					return nil, err
 				}
-				return f(conn), nil
+				instance := f(conn)
+				// Probe proxy snapshotters up front so consumers can
+				// pick fallbacks instead of failing at create time.
+				if sn, ok := instance.(snapshots.Snapshotter); ok {
+					caps := probe.Probe(ic.Context, name, sn)
+					ic.Meta.Exports["capabilities"] = caps.Export()
+					if !caps.Responsive {
+						log.G(ic.Context).Warnf("proxy snapshotter %q did not respond to capability probe", name)
+					}
+				}
+				return instance, nil
 			},
 		})
 
@@ -0,0 +1,219 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/containerd/log"
+
+	corert "github.com/containerd/containerd/v2/core/runtime"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+)
+
+const (
+	// ShutdownPolicyLeaveRunning leaves shims and tasks running on shutdown
+	// so they can be reconnected when the daemon restarts. This is the
+	// default policy.
+	ShutdownPolicyLeaveRunning = "leave-running"
+	// ShutdownPolicySigterm sends SIGTERM to all tasks on shutdown and
+	// waits up to the configured grace period for them to exit.
+	ShutdownPolicySigterm = "sigterm"
+	// ShutdownPolicyCheckpoint checkpoints tasks on shutdown where the
+	// runtime supports it, leaving tasks running when checkpointing fails.
+	ShutdownPolicyCheckpoint = "checkpoint"
+	// ShutdownPolicyTeardown asks every shim to shut down, tearing down
+	// its containers with it.
+	ShutdownPolicyTeardown = "teardown"
+
+	// defaultShutdownGracePeriod is used for the "sigterm" policy when no
+	// grace period has been configured.
+	defaultShutdownGracePeriod = 10 * time.Second
+)
+
+// taskShutdowner is implemented by runtimes that can shut down their
+// shims, optionally preserving running containers for re-parenting.
+type taskShutdowner interface {
+	Shutdown(ctx context.Context, preserve bool)
+}
+
+// shutdownTasks applies the configured shutdown policy to all running tasks
+// before the daemon exits. Progress is logged per task, and the configured
+// deadline bounds the total time spent regardless of policy.
+func (s *Server) shutdownTasks(ctx context.Context) {
+	cfg := s.config.Shutdown
+	if cfg.Policy == "" && len(cfg.NamespacePolicies) == 0 {
+		return
+	}
+
+	if cfg.Deadline != "" {
+		deadline, err := time.ParseDuration(cfg.Deadline)
+		if err != nil {
+			log.G(ctx).WithError(err).Errorf("failed to parse shutdown deadline %q", cfg.Deadline)
+		} else {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, deadline)
+			defer cancel()
+		}
+	}
+
+	gracePeriod := defaultShutdownGracePeriod
+	if cfg.GracePeriod != "" {
+		period, err := time.ParseDuration(cfg.GracePeriod)
+		if err != nil {
+			log.G(ctx).WithError(err).Errorf("failed to parse shutdown grace_period %q", cfg.GracePeriod)
+		} else {
+			gracePeriod = period
+		}
+	}
+
+	for _, p := range s.plugins {
+		instance, err := p.Instance()
+		if err != nil {
+			continue
+		}
+		rt, ok := instance.(corert.PlatformRuntime)
+		if !ok {
+			continue
+		}
+		tasks, err := rt.Tasks(ctx, true)
+		if err != nil {
+			log.G(ctx).WithError(err).Errorf("failed to list tasks for runtime %q during shutdown", rt.ID())
+			continue
+		}
+		for _, t := range tasks {
+			policy := cfg.Policy
+			if override, ok := cfg.NamespacePolicies[t.Namespace()]; ok {
+				policy = override
+			}
+			if ctx.Err() != nil {
+				log.G(ctx).Warnf("shutdown deadline exceeded, leaving task %q running", t.ID())
+				continue
+			}
+			nsCtx := namespaces.WithNamespace(ctx, t.Namespace())
+			switch policy {
+			case ShutdownPolicySigterm:
+				s.sigtermTask(nsCtx, t, gracePeriod)
+			case ShutdownPolicyCheckpoint:
+				s.checkpointTask(nsCtx, t)
+			case ShutdownPolicyTeardown:
+				if cfg.Policy == ShutdownPolicyTeardown {
+					// The runtime-level shutdown below tears down every
+					// shim of this runtime in one pass.
+					break
+				}
+				// Namespace-scoped override: tear down just this task.
+				s.teardownTask(nsCtx, rt, t)
+			default:
+				log.G(ctx).Debugf("leaving task %q in namespace %q running on shutdown", t.ID(), t.Namespace())
+			}
+		}
+
+		ts, ok := instance.(taskShutdowner)
+		if !ok {
+			continue
+		}
+		switch cfg.Policy {
+		case ShutdownPolicyTeardown:
+			log.G(ctx).Infof("tearing down shims of runtime %q on shutdown", rt.ID())
+			ts.Shutdown(ctx, false)
+		case ShutdownPolicyLeaveRunning:
+			// Disconnect from shims without shutting them down so a
+			// restarted daemon re-parents the running containers.
+			ts.Shutdown(ctx, true)
+		}
+	}
+}
+
+// teardownTask kills and deletes a single task, implementing the
+// "teardown" policy for namespace overrides where only part of a
+// runtime's tasks are torn down while the rest keep running.
+func (s *Server) teardownTask(ctx context.Context, rt corert.PlatformRuntime, t corert.Task) {
+	log.G(ctx).Infof("tearing down task %q in namespace %q on shutdown", t.ID(), t.Namespace())
+	if err := t.Kill(ctx, uint32(syscall.SIGKILL), true); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to kill task %q on shutdown", t.ID())
+		return
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		state, err := t.State(waitCtx)
+		if err != nil || state.Status == corert.StoppedStatus {
+			break
+		}
+		select {
+		case <-waitCtx.Done():
+		case <-ticker.C:
+			continue
+		}
+		break
+	}
+	if _, err := rt.Delete(ctx, t.ID()); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to delete task %q on shutdown", t.ID())
+	}
+}
+
+// sigtermTask sends SIGTERM to all processes of the task and waits up to
+// the grace period for the task to stop.
+func (s *Server) sigtermTask(ctx context.Context, t corert.Task, gracePeriod time.Duration) {
+	log.G(ctx).Infof("sending SIGTERM to task %q in namespace %q on shutdown", t.ID(), t.Namespace())
+	if err := t.Kill(ctx, uint32(syscall.SIGTERM), true); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to signal task %q on shutdown", t.ID())
+		return
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, gracePeriod)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		state, err := t.State(waitCtx)
+		if err == nil && state.Status == corert.StoppedStatus {
+			log.G(ctx).Infof("task %q exited on shutdown", t.ID())
+			return
+		}
+		select {
+		case <-waitCtx.Done():
+			log.G(ctx).Warnf("task %q did not exit within the shutdown grace period %s", t.ID(), gracePeriod)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkpointTask checkpoints the task into the daemon state directory,
+// leaving the task running when the runtime does not support checkpointing.
+func (s *Server) checkpointTask(ctx context.Context, t corert.Task) {
+	dir := filepath.Join(s.config.State, "shutdown-checkpoints", t.Namespace())
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to create shutdown checkpoint directory for task %q", t.ID())
+		return
+	}
+	path := filepath.Join(dir, t.ID())
+	log.G(ctx).Infof("checkpointing task %q in namespace %q to %q on shutdown", t.ID(), t.Namespace(), path)
+	if err := t.Checkpoint(ctx, path, nil); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to checkpoint task %q on shutdown, leaving it running", t.ID())
+		return
+	}
+	log.G(ctx).Infof("checkpointed task %q on shutdown", t.ID())
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/containerd/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	srvconfig "github.com/containerd/containerd/v2/cmd/containerd/server/config"
+	"github.com/containerd/containerd/v2/pkg/sys"
+)
+
+// restrictionInterceptors rejects calls to services not on the allowed
+// list with PermissionDenied, so a socket can expose only a subset of the
+// API, e.g. introspection for monitoring agents or CRI for the kubelet.
+func restrictionInterceptors(allowed []string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	permitted := func(fullMethod string) bool {
+		if len(allowed) == 0 {
+			return true
+		}
+		for _, prefix := range allowed {
+			if !strings.HasPrefix(prefix, "/") {
+				prefix = "/" + prefix
+			}
+			if strings.HasPrefix(fullMethod, prefix) {
+				return true
+			}
+		}
+		return false
+	}
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !permitted(info.FullMethod) {
+			return nil, status.Errorf(codes.PermissionDenied, "method %s is not served on this socket", info.FullMethod)
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !permitted(info.FullMethod) {
+			return status.Errorf(codes.PermissionDenied, "method %s is not served on this socket", info.FullMethod)
+		}
+		return handler(srv, ss)
+	}
+	return unary, stream
+}
+
+// serveExtraSockets starts the additional restricted sockets from the
+// configuration. Each socket gets its own gRPC server with every service
+// registered, guarded by the socket's service allowlist.
+func serveExtraSockets(ctx context.Context, config *srvconfig.Config, baseOpts []grpc.ServerOption, register func(*grpc.Server) error) ([]*grpc.Server, error) {
+	var servers []*grpc.Server
+	for _, socket := range config.GRPC.AdditionalSockets {
+		unary, stream := restrictionInterceptors(socket.AllowedServices)
+		opts := append(append([]grpc.ServerOption{}, baseOpts...),
+			grpc.ChainUnaryInterceptor(unary),
+			grpc.ChainStreamInterceptor(stream),
+		)
+		srv := grpc.NewServer(opts...)
+		if err := register(srv); err != nil {
+			return servers, err
+		}
+
+		l, err := sys.GetLocalListener(socket.Address, socket.UID, socket.GID)
+		if err != nil {
+			return servers, err
+		}
+		log.G(ctx).WithField("address", socket.Address).Info("serving additional API socket")
+		go func(address string) {
+			if err := trapClosedConnErr(srv.Serve(l)); err != nil {
+				log.G(ctx).WithError(err).Errorf("serving socket %s", address)
+			}
+		}(socket.Address)
+		servers = append(servers, srv)
+	}
+	return servers, nil
+}
@@ -24,10 +24,13 @@ import (
 	"github.com/urfave/cli/v2"
 	"google.golang.org/grpc/grpclog"
 
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/completion"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/containers"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/content"
+	criCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/cri"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/deprecations"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/events"
+	gcCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/gc"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/images"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/info"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/install"
@@ -39,8 +42,10 @@ import (
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/run"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/sandboxes"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/snapshots"
+	streamprocessorsCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/streamprocessors"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands/tasks"
 	versionCmd "github.com/containerd/containerd/v2/cmd/ctr/commands/version"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands/volumes"
 	"github.com/containerd/containerd/v2/defaults"
 	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/version"
@@ -70,6 +75,7 @@ func init() {
 // New returns a *cli.App instance.
 func New() *cli.App {
 	app := cli.NewApp()
+	app.EnableBashCompletion = true
 	app.Name = "ctr"
 	app.Version = version.Version
 	app.Description = `
@@ -134,6 +140,11 @@ containerd CLI
 		sandboxes.Command,
 		info.Command,
 		deprecations.Command,
+		criCmd.Command,
+		completion.Command,
+		volumes.Command,
+		streamprocessorsCmd.Command,
+		gcCmd.Command,
 	}, extraCmds...)
 	app.Before = func(cliContext *cli.Context) error {
 		if cliContext.Bool("debug") {
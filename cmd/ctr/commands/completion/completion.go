@@ -0,0 +1,94 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package completion
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// bashCompletion drives bash completion through ctr's hidden
+// --generate-bash-completion flag provided by urfave/cli.
+const bashCompletion = `#!/usr/bin/env bash
+_ctr_completion() {
+    local cur opts
+    COMPREPLY=()
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    if [[ "$cur" == "-"* ]]; then
+        opts=$("${COMP_WORDS[@]:0:COMP_CWORD}" "$cur" --generate-bash-completion)
+    else
+        opts=$("${COMP_WORDS[@]:0:COMP_CWORD}" --generate-bash-completion)
+    fi
+    COMPREPLY=($(compgen -W "$opts" -- "$cur"))
+    return 0
+}
+complete -o bashdefault -o default -o nospace -F _ctr_completion ctr
+`
+
+// zshCompletion reuses the same hidden flag through zsh's bashcompinit
+// style wrapper.
+const zshCompletion = `#compdef ctr
+_ctr() {
+    local -a opts
+    local current
+    current=${words[-1]}
+    if [[ "$current" == "-"* ]]; then
+        opts=("${(@f)$(${words[@]:0:#words[@]-1} "$current" --generate-bash-completion)}")
+    else
+        opts=("${(@f)$(${words[@]:0:#words[@]-1} --generate-bash-completion)}")
+    fi
+    if [[ ${#opts[@]} -ne 0 ]]; then
+        _describe 'values' opts
+    else
+        _files
+    fi
+}
+compdef _ctr ctr
+`
+
+// Command outputs shell completion scripts
+var Command = &cli.Command{
+	Name:      "completion",
+	Usage:     "Output shell completion script for bash, zsh or fish",
+	ArgsUsage: "<bash|zsh|fish>",
+	Description: `Output a completion script for the given shell.
+
+Load it with e.g.:
+
+	source <(ctr completion bash)
+	ctr completion fish > ~/.config/fish/completions/ctr.fish`,
+	Action: func(cliContext *cli.Context) error {
+		switch shell := cliContext.Args().First(); shell {
+		case "bash":
+			fmt.Print(bashCompletion)
+			return nil
+		case "zsh":
+			fmt.Print(zshCompletion)
+			return nil
+		case "fish":
+			s, err := cliContext.App.ToFishCompletion()
+			if err != nil {
+				return err
+			}
+			fmt.Print(s)
+			return nil
+		default:
+			return fmt.Errorf("unsupported shell %q, expected bash, zsh or fish", shell)
+		}
+	},
+}
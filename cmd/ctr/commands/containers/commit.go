@@ -0,0 +1,234 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package containers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/pkg/rootfs"
+)
+
+var commitCommand = &cli.Command{
+	Name:      "commit",
+	Usage:     "Commit a container's rootfs changes into a new image",
+	ArgsUsage: "[flags] CONTAINER REF",
+	Description: `Commit diffs the container's snapshot against its parent, assembles a
+new layer and manifest with the requested config mutations and registers
+the result in the image store, ready for push.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "author",
+			Usage: "Author recorded in the new layer's history",
+		},
+		&cli.StringFlag{
+			Name:  "message",
+			Usage: "Commit message recorded in the new layer's history",
+		},
+		&cli.StringSliceFlag{
+			Name:  "entrypoint",
+			Usage: "Replace the image entrypoint",
+		},
+		&cli.StringSliceFlag{
+			Name:  "cmd",
+			Usage: "Replace the image cmd",
+		},
+		&cli.StringSliceFlag{
+			Name:  "env",
+			Usage: "Append environment variables to the image config",
+		},
+		&cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Set labels on the image config",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		var (
+			id  = cliContext.Args().Get(0)
+			ref = cliContext.Args().Get(1)
+		)
+		if id == "" || ref == "" {
+			return fmt.Errorf("container id and target image ref must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		ctx, done, err := client.WithLease(ctx)
+		if err != nil {
+			return err
+		}
+		defer done(ctx)
+
+		container, err := client.LoadContainer(ctx, id)
+		if err != nil {
+			return err
+		}
+		info, err := container.Info(ctx)
+		if err != nil {
+			return err
+		}
+		if info.SnapshotKey == "" {
+			return fmt.Errorf("container %q has no snapshot to commit", id)
+		}
+		if info.Image == "" {
+			return fmt.Errorf("container %q has no base image", id)
+		}
+
+		img, err := client.GetImage(ctx, info.Image)
+		if err != nil {
+			return fmt.Errorf("failed to get base image %q: %w", info.Image, err)
+		}
+		cs := client.ContentStore()
+
+		manifest, err := images.Manifest(ctx, cs, img.Target(), platforms.Default())
+		if err != nil {
+			return fmt.Errorf("failed to get base manifest: %w", err)
+		}
+		configBlob, err := content.ReadBlob(ctx, cs, manifest.Config)
+		if err != nil {
+			return fmt.Errorf("failed to read base image config: %w", err)
+		}
+		var config ocispec.Image
+		if err := json.Unmarshal(configBlob, &config); err != nil {
+			return fmt.Errorf("failed to unmarshal base image config: %w", err)
+		}
+
+		// Diff the container snapshot against its parent into a new layer.
+		sn := client.SnapshotService(info.Snapshotter)
+		layerDesc, err := rootfs.CreateDiff(ctx, info.SnapshotKey, sn, client.DiffService())
+		if err != nil {
+			return fmt.Errorf("failed to diff container snapshot: %w", err)
+		}
+		diffID, err := images.GetDiffID(ctx, cs, layerDesc)
+		if err != nil {
+			return fmt.Errorf("failed to get diff ID of new layer: %w", err)
+		}
+
+		mutateConfig(&config, diffID, cliContext)
+
+		newManifest, err := writeImage(ctx, cs, manifest, config, layerDesc)
+		if err != nil {
+			return err
+		}
+
+		image := images.Image{
+			Name:      ref,
+			Target:    newManifest,
+			CreatedAt: time.Now(),
+			Labels: images.WithProvenance(nil, images.Provenance{
+				Source: "commit",
+				Ref:    info.Image,
+			}),
+		}
+		if _, err := client.ImageService().Create(ctx, image); err != nil {
+			if _, err := client.ImageService().Update(ctx, image); err != nil {
+				return fmt.Errorf("failed to register image %q: %w", ref, err)
+			}
+		}
+		fmt.Fprintln(cliContext.App.Writer, newManifest.Digest.String())
+		return nil
+	},
+}
+
+// mutateConfig applies the new layer and the requested config mutations to
+// the base image config.
+func mutateConfig(config *ocispec.Image, diffID digest.Digest, cliContext *cli.Context) {
+	now := time.Now()
+	config.Created = &now
+	config.RootFS.DiffIDs = append(config.RootFS.DiffIDs, diffID)
+	config.History = append(config.History, ocispec.History{
+		Created:   &now,
+		CreatedBy: "ctr containers commit",
+		Author:    cliContext.String("author"),
+		Comment:   cliContext.String("message"),
+	})
+	if config.Author == "" {
+		config.Author = cliContext.String("author")
+	}
+	if entrypoint := cliContext.StringSlice("entrypoint"); len(entrypoint) > 0 {
+		config.Config.Entrypoint = entrypoint
+	}
+	if cmd := cliContext.StringSlice("cmd"); len(cmd) > 0 {
+		config.Config.Cmd = cmd
+	}
+	config.Config.Env = append(config.Config.Env, cliContext.StringSlice("env")...)
+	if labels := cliContext.StringSlice("label"); len(labels) > 0 {
+		if config.Config.Labels == nil {
+			config.Config.Labels = map[string]string{}
+		}
+		for k, v := range commands.LabelArgs(labels) {
+			config.Config.Labels[k] = v
+		}
+	}
+}
+
+// writeImage writes the mutated config and the new manifest to the content
+// store, returning the manifest descriptor.
+func writeImage(ctx context.Context, cs content.Store, base ocispec.Manifest, config ocispec.Image, layerDesc ocispec.Descriptor) (ocispec.Descriptor, error) {
+	configBlob, err := json.Marshal(config)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to marshal new config: %w", err)
+	}
+	configDesc := ocispec.Descriptor{
+		MediaType: base.Config.MediaType,
+		Digest:    digest.FromBytes(configBlob),
+		Size:      int64(len(configBlob)),
+	}
+	if err := content.WriteBlob(ctx, cs, "commit-config-"+configDesc.Digest.String(), bytes.NewReader(configBlob), configDesc); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to write new config: %w", err)
+	}
+
+	manifest := base
+	manifest.Config = configDesc
+	manifest.Layers = append(manifest.Layers, layerDesc)
+	manifestBlob, err := json.Marshal(manifest)
+	if err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to marshal new manifest: %w", err)
+	}
+	manifestDesc := ocispec.Descriptor{
+		MediaType: manifest.MediaType,
+		Digest:    digest.FromBytes(manifestBlob),
+		Size:      int64(len(manifestBlob)),
+	}
+
+	// Reference the config and layers from the manifest so they are not
+	// garbage collected from the content store.
+	labels := map[string]string{
+		"containerd.io/gc.ref.content.config": configDesc.Digest.String(),
+	}
+	for i, layer := range manifest.Layers {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.l.%d", i)] = layer.Digest.String()
+	}
+	if err := content.WriteBlob(ctx, cs, "commit-manifest-"+manifestDesc.Digest.String(), bytes.NewReader(manifestBlob), manifestDesc, content.WithLabels(labels)); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to write new manifest: %w", err)
+	}
+	return manifestDesc, nil
+}
@@ -40,6 +40,7 @@ var Command = &cli.Command{
 	Usage:   "Manage containers",
 	Aliases: []string{"c", "container"},
 	Subcommands: []*cli.Command{
+		commitCommand,
 		createCommand,
 		deleteCommand,
 		infoCommand,
@@ -101,6 +102,7 @@ var listCommand = &cli.Command{
 			Aliases: []string{"q"},
 			Usage:   "Print only the container id",
 		},
+		commands.FormatFlag,
 	},
 	Action: func(cliContext *cli.Context) error {
 		var (
@@ -116,6 +118,18 @@ var listCommand = &cli.Command{
 		if err != nil {
 			return err
 		}
+		if format := cliContext.String("format"); format != "" {
+			infos := make([]any, 0, len(containers))
+			for _, c := range containers {
+				info, err := c.Info(ctx, containerd.WithoutRefreshedMetadata)
+				if err != nil {
+					return err
+				}
+				infos = append(infos, info)
+			}
+			_, err := commands.PrintFormatted(format, infos)
+			return err
+		}
 		if quiet {
 			for _, c := range containers {
 				fmt.Printf("%s\n", c.ID())
@@ -54,6 +54,7 @@ var (
 			ingestCommand,
 			listCommand,
 			pushObjectCommand,
+			refsCommand,
 			setLabelsCommand,
 			pruneCommand,
 		},
@@ -0,0 +1,130 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package content
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+)
+
+var refsCommand = &cli.Command{
+	Name:      "refs",
+	Usage:     "Display the holders referencing a blob",
+	ArgsUsage: "[flags] <digest>",
+	Description: `Display what keeps a blob alive: images whose manifest graph contains
+the blob, other content referencing it through gc.ref labels, and leases
+holding it.`,
+	Action: func(cliContext *cli.Context) error {
+		dgst, err := digest.Parse(cliContext.Args().First())
+		if err != nil {
+			return err
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		cs := client.ContentStore()
+		if _, err := cs.Info(ctx, dgst); err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 4, 8, 4, ' ', 0)
+		fmt.Fprintln(w, "TYPE\tHOLDER\t")
+
+		// Images whose manifest graph contains the blob.
+		imageList, err := client.ImageService().List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, img := range imageList {
+			ok, err := imageReferences(ctx, cs, img.Target, dgst)
+			if err != nil {
+				return err
+			}
+			if ok {
+				fmt.Fprintf(w, "image\t%s\t\n", img.Name)
+			}
+		}
+
+		// Content referencing the blob through gc.ref labels.
+		if err := cs.Walk(ctx, func(info content.Info) error {
+			for k, v := range info.Labels {
+				if strings.HasPrefix(k, "containerd.io/gc.ref.") && v == dgst.String() {
+					fmt.Fprintf(w, "content\t%s (%s)\t\n", info.Digest, k)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		// Leases holding the blob.
+		ls := client.LeasesService()
+		leaseList, err := ls.List(ctx)
+		if err != nil {
+			return err
+		}
+		for _, lease := range leaseList {
+			resources, err := ls.ListResources(ctx, lease)
+			if err != nil {
+				return err
+			}
+			for _, r := range resources {
+				if r.Type == "content" && r.ID == dgst.String() {
+					fmt.Fprintf(w, "lease\t%s\t\n", lease.ID)
+				}
+			}
+		}
+
+		return w.Flush()
+	},
+}
+
+// imageReferences reports whether the image target's descriptor graph
+// contains the digest.
+func imageReferences(ctx context.Context, cs content.Store, target ocispec.Descriptor, dgst digest.Digest) (bool, error) {
+	if target.Digest == dgst {
+		return true, nil
+	}
+	children, err := images.Children(ctx, cs, target)
+	if err != nil {
+		return false, nil
+	}
+	for _, child := range children {
+		ok, err := imageReferences(ctx, cs, child, dgst)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,96 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package cri
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/internal/cri/server"
+)
+
+// Command is the parent for all commands under "cri"
+var Command = &cli.Command{
+	Name:  "cri",
+	Usage: "Manage the CRI plugin",
+	Subcommands: []*cli.Command{
+		drainCommand,
+		resumeCommand,
+		drainStatusCommand,
+	},
+}
+
+var drainCommand = &cli.Command{
+	Name:  "drain",
+	Usage: "Put the CRI plugin into drain mode, rejecting new pod sandboxes",
+	Action: func(cliContext *cli.Context) error {
+		return setDrain(cliContext, true)
+	},
+}
+
+var resumeCommand = &cli.Command{
+	Name:  "resume",
+	Usage: "Take the CRI plugin out of drain mode",
+	Action: func(cliContext *cli.Context) error {
+		return setDrain(cliContext, false)
+	},
+}
+
+var drainStatusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "Print whether the CRI plugin is in drain mode",
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		conn, ok := client.Conn().(*grpc.ClientConn)
+		if !ok {
+			return errors.New("drain service requires a grpc connection")
+		}
+		resp := &wrapperspb.BoolValue{}
+		if err := conn.Invoke(ctx, "/"+server.DrainServiceName+"/Status", &emptypb.Empty{}, resp); err != nil {
+			return err
+		}
+		if resp.GetValue() {
+			fmt.Println("draining")
+		} else {
+			fmt.Println("active")
+		}
+		return nil
+	},
+}
+
+func setDrain(cliContext *cli.Context, draining bool) error {
+	client, ctx, cancel, err := commands.NewClient(cliContext)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	conn, ok := client.Conn().(*grpc.ClientConn)
+	if !ok {
+		return errors.New("drain service requires a grpc connection")
+	}
+	return conn.Invoke(ctx, "/"+server.DrainServiceName+"/Set", wrapperspb.Bool(draining), &emptypb.Empty{})
+}
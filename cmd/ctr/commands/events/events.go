@@ -17,11 +17,18 @@
 package events
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
 	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/containerd/v2/pkg/filters"
 	"github.com/containerd/log"
 	"github.com/containerd/typeurl/v2"
 	"github.com/urfave/cli/v2"
@@ -35,44 +42,248 @@ var Command = &cli.Command{
 	Name:    "events",
 	Aliases: []string{"event"},
 	Usage:   "Display containerd events",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "filter",
+			Usage: "Event filters, applied server-side for the live tail and to replayed events",
+		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: `Output format: "text" or "json" (NDJSON, one envelope per line)`,
+			Value: "text",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Replay events from the durable journal since the given RFC3339 time or duration ago (e.g. 1h)",
+		},
+		&cli.StringFlag{
+			Name:  "until",
+			Usage: "Stop after the given RFC3339 time or duration from now; bounds both replay and live tail",
+		},
+		&cli.StringFlag{
+			Name:  "journal",
+			Usage: "Directory holding the durable NDJSON event journal used for replay; point it at the event-outbox plugin's journal_dir",
+		},
+	},
 	Action: func(cliContext *cli.Context) error {
+		var (
+			format      = cliContext.String("format")
+			filterArgs  = cliContext.StringSlice("filter")
+			since       time.Time
+			until       time.Time
+			err         error
+			eventFilter filters.Filter = filters.Always
+		)
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unsupported format %q", format)
+		}
+		if len(filterArgs) == 0 {
+			// Backwards compatible positional filters.
+			filterArgs = cliContext.Args().Slice()
+		}
+		if len(filterArgs) > 0 {
+			if eventFilter, err = filters.ParseAll(filterArgs...); err != nil {
+				return err
+			}
+		}
+		if v := cliContext.String("since"); v != "" {
+			if since, err = parseTimeRef(v); err != nil {
+				return fmt.Errorf("invalid --since: %w", err)
+			}
+		}
+		if v := cliContext.String("until"); v != "" {
+			if until, err = parseTimeRef(v); err != nil {
+				return fmt.Errorf("invalid --until: %w", err)
+			}
+		}
+
+		// Replay from the durable journal first when requested.
+		if !since.IsZero() {
+			journal := cliContext.String("journal")
+			if journal == "" {
+				return fmt.Errorf("--since replays the durable journal: set --journal to the event-outbox plugin's journal_dir")
+			}
+			if err := replayJournal(journal, since, until, eventFilter, format); err != nil {
+				return err
+			}
+			// A fully historic window needs no live tail.
+			if !until.IsZero() && until.Before(time.Now()) {
+				return nil
+			}
+		}
+
 		client, ctx, cancel, err := commands.NewClient(cliContext)
 		if err != nil {
 			return err
 		}
 		defer cancel()
+		if !until.IsZero() {
+			var untilCancel context.CancelFunc
+			ctx, untilCancel = context.WithDeadline(ctx, until)
+			defer untilCancel()
+		}
 		eventsClient := client.EventService()
-		eventsCh, errCh := eventsClient.Subscribe(ctx, cliContext.Args().Slice()...)
+		eventsCh, errCh := eventsClient.Subscribe(ctx, filterArgs...)
 		for {
 			var e *events.Envelope
 			select {
 			case e = <-eventsCh:
 			case err = <-errCh:
+				if ctx.Err() != nil {
+					// The --until deadline ended the tail.
+					return nil
+				}
 				return err
 			}
 			if e != nil {
-				var out []byte
-				if e.Event != nil {
-					v, err := typeurl.UnmarshalAny(e.Event)
-					if err != nil {
-						log.G(ctx).WithError(err).Warn("cannot unmarshal an event from Any")
-						continue
-					}
-					out, err = json.Marshal(v)
-					if err != nil {
-						log.G(ctx).WithError(err).Warn("cannot marshal Any into JSON")
-						continue
-					}
-				}
-				if _, err := fmt.Println(
-					e.Timestamp,
-					e.Namespace,
-					e.Topic,
-					string(out),
-				); err != nil {
+				if err := printEnvelope(e, format); err != nil {
 					return err
 				}
 			}
 		}
 	},
 }
+
+// journalRecord is one line of the durable NDJSON event journal, matching
+// the payload format emitted by the event outbox sink.
+type journalRecord struct {
+	Timestamp time.Time `json:"timestamp"`
+	Namespace string    `json:"namespace"`
+	Topic     string    `json:"topic"`
+	TypeURL   string    `json:"typeUrl,omitempty"`
+	Value     []byte    `json:"value,omitempty"`
+}
+
+// journalPayload carries a replayed event payload into typeurl decoding
+// without re-registering its type.
+type journalPayload struct {
+	typeURL string
+	value   []byte
+}
+
+func (p *journalPayload) GetTypeUrl() string { return p.typeURL }
+func (p *journalPayload) GetValue() []byte   { return p.value }
+
+var _ typeurl.Any = (*journalPayload)(nil)
+
+// replayJournal prints journal events within the time bounds, decoding
+// payloads through their recorded type URL like the live tail does.
+func replayJournal(dir string, since, until time.Time, filter filters.Filter, format string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no durable event journal at %q, replay unavailable", dir)
+		}
+		return err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for scanner.Scan() {
+			var r journalRecord
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				continue
+			}
+			if r.Timestamp.Before(since) {
+				continue
+			}
+			if !until.IsZero() && r.Timestamp.After(until) {
+				continue
+			}
+			envelope := &events.Envelope{
+				Timestamp: r.Timestamp,
+				Namespace: r.Namespace,
+				Topic:     r.Topic,
+			}
+			if !filter.Match(filters.AdapterFunc(envelope.Field)) {
+				continue
+			}
+			if format == "json" {
+				// The raw record is lossless even for payload types
+				// this build does not register.
+				line, err := json.Marshal(r)
+				if err != nil {
+					continue
+				}
+				fmt.Println(string(line))
+				continue
+			}
+			if r.TypeURL != "" {
+				envelope.Event = &journalPayload{typeURL: r.TypeURL, value: r.Value}
+			}
+			if err := printEnvelope(envelope, "text"); err != nil {
+				return err
+			}
+		}
+		f.Close()
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printEnvelope(e *events.Envelope, format string) error {
+	var (
+		v   interface{}
+		out []byte
+		err error
+	)
+	if e.Event != nil {
+		if v, err = typeurl.UnmarshalAny(e.Event); err != nil {
+			log.L.WithError(err).Warn("cannot unmarshal an event from Any")
+			return nil
+		}
+		if out, err = json.Marshal(v); err != nil {
+			log.L.WithError(err).Warn("cannot marshal Any into JSON")
+			return nil
+		}
+	}
+	if format == "json" {
+		line, err := json.Marshal(struct {
+			Timestamp time.Time       `json:"timestamp"`
+			Namespace string          `json:"namespace"`
+			Topic     string          `json:"topic"`
+			Event     json.RawMessage `json:"event,omitempty"`
+		}{
+			Timestamp: e.Timestamp,
+			Namespace: e.Namespace,
+			Topic:     e.Topic,
+			Event:     out,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Println(string(line))
+		return err
+	}
+	_, err = fmt.Println(e.Timestamp, e.Namespace, e.Topic, string(out))
+	return err
+}
+
+// parseTimeRef parses an absolute RFC3339 time or a duration relative to
+// now ("1h" means one hour ago for --since and one hour from now for
+// --until; the caller interprets the direction).
+func parseTimeRef(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected RFC3339 time or duration: %w", err)
+	}
+	return time.Now().Add(-d), nil
+}
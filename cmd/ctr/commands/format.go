@@ -0,0 +1,70 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/urfave/cli/v2"
+)
+
+// FormatFlag requests machine readable output from list and inspect
+// commands, either "json" or a Go template such as "go-template={{.ID}}".
+var FormatFlag = &cli.StringFlag{
+	Name:  "format",
+	Usage: `Output format: "json" or "go-template=<template>"`,
+}
+
+// PrintFormatted renders x with the format requested through FormatFlag.
+// It reports false when no machine readable format was requested, so
+// callers fall back to their default table output. Go templates are
+// executed once per element when x is a slice, one line per element.
+func PrintFormatted(format string, x interface{}) (bool, error) {
+	switch {
+	case format == "" || format == "table":
+		return false, nil
+	case format == "json":
+		PrintAsJSON(x)
+		return true, nil
+	case strings.HasPrefix(format, "go-template="):
+		tmpl, err := template.New("format").Parse(strings.TrimPrefix(format, "go-template="))
+		if err != nil {
+			return true, fmt.Errorf("failed to parse go-template: %w", err)
+		}
+		v := reflect.ValueOf(x)
+		if v.Kind() == reflect.Slice {
+			for i := 0; i < v.Len(); i++ {
+				if err := tmpl.Execute(os.Stdout, v.Index(i).Interface()); err != nil {
+					return true, err
+				}
+				fmt.Println()
+			}
+			return true, nil
+		}
+		if err := tmpl.Execute(os.Stdout, x); err != nil {
+			return true, err
+		}
+		fmt.Println()
+		return true, nil
+	default:
+		return true, fmt.Errorf(`unsupported format %q, expected "json" or "go-template=<template>"`, format)
+	}
+}
@@ -0,0 +1,210 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/urfave/cli/v2"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/snapshots"
+)
+
+// Command is the parent for garbage collection debugging commands
+var Command = &cli.Command{
+	Name:  "gc",
+	Usage: "Garbage collection debugging",
+	Subcommands: []*cli.Command{
+		whyCommand,
+	},
+}
+
+var whyCommand = &cli.Command{
+	Name:      "why",
+	Usage:     "Explain why a resource is not collectable",
+	ArgsUsage: `<digest> | snapshots/<snapshotter>/<key>`,
+	Description: `Trace the roots keeping a piece of content or a snapshot alive: images
+whose graph reaches it, containers using it, leases holding it and GC
+labels pinning it. If nothing is printed, nothing containerd knows about
+references the resource and the next GC pass may collect it.`,
+	Action: func(cliContext *cli.Context) error {
+		resource := cliContext.Args().First()
+		if resource == "" {
+			return errors.New("a content digest or snapshot reference must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		if strings.HasPrefix(resource, "snapshots/") {
+			parts := strings.SplitN(resource, "/", 3)
+			if len(parts) != 3 {
+				return errors.New(`expected snapshot reference "snapshots/<snapshotter>/<key>"`)
+			}
+			return explainSnapshot(ctx, client, parts[1], parts[2])
+		}
+		dgst, err := digest.Parse(resource)
+		if err != nil {
+			return fmt.Errorf("expected a content digest or snapshots/... reference: %w", err)
+		}
+		return explainContent(ctx, client, dgst)
+	},
+}
+
+func explainContent(ctx context.Context, client *containerd.Client, dgst digest.Digest) error {
+	cs := client.ContentStore()
+	info, err := cs.Info(ctx, dgst)
+	if err != nil {
+		return err
+	}
+
+	// GC labels on the blob itself.
+	for k, v := range info.Labels {
+		if strings.HasPrefix(k, "containerd.io/gc.root") {
+			fmt.Printf("pinned by gc root label %s=%s\n", k, v)
+		}
+	}
+
+	// Images whose descriptor graph reaches the blob.
+	imgs, err := client.ImageService().List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		if reaches(ctx, cs, img.Target, dgst, map[digest.Digest]bool{}) {
+			fmt.Printf("reachable from image %s (target %s)\n", img.Name, img.Target.Digest)
+		}
+	}
+
+	// Other content referencing the blob through gc.ref labels.
+	if err := cs.Walk(ctx, func(other content.Info) error {
+		for k, v := range other.Labels {
+			if strings.HasPrefix(k, "containerd.io/gc.ref.") && v == dgst.String() {
+				fmt.Printf("referenced by content %s via label %s\n", other.Digest, k)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Leases holding the blob.
+	ls := client.LeasesService()
+	leases, err := ls.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, lease := range leases {
+		resources, err := ls.ListResources(ctx, lease)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			if r.Type == "content" && r.ID == dgst.String() {
+				fmt.Printf("held by lease %s\n", lease.ID)
+			}
+		}
+	}
+	return nil
+}
+
+func explainSnapshot(ctx context.Context, client *containerd.Client, snapshotter, key string) error {
+	sn := client.SnapshotService(snapshotter)
+	info, err := sn.Stat(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range info.Labels {
+		if strings.HasPrefix(k, "containerd.io/gc.root") {
+			fmt.Printf("pinned by gc root label %s=%s\n", k, v)
+		}
+	}
+
+	// Child snapshots keep their parents alive.
+	if err := sn.Walk(ctx, func(ctx context.Context, child snapshots.Info) error {
+		if child.Parent == key {
+			fmt.Printf("parent of snapshot %s\n", child.Name)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Containers using the snapshot as their rootfs.
+	containers, err := client.ContainerService().List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range containers {
+		if c.Snapshotter == snapshotter && c.SnapshotKey == key {
+			fmt.Printf("used as rootfs of container %s\n", c.ID)
+		}
+	}
+
+	// Leases holding the snapshot.
+	ls := client.LeasesService()
+	leases, err := ls.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, lease := range leases {
+		resources, err := ls.ListResources(ctx, lease)
+		if err != nil {
+			return err
+		}
+		for _, r := range resources {
+			if r.Type == "snapshots/"+snapshotter && r.ID == key {
+				fmt.Printf("held by lease %s\n", lease.ID)
+			}
+		}
+	}
+	return nil
+}
+
+// reaches reports whether the descriptor graph rooted at desc contains
+// the digest.
+func reaches(ctx context.Context, cs content.Store, desc ocispec.Descriptor, dgst digest.Digest, seen map[digest.Digest]bool) bool {
+	if desc.Digest == dgst {
+		return true
+	}
+	if seen[desc.Digest] {
+		return false
+	}
+	seen[desc.Digest] = true
+	children, err := images.Children(ctx, cs, desc)
+	if err != nil {
+		return false
+	}
+	for _, child := range children {
+		if reaches(ctx, cs, child, dgst, seen) {
+			return true
+		}
+	}
+	return false
+}
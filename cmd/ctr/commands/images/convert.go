@@ -22,6 +22,7 @@ import (
 
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
 	"github.com/containerd/containerd/v2/core/images/converter"
+	"github.com/containerd/containerd/v2/core/images/converter/recompress"
 	"github.com/containerd/containerd/v2/core/images/converter/uncompress"
 	"github.com/containerd/platforms"
 	"github.com/urfave/cli/v2"
@@ -44,6 +45,10 @@ When '--all-platforms' is given all images in a manifest list must be available.
 			Name:  "uncompress",
 			Usage: "Convert tar.gz layers to uncompressed tar layers",
 		},
+		&cli.StringFlag{
+			Name:  "compression",
+			Usage: "Re-compress layers with the given algorithm (\"gzip\" or \"zstd\")",
+		},
 		&cli.BoolFlag{
 			Name:  "oci",
 			Usage: "Convert Docker media types to OCI media types",
@@ -80,9 +85,20 @@ When '--all-platforms' is given all images in a manifest list must be available.
 		}
 
 		if cliContext.Bool("uncompress") {
+			if cliContext.IsSet("compression") {
+				return errors.New("conflicting --uncompress and --compression options")
+			}
 			convertOpts = append(convertOpts, converter.WithLayerConvertFunc(uncompress.LayerConvertFunc))
 		}
 
+		if cliContext.IsSet("compression") {
+			compressionType, err := recompress.Parse(cliContext.String("compression"))
+			if err != nil {
+				return err
+			}
+			convertOpts = append(convertOpts, converter.WithLayerConvertFunc(recompress.LayerConvertFunc(compressionType)))
+		}
+
 		if cliContext.Bool("oci") {
 			convertOpts = append(convertOpts, converter.WithDockerToOCI(true))
 		}
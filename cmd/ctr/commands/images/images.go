@@ -17,14 +17,20 @@
 package images
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/pkg/progress"
 	"github.com/containerd/errdefs"
@@ -48,6 +54,9 @@ var Command = &cli.Command{
 		unmountCommand,
 		pullCommand,
 		pushCommand,
+		historyCommand,
+		pinCommand,
+		unpinCommand,
 		pruneCommand,
 		removeCommand,
 		tagCommand,
@@ -69,6 +78,7 @@ var listCommand = &cli.Command{
 			Aliases: []string{"q"},
 			Usage:   "Print only the image refs",
 		},
+		commands.FormatFlag,
 	},
 	Action: func(cliContext *cli.Context) error {
 		var (
@@ -88,6 +98,9 @@ var listCommand = &cli.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list images: %w", err)
 		}
+		if handled, err := commands.PrintFormatted(cliContext.String("format"), imageList); handled {
+			return err
+		}
 		if quiet {
 			for _, image := range imageList {
 				fmt.Println(image.Name)
@@ -362,6 +375,115 @@ var removeCommand = &cli.Command{
 	},
 }
 
+var historyCommand = &cli.Command{
+	Name:      "history",
+	Usage:     "Show provenance and config history of an image",
+	ArgsUsage: "<ref>",
+	Action: func(cliContext *cli.Context) error {
+		ref := cliContext.Args().First()
+		if ref == "" {
+			return errors.New("please provide an image reference")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		img, err := client.ImageService().Get(ctx, ref)
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, ' ', 0)
+		if p, ok := images.GetProvenance(img); ok {
+			fmt.Fprintf(tw, "SOURCE\t%s\t\n", p.Source)
+			if p.Ref != "" {
+				fmt.Fprintf(tw, "REF\t%s\t\n", p.Ref)
+			}
+			if p.Digest != "" {
+				fmt.Fprintf(tw, "DIGEST\t%s\t\n", p.Digest)
+			}
+			if p.File != "" {
+				fmt.Fprintf(tw, "FILE\t%s\t\n", p.File)
+			}
+			fmt.Fprintf(tw, "CREATED\t%s\t\n", p.CreatedAt.Format(time.RFC3339))
+		} else {
+			fmt.Fprintln(tw, "no provenance recorded for image")
+		}
+
+		// Also show the config history entries of the image.
+		platImg := containerd.NewImage(client, img)
+		configDesc, err := platImg.Config(ctx)
+		if err == nil {
+			blob, err := content.ReadBlob(ctx, client.ContentStore(), configDesc)
+			if err == nil {
+				var config ocispec.Image
+				if json.Unmarshal(blob, &config) == nil && len(config.History) > 0 {
+					fmt.Fprintln(tw, "\nCREATED\tCREATED BY\tCOMMENT\t")
+					for _, h := range config.History {
+						created := ""
+						if h.Created != nil {
+							created = h.Created.Format(time.RFC3339)
+						}
+						fmt.Fprintf(tw, "%s\t%s\t%s\t\n", created, h.CreatedBy, h.Comment)
+					}
+				}
+			}
+		}
+		return tw.Flush()
+	},
+}
+
+var pinCommand = &cli.Command{
+	Name:      "pin",
+	Usage:     "Pin one or more images so GC and prune keep them",
+	ArgsUsage: "<ref> [<ref>, ...]",
+	Action: func(cliContext *cli.Context) error {
+		return updatePinned(cliContext, true)
+	},
+}
+
+var unpinCommand = &cli.Command{
+	Name:      "unpin",
+	Usage:     "Remove the pin from one or more images",
+	ArgsUsage: "<ref> [<ref>, ...]",
+	Action: func(cliContext *cli.Context) error {
+		return updatePinned(cliContext, false)
+	},
+}
+
+func updatePinned(cliContext *cli.Context, pinned bool) error {
+	if cliContext.NArg() == 0 {
+		return errors.New("please provide at least one image ref to update")
+	}
+	client, ctx, cancel, err := commands.NewClient(cliContext)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	imageStore := client.ImageService()
+	for _, ref := range cliContext.Args().Slice() {
+		img, err := imageStore.Get(ctx, ref)
+		if err != nil {
+			return err
+		}
+		if img.Labels == nil {
+			img.Labels = map[string]string{}
+		}
+		if pinned {
+			img.Labels[images.PinnedLabel] = images.PinnedLabelValue
+		} else {
+			delete(img.Labels, images.PinnedLabel)
+		}
+		if _, err := imageStore.Update(ctx, img, "labels."+images.PinnedLabel); err != nil {
+			return err
+		}
+		fmt.Println(ref)
+	}
+	return nil
+}
+
 var pruneCommand = &cli.Command{
 	Name:  "prune",
 	Usage: "Remove unused images",
@@ -409,6 +531,10 @@ var pruneCommand = &cli.Command{
 			if _, ok := usedImages[image.Name]; ok {
 				continue
 			}
+			if images.IsPinned(image) {
+				log.G(ctx).Debugf("skipping pinned image %s", image.Name)
+				continue
+			}
 			removedImages = append(removedImages, image.Name)
 		}
 
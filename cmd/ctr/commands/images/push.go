@@ -77,6 +77,12 @@ var pushCommand = &cli.Command{
 	}, &cli.BoolFlag{
 		Name:  "allow-non-distributable-blobs",
 		Usage: "Allow pushing blobs that are marked as non-distributable",
+	}, &cli.BoolFlag{
+		Name:  "subset-index",
+		Usage: "Regenerate the index to contain only the selected --platform entries before pushing (requires --local)",
+	}, &cli.StringFlag{
+		Name:  "index-signer",
+		Usage: "External signer hook run on the regenerated index: receives the index JSON on stdin and returns the index to push on stdout (requires --subset-index)",
 	}),
 	Action: func(cliContext *cli.Context) error {
 		var (
@@ -98,6 +104,7 @@ var pushCommand = &cli.Command{
 		if !cliContext.Bool("local") {
 			unsupportedFlags := []string{
 				"manifest", "manifest-type", "max-concurrent-uploaded-layers", "allow-non-distributable-blobs",
+				"subset-index", "index-signer",
 				"skip-verify", "tlscacert", "tlscert", "tlskey", "http-dump", "http-trace", // RegistryFlags
 			}
 			for _, s := range unsupportedFlags {
@@ -153,7 +160,20 @@ var pushCommand = &cli.Command{
 			}
 			desc = img.Target
 
-			if pss := cliContext.StringSlice("platform"); len(pss) == 1 {
+			if cliContext.Bool("subset-index") {
+				pss := cliContext.StringSlice("platform")
+				if len(pss) == 0 {
+					return errors.New("--subset-index requires at least one --platform")
+				}
+				matchers, err := platforms.ParseAll(pss)
+				if err != nil {
+					return fmt.Errorf("invalid platform %v: %w", pss, err)
+				}
+				desc, err = subsetIndex(ctx, client.ContentStore(), desc, matchers, cliContext.String("index-signer"))
+				if err != nil {
+					return err
+				}
+			} else if pss := cliContext.StringSlice("platform"); len(pss) == 1 {
 				p, err := platforms.Parse(pss[0])
 				if err != nil {
 					return fmt.Errorf("invalid platform %q: %w", pss[0], err)
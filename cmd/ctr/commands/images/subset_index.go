@@ -0,0 +1,112 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+)
+
+// subsetIndex regenerates the image index keeping only manifests matching
+// the requested platforms, optionally passing the new index through an
+// external signer hook, and writes it to the content store. Trimmed
+// per-cluster mirrors push the returned descriptor instead of the full
+// index.
+func subsetIndex(ctx context.Context, cs content.Store, desc ocispec.Descriptor, plats []ocispec.Platform, signer string) (ocispec.Descriptor, error) {
+	if !images.IsIndexType(desc.MediaType) {
+		return ocispec.Descriptor{}, fmt.Errorf("--subset-index requires an index image, got %s", desc.MediaType)
+	}
+	blob, err := content.ReadBlob(ctx, cs, desc)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(blob, &index); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to parse index: %w", err)
+	}
+
+	matchers := make([]platforms.Matcher, len(plats))
+	for i, p := range plats {
+		matchers[i] = platforms.NewMatcher(p)
+	}
+	var kept []ocispec.Descriptor
+	for _, m := range index.Manifests {
+		if m.Platform == nil {
+			// Keep attestation and unknown-platform entries.
+			kept = append(kept, m)
+			continue
+		}
+		for _, matcher := range matchers {
+			if matcher.Match(*m.Platform) {
+				kept = append(kept, m)
+				break
+			}
+		}
+	}
+	if len(kept) == 0 {
+		return ocispec.Descriptor{}, fmt.Errorf("no manifests match the requested platforms")
+	}
+	index.Manifests = kept
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return ocispec.Descriptor{}, err
+	}
+
+	if signer != "" {
+		// The signer hook may attach signatures or annotations; its
+		// output replaces the index.
+		cmd := exec.CommandContext(ctx, signer)
+		cmd.Stdin = bytes.NewReader(data)
+		var out, stderr bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("index signer failed: %s: %w", stderr.String(), err)
+		}
+		var signed ocispec.Index
+		if err := json.Unmarshal(out.Bytes(), &signed); err != nil {
+			return ocispec.Descriptor{}, fmt.Errorf("index signer returned invalid index: %w", err)
+		}
+		data = out.Bytes()
+	}
+
+	newDesc := ocispec.Descriptor{
+		MediaType:   desc.MediaType,
+		Digest:      digest.FromBytes(data),
+		Size:        int64(len(data)),
+		Annotations: desc.Annotations,
+	}
+	labels := map[string]string{}
+	for i, m := range index.Manifests {
+		labels[fmt.Sprintf("containerd.io/gc.ref.content.m.%d", i)] = m.Digest.String()
+	}
+	if err := content.WriteBlob(ctx, cs, "subset-index-"+newDesc.Digest.String(), bytes.NewReader(data), newDesc, content.WithLabels(labels)); err != nil {
+		return ocispec.Descriptor{}, fmt.Errorf("failed to write subset index: %w", err)
+	}
+	return newDesc, nil
+}
@@ -37,6 +37,8 @@ var Command = &cli.Command{
 		listCommand,
 		createCommand,
 		deleteCommand,
+		resourcesCommand,
+		releaseResourceCommand,
 	},
 }
 
@@ -53,6 +55,7 @@ var listCommand = &cli.Command{
 			Aliases: []string{"q"},
 			Usage:   "Print only the blob digest",
 		},
+		commands.FormatFlag,
 	},
 	Action: func(cliContext *cli.Context) error {
 		var (
@@ -71,6 +74,9 @@ var listCommand = &cli.Command{
 		if err != nil {
 			return fmt.Errorf("failed to list leases: %w", err)
 		}
+		if handled, err := commands.PrintFormatted(cliContext.String("format"), leaseList); handled {
+			return err
+		}
 		if quiet {
 			for _, l := range leaseList {
 				fmt.Println(l.ID)
@@ -0,0 +1,98 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package leases
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/leases"
+)
+
+var resourcesCommand = &cli.Command{
+	Name:      "resources",
+	Usage:     "List the resources pinned by a lease",
+	ArgsUsage: "<id>",
+	Description: `List exactly which content, snapshots and other resources a lease
+pins, to diagnose why GC is not freeing space.`,
+	Action: func(cliContext *cli.Context) error {
+		id := cliContext.Args().First()
+		if id == "" {
+			return errors.New("lease id must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		resources, err := client.LeasesService().ListResources(ctx, leases.Lease{ID: id})
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 4, 8, 4, ' ', 0)
+		fmt.Fprintln(w, "TYPE\tID\t")
+		for _, r := range resources {
+			fmt.Fprintf(w, "%s\t%s\t\n", r.Type, r.ID)
+		}
+		return w.Flush()
+	},
+}
+
+var releaseResourceCommand = &cli.Command{
+	Name:      "release",
+	Usage:     "Detach a resource from a lease, allowing GC to collect it",
+	ArgsUsage: "[flags] <id> <type> <resource>",
+	Description: `Detach a single resource (e.g. "content sha256:...", or
+"snapshots/overlayfs <key>") from the lease without deleting the lease.
+This makes the resource collectable by GC unless something else holds it,
+so it requires --force as a guard.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "force",
+			Usage: "Confirm detaching the resource from the lease",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		var (
+			id       = cliContext.Args().Get(0)
+			rtype    = cliContext.Args().Get(1)
+			resource = cliContext.Args().Get(2)
+		)
+		if id == "" || rtype == "" || resource == "" {
+			return errors.New("lease id, resource type and resource id must be provided")
+		}
+		if !cliContext.Bool("force") {
+			return errors.New("detaching a resource makes it collectable by GC, pass --force to confirm")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		return client.LeasesService().DeleteResource(ctx, leases.Lease{ID: id}, leases.Resource{
+			Type: rtype,
+			ID:   resource,
+		})
+	},
+}
@@ -0,0 +1,181 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package namespaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/errdefs"
+)
+
+var cloneCommand = &cli.Command{
+	Name:      "clone",
+	Usage:     "Clone a namespace's images into a new namespace",
+	ArgsUsage: "<source> <destination>",
+	Description: `Clone the images (and their labels) of one namespace into another.
+Underlying content is shared through the content store's deduplication;
+only metadata is duplicated. This is used to template tenant namespaces
+from a golden namespace.`,
+	Action: func(cliContext *cli.Context) error {
+		var (
+			src = cliContext.Args().Get(0)
+			dst = cliContext.Args().Get(1)
+		)
+		if src == "" || dst == "" {
+			return errors.New("source and destination namespaces must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		return cloneNamespaceImages(ctx, client, src, dst)
+	},
+}
+
+var renameCommand = &cli.Command{
+	Name:      "rename",
+	Usage:     "Rename a namespace by cloning its images and removing the old namespace",
+	ArgsUsage: "<old> <new>",
+	Description: `Rename clones the namespace's images and labels into the new name and
+then removes the old namespace. The old namespace must not contain
+containers; remove or migrate them first.`,
+	Action: func(cliContext *cli.Context) error {
+		var (
+			oldName = cliContext.Args().Get(0)
+			newName = cliContext.Args().Get(1)
+		)
+		if oldName == "" || newName == "" {
+			return errors.New("old and new namespace names must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		// Refuse to rename a namespace still holding containers.
+		srcCtx := namespaces.WithNamespace(ctx, oldName)
+		cntrs, err := client.Containers(srcCtx)
+		if err != nil {
+			return err
+		}
+		if len(cntrs) > 0 {
+			return fmt.Errorf("namespace %q still has %d containers, remove them before renaming", oldName, len(cntrs))
+		}
+
+		if err := cloneNamespaceImages(ctx, client, oldName, newName); err != nil {
+			return err
+		}
+
+		// Drop the cloned images so the old namespace can be removed.
+		is := client.ImageService()
+		imgs, err := is.List(srcCtx)
+		if err != nil {
+			return err
+		}
+		for _, img := range imgs {
+			if err := is.Delete(srcCtx, img.Name); err != nil && !errdefs.IsNotFound(err) {
+				return err
+			}
+		}
+		return client.NamespaceService().Delete(ctx, oldName)
+	},
+}
+
+// cloneNamespaceImages copies namespace labels and every image record
+// from src into dst, sharing the underlying content.
+func cloneNamespaceImages(ctx context.Context, client *containerd.Client, src, dst string) error {
+	nsService := client.NamespaceService()
+	labels, err := nsService.Labels(ctx, src)
+	if err != nil {
+		return fmt.Errorf("failed to read namespace %q: %w", src, err)
+	}
+	if err := nsService.Create(ctx, dst, labels); err != nil && !errdefs.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create namespace %q: %w", dst, err)
+	}
+
+	var (
+		srcCtx = namespaces.WithNamespace(ctx, src)
+		dstCtx = namespaces.WithNamespace(ctx, dst)
+		is     = client.ImageService()
+		cs     = client.ContentStore()
+	)
+
+	imgs, err := is.List(srcCtx)
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		// Reference every blob of the image in the destination namespace.
+		// The content store deduplicates the bytes, so this only records
+		// metadata.
+		if err := images.Walk(srcCtx, images.Handlers(
+			images.HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+				return nil, copyBlob(srcCtx, dstCtx, cs, desc)
+			}),
+			images.ChildrenHandler(nsProvider{cs, srcCtx}),
+		), img.Target); err != nil {
+			return fmt.Errorf("failed to copy content of image %q: %w", img.Name, err)
+		}
+
+		if _, err := is.Create(dstCtx, img); err != nil && !errdefs.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to create image %q in namespace %q: %w", img.Name, dst, err)
+		}
+	}
+	return nil
+}
+
+// copyBlob records the blob in the destination namespace, reading from
+// the source namespace. With a shared content policy the commit
+// deduplicates instantly.
+func copyBlob(srcCtx, dstCtx context.Context, cs content.Store, desc ocispec.Descriptor) error {
+	ra, err := cs.ReaderAt(srcCtx, desc)
+	if err != nil {
+		return err
+	}
+	defer ra.Close()
+	err = content.WriteBlob(dstCtx, cs, "clone-"+desc.Digest.String(), io.NewSectionReader(ra, 0, ra.Size()), desc)
+	if errdefs.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+// nsProvider pins a content provider to the source namespace context for
+// child descriptor resolution.
+type nsProvider struct {
+	cs  content.Store
+	ctx context.Context
+}
+
+func (p nsProvider) ReaderAt(_ context.Context, desc ocispec.Descriptor) (content.ReaderAt, error) {
+	return p.cs.ReaderAt(p.ctx, desc)
+}
@@ -36,9 +36,11 @@ var Command = &cli.Command{
 	Aliases: []string{"namespace", "ns"},
 	Usage:   "Manage namespaces",
 	Subcommands: cli.Commands{
+		cloneCommand,
 		createCommand,
 		listCommand,
 		removeCommand,
+		renameCommand,
 		setLabelsCommand,
 	},
 }
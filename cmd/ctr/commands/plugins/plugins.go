@@ -41,6 +41,7 @@ var Command = &cli.Command{
 	Subcommands: []*cli.Command{
 		listCommand,
 		inspectRuntimeCommand,
+		treeCommand,
 	},
 }
 
@@ -0,0 +1,98 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package plugins
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+)
+
+var treeCommand = &cli.Command{
+	Name:  "tree",
+	Usage: "Print the plugin dependency graph with init timings and failures",
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		response, err := client.IntrospectionService().Plugins(ctx)
+		if err != nil {
+			return err
+		}
+
+		// Group plugins by type to resolve the Requires edges, which
+		// reference plugin types.
+		byType := map[string][]string{}
+		type nodeInfo struct {
+			requires []string
+			duration string
+			initErr  string
+		}
+		nodes := map[string]nodeInfo{}
+		var ids []string
+		for _, p := range response.Plugins {
+			id := p.Type + "." + p.ID
+			byType[p.Type] = append(byType[p.Type], id)
+			info := nodeInfo{
+				requires: p.Requires,
+				duration: p.Exports["init_duration"],
+			}
+			if p.InitErr != nil {
+				info.initErr = p.InitErr.Message
+			}
+			nodes[id] = info
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		var render func(id string, depth int, seen map[string]bool)
+		render = func(id string, depth int, seen map[string]bool) {
+			node := nodes[id]
+			line := strings.Repeat("  ", depth) + id
+			if node.duration != "" {
+				line += " (" + node.duration + ")"
+			}
+			if node.initErr != "" {
+				line += " [error: " + node.initErr + "]"
+			}
+			fmt.Println(line)
+			if seen[id] {
+				return
+			}
+			seen[id] = true
+			for _, requiredType := range node.requires {
+				deps := append([]string{}, byType[requiredType]...)
+				sort.Strings(deps)
+				for _, dep := range deps {
+					render(dep, depth+1, seen)
+				}
+			}
+		}
+
+		for _, id := range ids {
+			render(id, 0, map[string]bool{})
+		}
+		return nil
+	},
+}
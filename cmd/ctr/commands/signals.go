@@ -32,6 +32,9 @@ type killer interface {
 }
 
 // ForwardAllSignals forwards signals
+//
+// Embedders should prefer client.ForwardSignals, which provides the same
+// SIGURG-safe forwarding with allow/deny list options.
 func ForwardAllSignals(ctx context.Context, task killer) chan os.Signal {
 	sigc := make(chan os.Signal, 128)
 	signal.Notify(sigc)
@@ -0,0 +1,109 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package snapshots
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/snapshots/migrate"
+)
+
+var exportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Export a committed snapshot chain to a portable archive",
+	ArgsUsage: "[flags] <key> <out|->",
+	Action: func(cliContext *cli.Context) error {
+		var (
+			key = cliContext.Args().Get(0)
+			out = cliContext.Args().Get(1)
+		)
+		if key == "" || out == "" {
+			return errors.New("snapshot key and output file must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		var w io.Writer = os.Stdout
+		if out != "-" {
+			f, err := os.Create(out)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		ctx, done, err := client.WithLease(ctx)
+		if err != nil {
+			return err
+		}
+		defer done(ctx)
+
+		sn := client.SnapshotService(cliContext.String("snapshotter"))
+		return migrate.Export(ctx, w, key, sn, client.ContentStore(), client.DiffService())
+	},
+}
+
+var importCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Import a snapshot chain from an archive written by export",
+	ArgsUsage: "[flags] <in|->",
+	Action: func(cliContext *cli.Context) error {
+		in := cliContext.Args().First()
+		if in == "" {
+			return errors.New("input file must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		var r io.Reader = os.Stdin
+		if in != "-" {
+			f, err := os.Open(in)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		ctx, done, err := client.WithLease(ctx)
+		if err != nil {
+			return err
+		}
+		defer done(ctx)
+
+		sn := client.SnapshotService(cliContext.String("snapshotter"))
+		chainID, err := migrate.Import(ctx, r, sn, client.ContentStore(), client.DiffService())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cliContext.App.Writer, chainID.String())
+		return nil
+	},
+}
@@ -50,12 +50,15 @@ var Command = &cli.Command{
 	Subcommands: cli.Commands{
 		commitCommand,
 		diffCommand,
+		exportCommand,
+		importCommand,
 		infoCommand,
 		listCommand,
 		mountCommand,
 		prepareCommand,
 		removeCommand,
 		setLabelCommand,
+		tmpMountCommand,
 		treeCommand,
 		unpackCommand,
 		usageCommand,
@@ -67,16 +70,28 @@ var listCommand = &cli.Command{
 	Name:    "list",
 	Aliases: []string{"ls"},
 	Usage:   "List snapshots",
+	Flags: []cli.Flag{
+		commands.FormatFlag,
+	},
 	Action: func(cliContext *cli.Context) error {
 		client, ctx, cancel, err := commands.NewClient(cliContext)
 		if err != nil {
 			return err
 		}
 		defer cancel()
-		var (
-			snapshotter = client.SnapshotService(cliContext.String("snapshotter"))
-			tw          = tabwriter.NewWriter(os.Stdout, 1, 8, 1, ' ', 0)
-		)
+		snapshotter := client.SnapshotService(cliContext.String("snapshotter"))
+		if format := cliContext.String("format"); format != "" {
+			var infos []snapshots.Info
+			if err := snapshotter.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+				infos = append(infos, info)
+				return nil
+			}); err != nil {
+				return err
+			}
+			_, err := commands.PrintFormatted(format, infos)
+			return err
+		}
+		tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, ' ', 0)
 		fmt.Fprintln(tw, "KEY\tPARENT\tKIND\t")
 		if err := snapshotter.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
 			fmt.Fprintf(tw, "%v\t%v\t%v\t\n",
@@ -0,0 +1,116 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package snapshots
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+
+	"github.com/containerd/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/mount"
+)
+
+var tmpMountCommand = &cli.Command{
+	Name:      "tmpmount",
+	Usage:     "Mount a snapshot at a temporary path with guaranteed cleanup",
+	ArgsUsage: "[flags] <key> [command...]",
+	Description: `Mount the snapshot at a temporary directory and print the path. When a
+command is given it runs with the mount path as its last argument and the
+snapshot is unmounted and the directory removed afterwards, also on
+SIGINT/SIGTERM. Without a command the mount stays until interrupted.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "rw",
+			Usage: "Mount the snapshot read-write (mounts a view otherwise when possible)",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		key := cliContext.Args().First()
+		if key == "" {
+			return errors.New("snapshot key must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		snapshotter := client.SnapshotService(cliContext.String("snapshotter"))
+		mounts, err := snapshotter.Mounts(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		target, err := os.MkdirTemp("", "ctr-snapshot-")
+		if err != nil {
+			return err
+		}
+		// Guarantee unmount and removal of the temporary directory, also
+		// when the command is interrupted.
+		cleanup := func() {
+			if err := mount.UnmountAll(target, 0); err != nil {
+				log.L.WithError(err).Errorf("failed to unmount %s", target)
+			}
+			if err := os.Remove(target); err != nil {
+				log.L.WithError(err).Errorf("failed to remove %s", target)
+			}
+		}
+
+		if err := mount.All(mounts, target); err != nil {
+			os.Remove(target)
+			return fmt.Errorf("failed to mount snapshot %q: %w", key, err)
+		}
+		defer cleanup()
+
+		signals := make(chan os.Signal, 2)
+		signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(signals)
+
+		fmt.Fprintln(cliContext.App.Writer, target)
+
+		if cliContext.NArg() < 2 {
+			// No command: hold the mount until interrupted.
+			<-signals
+			return nil
+		}
+
+		args := cliContext.Args().Slice()[1:]
+		cmd := exec.Command(args[0], append(args[1:], target)...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		done := make(chan error, 1)
+		go func() { done <- cmd.Wait() }()
+		select {
+		case err := <-done:
+			return err
+		case sig := <-signals:
+			cmd.Process.Signal(sig)
+			return <-done
+		}
+	},
+}
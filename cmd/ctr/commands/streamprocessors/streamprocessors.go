@@ -0,0 +1,156 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package streamprocessors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/diff"
+	"github.com/containerd/containerd/v2/plugins/services/streamprocessors"
+)
+
+// Command is the parent for all commands under "stream-processors"
+var Command = &cli.Command{
+	Name:  "stream-processors",
+	Usage: "Manage runtime registered stream processors",
+	Subcommands: []*cli.Command{
+		registerCommand,
+		removeCommand,
+		listCommand,
+	},
+}
+
+var registerCommand = &cli.Command{
+	Name:      "register",
+	Usage:     "Register a binary stream processor",
+	ArgsUsage: "[flags] <id> <path>",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:     "accept",
+			Usage:    "Media types the processor accepts",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name:     "returns",
+			Usage:    "Media type the processor returns",
+			Required: true,
+		},
+		&cli.StringSliceFlag{
+			Name:  "arg",
+			Usage: "Arguments passed to the processor binary",
+		},
+		&cli.StringSliceFlag{
+			Name:  "env",
+			Usage: "Environment variables passed to the processor binary",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		var (
+			id   = cliContext.Args().Get(0)
+			path = cliContext.Args().Get(1)
+		)
+		if id == "" || path == "" {
+			return errors.New("processor id and binary path must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		cc, ok := client.Conn().(*grpc.ClientConn)
+		if !ok {
+			return errors.New("stream processors service requires a grpc connection")
+		}
+		payload, err := json.Marshal(diff.ProcessorInfo{
+			ID:      id,
+			Path:    path,
+			Accepts: cliContext.StringSlice("accept"),
+			Returns: cliContext.String("returns"),
+			Args:    cliContext.StringSlice("arg"),
+			Env:     cliContext.StringSlice("env"),
+		})
+		if err != nil {
+			return err
+		}
+		return cc.Invoke(ctx, "/"+streamprocessors.ServiceName+"/Register", wrapperspb.String(string(payload)), &emptypb.Empty{})
+	},
+}
+
+var removeCommand = &cli.Command{
+	Name:      "remove",
+	Aliases:   []string{"rm"},
+	Usage:     "Remove a runtime registered stream processor",
+	ArgsUsage: "<id>",
+	Action: func(cliContext *cli.Context) error {
+		id := cliContext.Args().First()
+		if id == "" {
+			return errors.New("processor id must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		cc, ok := client.Conn().(*grpc.ClientConn)
+		if !ok {
+			return errors.New("stream processors service requires a grpc connection")
+		}
+		return cc.Invoke(ctx, "/"+streamprocessors.ServiceName+"/Remove", wrapperspb.String(id), &emptypb.Empty{})
+	},
+}
+
+var listCommand = &cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "List runtime registered stream processors",
+	Action: func(cliContext *cli.Context) error {
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		cc, ok := client.Conn().(*grpc.ClientConn)
+		if !ok {
+			return errors.New("stream processors service requires a grpc connection")
+		}
+		resp := &wrapperspb.StringValue{}
+		if err := cc.Invoke(ctx, "/"+streamprocessors.ServiceName+"/List", &emptypb.Empty{}, resp); err != nil {
+			return err
+		}
+		var infos []diff.ProcessorInfo
+		if err := json.Unmarshal([]byte(resp.GetValue()), &infos); err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 4, 8, 4, ' ', 0)
+		fmt.Fprintln(w, "ID\tACCEPTS\tRETURNS\tPATH\t")
+		for _, info := range infos {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", info.ID, strings.Join(info.Accepts, ","), info.Returns, info.Path)
+		}
+		return w.Flush()
+	},
+}
@@ -37,6 +37,7 @@ var listCommand = &cli.Command{
 			Aliases: []string{"q"},
 			Usage:   "Print only the task id",
 		},
+		commands.FormatFlag,
 	},
 	Action: func(cliContext *cli.Context) error {
 		quiet := cliContext.Bool("quiet")
@@ -50,6 +51,9 @@ var listCommand = &cli.Command{
 		if err != nil {
 			return err
 		}
+		if handled, err := commands.PrintFormatted(cliContext.String("format"), response.Tasks); handled {
+			return err
+		}
 		if quiet {
 			for _, task := range response.Tasks {
 				fmt.Println(task.ID)
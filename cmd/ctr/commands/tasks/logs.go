@@ -0,0 +1,191 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+)
+
+var logsCommand = &cli.Command{
+	Name:      "logs",
+	Usage:     "Read the log file of a container's task",
+	ArgsUsage: "[flags] CONTAINER",
+	Description: `Read the container's log based on its file:// log URI, including logs
+written with the CRI framing option. The file is located from the task's
+IO configuration, so users no longer need to find and tail log files by
+hand.`,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "follow",
+			Aliases: []string{"f"},
+			Usage:   "Keep reading as the log grows",
+		},
+		&cli.IntFlag{
+			Name:  "tail",
+			Usage: "Show only the last N lines; 0 shows everything",
+		},
+		&cli.StringFlag{
+			Name:  "since",
+			Usage: "Only show CRI framed lines newer than the RFC3339 time or duration ago (e.g. 1h)",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		id := cliContext.Args().First()
+		if id == "" {
+			return errors.New("container id must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		path, err := taskLogPath(ctx, client, id)
+		if err != nil {
+			return err
+		}
+
+		var since time.Time
+		if v := cliContext.String("since"); v != "" {
+			if since, err = time.Parse(time.RFC3339, v); err != nil {
+				d, derr := time.ParseDuration(v)
+				if derr != nil {
+					return fmt.Errorf("invalid --since: expected RFC3339 time or duration")
+				}
+				since = time.Now().Add(-d)
+			}
+		}
+
+		return tailLog(ctx, path, cliContext.Int("tail"), since, cliContext.Bool("follow"))
+	},
+}
+
+// taskLogPath locates the log file of the container from its stdout log
+// URI.
+func taskLogPath(ctx context.Context, client *containerd.Client, id string) (string, error) {
+	container, err := client.LoadContainer(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	stdout := task.IO().Config().Stdout
+	u, err := url.Parse(stdout)
+	if err != nil || u.Scheme != "file" {
+		return "", fmt.Errorf("container %q does not log to a file (stdout: %q)", id, stdout)
+	}
+	return u.Path, nil
+}
+
+// tailLog prints the log file, honoring tail count, since filtering of
+// CRI framed lines and follow mode.
+func tailLog(ctx context.Context, path string, tail int, since time.Time, follow bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	printLine := func(line string) {
+		if !since.IsZero() {
+			// Filter CRI framed lines by their timestamp; unframed
+			// lines are always printed.
+			if fields := strings.SplitN(line, " ", 2); len(fields) == 2 {
+				if ts, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil && ts.Before(since) {
+					return
+				}
+			}
+		}
+		fmt.Println(line)
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	if tail > 0 {
+		ring := make([]string, 0, tail)
+		for scanner.Scan() {
+			if len(ring) == tail {
+				ring = ring[1:]
+			}
+			ring = append(ring, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		for _, line := range ring {
+			printLine(line)
+		}
+	} else {
+		for scanner.Scan() {
+			printLine(scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+
+	// Follow by polling for appended data from the current offset.
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(500 * time.Millisecond):
+		}
+		fi, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if fi.Size() <= offset {
+			continue
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+		scanner := bufio.NewScanner(io.LimitReader(f, fi.Size()-offset))
+		scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+		for scanner.Scan() {
+			printLine(scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return err
+		}
+		offset = fi.Size()
+	}
+}
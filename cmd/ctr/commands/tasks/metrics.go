@@ -17,16 +17,21 @@
 package tasks
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	wstats "github.com/Microsoft/hcsshim/cmd/containerd-shim-runhcs-v1/stats"
 	v1 "github.com/containerd/cgroups/v3/cgroup1/stats"
 	v2 "github.com/containerd/cgroups/v3/cgroup2/stats"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/pkg/protobuf"
 	"github.com/containerd/typeurl/v2"
 	"github.com/urfave/cli/v2"
 )
@@ -40,7 +45,7 @@ const (
 var metricsCommand = &cli.Command{
 	Name:      "metrics",
 	Usage:     "Get a single data point of metrics for a task with the built-in Linux runtime",
-	ArgsUsage: "CONTAINER",
+	ArgsUsage: "[CONTAINER...]",
 	Aliases:   []string{"metric"},
 	Flags: []cli.Flag{
 		&cli.StringFlag{
@@ -48,6 +53,15 @@ var metricsCommand = &cli.Command{
 			Usage: `"table" or "json"`,
 			Value: formatTable,
 		},
+		&cli.BoolFlag{
+			Name:  "watch",
+			Usage: "Continuously refresh metrics; with \"json\" format an NDJSON stream is emitted",
+		},
+		&cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Refresh interval used with --watch",
+			Value: time.Second,
+		},
 	},
 	Action: func(cliContext *cli.Context) error {
 		client, ctx, cancel, err := commands.NewClient(cliContext)
@@ -55,6 +69,9 @@ var metricsCommand = &cli.Command{
 			return err
 		}
 		defer cancel()
+		if cliContext.Bool("watch") {
+			return watchMetrics(ctx, client, cliContext)
+		}
 		container, err := client.LoadContainer(ctx, cliContext.Args().First())
 		if err != nil {
 			return err
@@ -202,3 +219,124 @@ func printWindowsVMStatistics(w *tabwriter.Writer, stats *wstats.VirtualMachineS
 		fmt.Fprintf(w, "vm.memory.dm_operation_in_progress\t%t\t\n", stats.Memory.VmMemory.DmOperationInProgress)
 	}
 }
+
+// watchMetrics polls task metrics on the configured interval, rendering a
+// refreshing table or an NDJSON stream. With no arguments all tasks in the
+// namespace are watched.
+func watchMetrics(ctx context.Context, client *containerd.Client, cliContext *cli.Context) error {
+	format := cliContext.String(formatFlag)
+	if format != formatTable && format != formatJSON {
+		return errors.New("format must be table or json")
+	}
+
+	req := &tasksapi.MetricsRequest{}
+	for _, id := range cliContext.Args().Slice() {
+		req.Filters = append(req.Filters, "id=="+id)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	ticker := time.NewTicker(cliContext.Duration("interval"))
+	defer ticker.Stop()
+	for {
+		resp, err := client.TaskService().Metrics(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		if format == formatTable {
+			// Clear the terminal and move the cursor home before
+			// rendering the refreshed table.
+			fmt.Print("\033[2J\033[H")
+			w := tabwriter.NewWriter(os.Stdout, 1, 8, 4, ' ', 0)
+			fmt.Fprintf(w, "ID\tTIMESTAMP\tCPU\tMEMORY\tPIDS\t\n")
+			for _, metric := range resp.Metrics {
+				cpu, memory, pids, err := metricsSummary(metric.Data)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t\n",
+					metric.ID, protobuf.FromTimestamp(metric.Timestamp).Format(time.RFC3339), cpu, memory, pids)
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		} else {
+			for _, metric := range resp.Metrics {
+				data, err := typeurl.UnmarshalAny(metric.Data)
+				if err != nil {
+					return err
+				}
+				if err := enc.Encode(struct {
+					ID        string      `json:"id"`
+					Timestamp time.Time   `json:"timestamp"`
+					Metrics   interface{} `json:"metrics"`
+				}{
+					ID:        metric.ID,
+					Timestamp: protobuf.FromTimestamp(metric.Timestamp),
+					Metrics:   data,
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// metricsSummary extracts cumulative cpu usage (ns), memory usage (bytes)
+// and current pids from task metrics for the watch table.
+func metricsSummary(data typeurl.Any) (cpu, memory, pids uint64, _ error) {
+	anyData, err := typeurl.UnmarshalAny(data)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	switch m := anyData.(type) {
+	case *v1.Metrics:
+		if m.CPU != nil && m.CPU.Usage != nil {
+			cpu = m.CPU.Usage.Total
+		}
+		if m.Memory != nil && m.Memory.Usage != nil {
+			memory = m.Memory.Usage.Usage
+		}
+		if m.Pids != nil {
+			pids = m.Pids.Current
+		}
+	case *v2.Metrics:
+		if m.CPU != nil {
+			cpu = m.CPU.UsageUsec * 1000
+		}
+		if m.Memory != nil {
+			memory = m.Memory.Usage
+		}
+		if m.Pids != nil {
+			pids = m.Pids.Current
+		}
+	case *wstats.Statistics:
+		if linux := m.GetLinux(); linux != nil {
+			if linux.CPU != nil && linux.CPU.Usage != nil {
+				cpu = linux.CPU.Usage.Total
+			}
+			if linux.Memory != nil && linux.Memory.Usage != nil {
+				memory = linux.Memory.Usage.Usage
+			}
+			if linux.Pids != nil {
+				pids = linux.Pids.Current
+			}
+		} else if windows := m.GetWindows(); windows != nil {
+			if windows.Processor != nil {
+				cpu = windows.Processor.TotalRuntimeNS
+			}
+			if windows.Memory != nil {
+				memory = windows.Memory.MemoryUsagePrivateWorkingSetBytes
+			}
+		}
+	default:
+		return 0, 0, 0, errors.New("cannot convert metric data to cgroups.Metrics or windows.Statistics")
+	}
+	return cpu, memory, pids, nil
+}
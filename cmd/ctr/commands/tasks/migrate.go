@@ -0,0 +1,146 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tasks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/core/images/archive"
+	"github.com/containerd/containerd/v2/pkg/cio"
+)
+
+var migrateCommand = &cli.Command{
+	Name:      "migrate",
+	Usage:     "Migrate a task to another containerd over a checkpoint",
+	ArgsUsage: "[flags] CONTAINER REF",
+	Description: `Checkpoint the task with CRIU, ship the checkpoint image and rootfs
+diff to the peer containerd given with --to, and resume the container
+there. The source task exits as part of the checkpoint; pass --keep to
+keep the stopped source container around. Lazy page-server migration is
+not supported by the runtime checkpoint options yet, so memory is
+transferred in the checkpoint image.`,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:     "to",
+			Usage:    "Address of the peer containerd socket",
+			Required: true,
+		},
+		&cli.BoolFlag{
+			Name:  "keep",
+			Usage: "Keep the stopped source container instead of deleting it",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		var (
+			id  = cliContext.Args().Get(0)
+			ref = cliContext.Args().Get(1)
+		)
+		if id == "" || ref == "" {
+			return errors.New("container id and checkpoint ref must be provided")
+		}
+		client, ctx, cancel, err := commands.NewClient(cliContext)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		container, err := client.LoadContainer(ctx, id)
+		if err != nil {
+			return err
+		}
+		task, err := container.Task(ctx, nil)
+		if err != nil {
+			return err
+		}
+
+		// Checkpoint the task with runtime state, memory and the rw layer.
+		// The task exits with the checkpoint so the workload does not run
+		// on both nodes.
+		checkpoint, err := container.Checkpoint(ctx, ref,
+			containerd.WithCheckpointTask,
+			containerd.WithCheckpointRuntime,
+			containerd.WithCheckpointRW,
+			containerd.WithCheckpointImage,
+			containerd.WithCheckpointTaskExit,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to checkpoint task: %w", err)
+		}
+
+		peer, err := containerd.New(cliContext.String("to"))
+		if err != nil {
+			return fmt.Errorf("failed to connect to peer containerd: %w", err)
+		}
+		defer peer.Close()
+
+		// Stream the checkpoint image, including rootfs diff and runtime
+		// state, to the peer.
+		pr, pw := io.Pipe()
+		exportErr := make(chan error, 1)
+		go func() {
+			err := client.Export(ctx, pw, archive.WithImage(client.ImageService(), checkpoint.Name()), archive.WithAllPlatforms())
+			pw.CloseWithError(err)
+			exportErr <- err
+		}()
+		if _, err := peer.Import(ctx, pr, containerd.WithAllPlatforms(true)); err != nil {
+			return fmt.Errorf("failed to import checkpoint on peer: %w", err)
+		}
+		if err := <-exportErr; err != nil {
+			return fmt.Errorf("failed to export checkpoint: %w", err)
+		}
+
+		peerCheckpoint, err := peer.GetImage(ctx, checkpoint.Name())
+		if err != nil {
+			return err
+		}
+
+		restored, err := peer.Restore(ctx, id, peerCheckpoint,
+			containerd.WithRestoreImage,
+			containerd.WithRestoreSpec,
+			containerd.WithRestoreRuntime,
+			containerd.WithRestoreRW,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to restore container on peer: %w", err)
+		}
+		peerTask, err := restored.NewTask(ctx, cio.NullIO, containerd.WithTaskCheckpoint(peerCheckpoint))
+		if err != nil {
+			return fmt.Errorf("failed to create task on peer: %w", err)
+		}
+		if err := peerTask.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start task on peer: %w", err)
+		}
+
+		if !cliContext.Bool("keep") {
+			if _, err := task.Delete(ctx); err != nil {
+				return fmt.Errorf("migrated, but failed to delete source task: %w", err)
+			}
+			if err := container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil {
+				return fmt.Errorf("migrated, but failed to delete source container: %w", err)
+			}
+		}
+
+		fmt.Fprintf(cliContext.App.Writer, "migrated %s to %s\n", id, cliContext.String("to"))
+		return nil
+	},
+}
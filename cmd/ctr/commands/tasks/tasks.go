@@ -37,8 +37,10 @@ var Command = &cli.Command{
 		deleteCommand,
 		execCommand,
 		listCommand,
+		logsCommand,
 		killCommand,
 		metricsCommand,
+		migrateCommand,
 		pauseCommand,
 		psCommand,
 		resumeCommand,
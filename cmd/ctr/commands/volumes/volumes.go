@@ -0,0 +1,143 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volumes
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/containerd/containerd/v2/cmd/ctr/commands"
+	"github.com/containerd/containerd/v2/defaults"
+	"github.com/containerd/containerd/v2/pkg/volumes"
+)
+
+// Command is the cli command for managing volumes
+var Command = &cli.Command{
+	Name:    "volumes",
+	Usage:   "Manage local volumes",
+	Aliases: []string{"volume"},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "volume-root",
+			Usage: "Root directory volumes are stored under",
+			Value: filepath.Join(defaults.DefaultRootDir, "volumes"),
+		},
+	},
+	Subcommands: []*cli.Command{
+		createCommand,
+		listCommand,
+		removeCommand,
+	},
+}
+
+func storeFromContext(cliContext *cli.Context) (*volumes.Store, error) {
+	return volumes.NewStore(cliContext.String("volume-root"))
+}
+
+var createCommand = &cli.Command{
+	Name:      "create",
+	Usage:     "Create a volume",
+	ArgsUsage: "[flags] NAME",
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  "label",
+			Usage: "Labels to attach to the volume",
+		},
+	},
+	Action: func(cliContext *cli.Context) error {
+		name := cliContext.Args().First()
+		if name == "" {
+			return errors.New("volume name must be provided")
+		}
+		store, err := storeFromContext(cliContext)
+		if err != nil {
+			return err
+		}
+		v, err := store.Create(name, commands.LabelArgs(cliContext.StringSlice("label")))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(cliContext.App.Writer, v.Path)
+		return nil
+	},
+}
+
+var listCommand = &cli.Command{
+	Name:    "list",
+	Aliases: []string{"ls"},
+	Usage:   "List volumes",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "quiet",
+			Aliases: []string{"q"},
+			Usage:   "Print only the volume name",
+		},
+		commands.FormatFlag,
+	},
+	Action: func(cliContext *cli.Context) error {
+		store, err := storeFromContext(cliContext)
+		if err != nil {
+			return err
+		}
+		vols, err := store.List()
+		if err != nil {
+			return err
+		}
+		if handled, err := commands.PrintFormatted(cliContext.String("format"), vols); handled {
+			return err
+		}
+		if cliContext.Bool("quiet") {
+			for _, v := range vols {
+				fmt.Fprintln(cliContext.App.Writer, v.Name)
+			}
+			return nil
+		}
+		w := tabwriter.NewWriter(os.Stdout, 4, 8, 4, ' ', 0)
+		fmt.Fprintln(w, "NAME\tPATH\tCREATED\t")
+		for _, v := range vols {
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", v.Name, v.Path, v.CreatedAt.Format("2006-01-02 15:04:05"))
+		}
+		return w.Flush()
+	},
+}
+
+var removeCommand = &cli.Command{
+	Name:      "remove",
+	Aliases:   []string{"rm"},
+	Usage:     "Remove one or more volumes",
+	ArgsUsage: "NAME [NAME...]",
+	Action: func(cliContext *cli.Context) error {
+		if cliContext.NArg() == 0 {
+			return errors.New("volume name must be provided")
+		}
+		store, err := storeFromContext(cliContext)
+		if err != nil {
+			return err
+		}
+		for _, name := range cliContext.Args().Slice() {
+			if err := store.Delete(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
@@ -19,8 +19,11 @@ package diff
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"os"
+	"sort"
+	"sync"
 
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/pkg/archive/compression"
@@ -31,10 +34,74 @@ import (
 var (
 	handlers []Handler
 
+	// dynamicMu guards the processors registered at runtime through the
+	// stream processors API.
+	dynamicMu       sync.Mutex
+	dynamicHandlers = map[string]Handler{}
+	dynamicInfos    = map[string]ProcessorInfo{}
+
 	// ErrNoProcessor is returned when no stream processor is available for a media-type
 	ErrNoProcessor = errors.New("no processor for media-type")
 )
 
+// ProcessorInfo describes a binary stream processor registered at runtime.
+type ProcessorInfo struct {
+	// ID uniquely names the processor.
+	ID string `json:"id"`
+	// Accepts are the media-types the processor accepts.
+	Accepts []string `json:"accepts"`
+	// Returns is the media-type the processor produces.
+	Returns string `json:"returns"`
+	// Path of the processor binary.
+	Path string `json:"path"`
+	// Args passed to the binary.
+	Args []string `json:"args,omitempty"`
+	// Env passed to the binary.
+	Env []string `json:"env,omitempty"`
+}
+
+// RegisterDynamicProcessor registers a binary stream processor at runtime.
+// Dynamic processors take precedence over the statically configured ones
+// and can be removed again with RemoveDynamicProcessor.
+func RegisterDynamicProcessor(info ProcessorInfo) error {
+	if info.ID == "" || info.Returns == "" || len(info.Accepts) == 0 || info.Path == "" {
+		return errors.New("stream processor requires id, accepts, returns and path")
+	}
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	if _, ok := dynamicInfos[info.ID]; ok {
+		return fmt.Errorf("stream processor %q already registered", info.ID)
+	}
+	dynamicHandlers[info.ID] = BinaryHandler(info.ID, info.Returns, info.Accepts, info.Path, info.Args, info.Env)
+	dynamicInfos[info.ID] = info
+	return nil
+}
+
+// RemoveDynamicProcessor removes a runtime registered stream processor.
+func RemoveDynamicProcessor(id string) error {
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	if _, ok := dynamicInfos[id]; !ok {
+		return fmt.Errorf("no such stream processor %q", id)
+	}
+	delete(dynamicHandlers, id)
+	delete(dynamicInfos, id)
+	return nil
+}
+
+// DynamicProcessors returns the runtime registered stream processors
+// ordered by id.
+func DynamicProcessors() []ProcessorInfo {
+	dynamicMu.Lock()
+	defer dynamicMu.Unlock()
+	infos := make([]ProcessorInfo, 0, len(dynamicInfos))
+	for _, info := range dynamicInfos {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
 func init() {
 	// register the default compression handler
 	RegisterProcessor(compressedHandler)
@@ -47,6 +114,19 @@ func RegisterProcessor(handler Handler) {
 
 // GetProcessor returns the processor for a media-type
 func GetProcessor(ctx context.Context, stream StreamProcessor, payloads map[string]typeurl.Any) (StreamProcessor, error) {
+	dynamicMu.Lock()
+	dynamic := make([]Handler, 0, len(dynamicHandlers))
+	for _, handler := range dynamicHandlers {
+		dynamic = append(dynamic, handler)
+	}
+	dynamicMu.Unlock()
+	// processors registered at runtime take precedence over the static ones
+	for _, handler := range dynamic {
+		processor, ok := handler(ctx, stream.MediaType())
+		if ok {
+			return processor(ctx, stream, payloads)
+		}
+	}
 	// reverse this list so that user configured handlers come up first
 	for i := len(handlers) - 1; i >= 0; i-- {
 		processor, ok := handlers[i](ctx, stream.MediaType())
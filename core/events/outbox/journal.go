@@ -0,0 +1,120 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package outbox
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/containerd/v2/core/events"
+)
+
+// Journal appends observed envelopes as NDJSON to daily files, providing
+// the durable record `ctr events --since/--until` replays for forensics.
+// Lines use the same format as the outbox's on-disk records and the
+// webhook payload.
+type Journal struct {
+	mu       sync.Mutex
+	dir      string
+	maxFiles int
+	// current is the daily file last appended to; rotation only runs
+	// when it rolls over.
+	current string
+}
+
+// NewJournal returns a Journal writing under dir, keeping at most
+// maxFiles daily files (default 14).
+func NewJournal(dir string, maxFiles int) (*Journal, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	if maxFiles <= 0 {
+		maxFiles = 14
+	}
+	return &Journal{
+		dir:      dir,
+		maxFiles: maxFiles,
+	}, nil
+}
+
+// Append writes one envelope to the current day's journal file.
+func (j *Journal) Append(envelope *events.Envelope) error {
+	r := record{
+		Timestamp: envelope.Timestamp,
+		Namespace: envelope.Namespace,
+		Topic:     envelope.Topic,
+	}
+	if envelope.Event != nil {
+		r.TypeURL = envelope.Event.GetTypeUrl()
+		r.Value = envelope.Event.GetValue()
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	name := filepath.Join(j.dir, "events-"+time.Now().UTC().Format("20060102")+".ndjson")
+	f, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, werr := f.Write(line)
+	cerr := f.Close()
+	if werr != nil {
+		return werr
+	}
+	if cerr != nil {
+		return cerr
+	}
+	if name != j.current {
+		j.current = name
+		return j.rotate()
+	}
+	return nil
+}
+
+// rotate drops the oldest daily files beyond the bound. Daily file names
+// sort chronologically.
+func (j *Journal) rotate() error {
+	entries, err := os.ReadDir(j.dir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".ndjson" {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) <= j.maxFiles {
+		return nil
+	}
+	sort.Strings(names)
+	for _, name := range names[:len(names)-j.maxFiles] {
+		if err := os.Remove(filepath.Join(j.dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
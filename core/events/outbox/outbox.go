@@ -0,0 +1,247 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package outbox forwards events from the exchange to an external sink
+// with at-least-once semantics. Envelopes are persisted to disk before
+// delivery, so events published while the sink is down survive restarts
+// and are retried; envelopes exhausted after the maximum retries move to
+// a dead-letter directory and are counted in metrics.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
+	goMetrics "github.com/docker/go-metrics"
+
+	"github.com/containerd/containerd/v2/core/events"
+)
+
+var (
+	deliveredCounter goMetrics.Counter
+	droppedCounter   goMetrics.Counter
+)
+
+func init() {
+	ns := goMetrics.NewNamespace("containerd", "event_outbox", nil)
+	deliveredCounter = ns.NewCounter("delivered", "number of events delivered to the external sink")
+	droppedCounter = ns.NewCounter("dropped", "number of events dropped after exhausting retries")
+	goMetrics.Register(ns)
+}
+
+// deadLetterDir is the subdirectory holding envelopes dropped after the
+// maximum retries, kept for operator inspection.
+const deadLetterDir = "dead-letter"
+
+// Sink delivers an envelope to an external system.
+type Sink interface {
+	Deliver(ctx context.Context, envelope *events.Envelope) error
+}
+
+// record is the on-disk representation of a queued envelope.
+type record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Namespace string    `json:"namespace"`
+	Topic     string    `json:"topic"`
+	TypeURL   string    `json:"typeUrl,omitempty"`
+	Value     []byte    `json:"value,omitempty"`
+	// Attempts counts delivery attempts so far. Only meaningful for
+	// queued records; journal lines omit it.
+	Attempts int `json:"attempts,omitempty"`
+}
+
+// Outbox persists and forwards envelopes.
+type Outbox struct {
+	dir           string
+	sink          Sink
+	maxRetries    int
+	retryInterval time.Duration
+	seq           atomic.Uint64
+}
+
+// Opt configures an Outbox.
+type Opt func(*Outbox)
+
+// WithMaxRetries bounds delivery attempts per envelope; afterwards the
+// envelope moves to the dead-letter directory. Default is 10.
+func WithMaxRetries(n int) Opt {
+	return func(o *Outbox) {
+		o.maxRetries = n
+	}
+}
+
+// WithRetryInterval sets the pause after a failed delivery. Default 5s.
+func WithRetryInterval(d time.Duration) Opt {
+	return func(o *Outbox) {
+		o.retryInterval = d
+	}
+}
+
+// New returns an Outbox persisting under dir and delivering to sink.
+func New(dir string, sink Sink, opts ...Opt) (*Outbox, error) {
+	if err := os.MkdirAll(filepath.Join(dir, deadLetterDir), 0700); err != nil {
+		return nil, err
+	}
+	o := &Outbox{
+		dir:           dir,
+		sink:          sink,
+		maxRetries:    10,
+		retryInterval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o, nil
+}
+
+// Enqueue persists the envelope for delivery.
+func (o *Outbox) Enqueue(envelope *events.Envelope) error {
+	r := record{
+		Timestamp: envelope.Timestamp,
+		Namespace: envelope.Namespace,
+		Topic:     envelope.Topic,
+	}
+	if envelope.Event != nil {
+		r.TypeURL = envelope.Event.GetTypeUrl()
+		r.Value = envelope.Event.GetValue()
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%020d-%012d.json", envelope.Timestamp.UnixNano(), o.seq.Add(1))
+	return os.WriteFile(filepath.Join(o.dir, name), data, 0600)
+}
+
+// pending returns the queued envelope files in delivery order.
+func (o *Outbox) pending() ([]string, error) {
+	entries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// deliver attempts delivery of a single queued file, returning whether it
+// was removed from the queue (delivered or dead-lettered).
+func (o *Outbox) deliver(ctx context.Context, name string) (bool, error) {
+	path := filepath.Join(o.dir, name)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	var r record
+	if err := json.Unmarshal(data, &r); err != nil {
+		// Corrupt records go straight to the dead-letter directory.
+		droppedCounter.Inc()
+		return true, os.Rename(path, filepath.Join(o.dir, deadLetterDir, name))
+	}
+
+	envelope := &events.Envelope{
+		Timestamp: r.Timestamp,
+		Namespace: r.Namespace,
+		Topic:     r.Topic,
+	}
+	if r.TypeURL != "" {
+		envelope.Event = &anyPayload{typeURL: r.TypeURL, value: r.Value}
+	}
+
+	if err := o.sink.Deliver(ctx, envelope); err != nil {
+		r.Attempts++
+		if r.Attempts >= o.maxRetries {
+			log.G(ctx).WithError(err).Warnf("dropping event %q for %q after %d attempts", r.Topic, r.Namespace, r.Attempts)
+			droppedCounter.Inc()
+			return true, os.Rename(path, filepath.Join(o.dir, deadLetterDir, name))
+		}
+		if data, merr := json.Marshal(r); merr == nil {
+			if werr := os.WriteFile(path, data, 0600); werr != nil {
+				return false, werr
+			}
+		}
+		return false, err
+	}
+	deliveredCounter.Inc()
+	return true, os.Remove(path)
+}
+
+// Run forwards queued envelopes until the context is cancelled. After a
+// fully successful pass over a non-empty queue the next scan starts
+// immediately so sustained event flow is delivered without added
+// latency; the retry interval only applies after a sink failure, and an
+// idle queue is polled every second.
+func (o *Outbox) Run(ctx context.Context) {
+	for {
+		names, err := o.pending()
+		if err != nil {
+			log.G(ctx).WithError(err).Error("failed to read event outbox")
+		}
+		var failed bool
+		for _, name := range names {
+			if ctx.Err() != nil {
+				return
+			}
+			done, err := o.deliver(ctx, name)
+			if err != nil && !done {
+				failed = true
+				break
+			}
+		}
+		if !failed && len(names) > 0 {
+			// Keep draining while events keep arriving.
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		wait := time.Second
+		if failed {
+			wait = o.retryInterval
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// anyPayload carries a stored event payload without re-registering its
+// type.
+type anyPayload struct {
+	typeURL string
+	value   []byte
+}
+
+func (p *anyPayload) GetTypeUrl() string { return p.typeURL }
+func (p *anyPayload) GetValue() []byte   { return p.value }
+
+var _ typeurl.Any = (*anyPayload)(nil)
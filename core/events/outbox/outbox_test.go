@@ -0,0 +1,148 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/containerd/v2/core/events"
+)
+
+type fakeSink struct {
+	fail      bool
+	delivered []*events.Envelope
+}
+
+func (s *fakeSink) Deliver(ctx context.Context, envelope *events.Envelope) error {
+	if s.fail {
+		return errors.New("sink down")
+	}
+	s.delivered = append(s.delivered, envelope)
+	return nil
+}
+
+func enqueueTestEvent(t *testing.T, ob *Outbox, topic string) {
+	require.NoError(t, ob.Enqueue(&events.Envelope{
+		Timestamp: time.Now(),
+		Namespace: "default",
+		Topic:     topic,
+	}))
+}
+
+func drain(t *testing.T, ob *Outbox) {
+	names, err := ob.pending()
+	require.NoError(t, err)
+	for _, name := range names {
+		ob.deliver(context.Background(), name)
+	}
+}
+
+func TestOutboxDelivery(t *testing.T) {
+	sink := &fakeSink{}
+	ob, err := New(t.TempDir(), sink)
+	require.NoError(t, err)
+
+	enqueueTestEvent(t, ob, "/tasks/exit")
+	enqueueTestEvent(t, ob, "/tasks/create")
+	drain(t, ob)
+
+	require.Len(t, sink.delivered, 2)
+	assert.Equal(t, "/tasks/exit", sink.delivered[0].Topic)
+	assert.Equal(t, "/tasks/create", sink.delivered[1].Topic)
+
+	names, err := ob.pending()
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestOutboxRetryAndDeadLetter(t *testing.T) {
+	dir := t.TempDir()
+	sink := &fakeSink{fail: true}
+	ob, err := New(dir, sink, WithMaxRetries(2), WithRetryInterval(time.Millisecond))
+	require.NoError(t, err)
+
+	enqueueTestEvent(t, ob, "/tasks/exit")
+
+	// First failure keeps the event queued with an incremented attempt.
+	drain(t, ob)
+	names, err := ob.pending()
+	require.NoError(t, err)
+	require.Len(t, names, 1)
+
+	// Second failure exhausts the retries and dead-letters the event.
+	drain(t, ob)
+	names, err = ob.pending()
+	require.NoError(t, err)
+	assert.Empty(t, names)
+	dead, err := os.ReadDir(filepath.Join(dir, deadLetterDir))
+	require.NoError(t, err)
+	assert.Len(t, dead, 1)
+
+	// Once the sink recovers, new events flow again.
+	sink.fail = false
+	enqueueTestEvent(t, ob, "/tasks/create")
+	drain(t, ob)
+	require.Len(t, sink.delivered, 1)
+}
+
+func TestJournalAppendAndRotate(t *testing.T) {
+	dir := t.TempDir()
+	j, err := NewJournal(dir, 1)
+	require.NoError(t, err)
+
+	// Older daily files beyond the bound are dropped on append.
+	stale := filepath.Join(dir, "events-20000101.ndjson")
+	require.NoError(t, os.WriteFile(stale, nil, 0600))
+
+	require.NoError(t, j.Append(&events.Envelope{
+		Timestamp: time.Now(),
+		Namespace: "default",
+		Topic:     "/tasks/exit",
+	}))
+	require.NoError(t, j.Append(&events.Envelope{
+		Timestamp: time.Now(),
+		Namespace: "default",
+		Topic:     "/tasks/create",
+	}))
+
+	_, err = os.Stat(stale)
+	assert.True(t, os.IsNotExist(err))
+
+	name := filepath.Join(dir, "events-"+time.Now().UTC().Format("20060102")+".ndjson")
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	var topics []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		topics = append(topics, r.Topic)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"/tasks/exit", "/tasks/create"}, topics)
+}
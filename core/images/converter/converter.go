@@ -112,6 +112,11 @@ func Convert(ctx context.Context, client Client, dstRef, srcRef string, opts ...
 
 	dstImg := srcImg
 	dstImg.Name = dstRef
+	dstImg.Labels = images.WithProvenance(dstImg.Labels, images.Provenance{
+		Source: "convert",
+		Ref:    srcRef,
+		Digest: srcImg.Target.Digest.String(),
+	})
 	if dstDesc != nil {
 		dstImg.Target = *dstDesc
 	}
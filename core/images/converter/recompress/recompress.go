@@ -0,0 +1,165 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package recompress provides a layer conversion function changing the
+// compression algorithm of layers, e.g. gzip to zstd and back.
+package recompress
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/containerd/errdefs"
+	"github.com/klauspost/compress/zstd"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/images/converter"
+	"github.com/containerd/containerd/v2/pkg/archive/compression"
+)
+
+// Type is a target layer compression algorithm.
+type Type string
+
+const (
+	// Gzip re-compresses layers as tar+gzip.
+	Gzip Type = "gzip"
+	// Zstd re-compresses layers as tar+zstd.
+	Zstd Type = "zstd"
+)
+
+// Parse returns the Type named by s.
+func Parse(s string) (Type, error) {
+	switch Type(s) {
+	case Gzip:
+		return Gzip, nil
+	case Zstd:
+		return Zstd, nil
+	default:
+		return "", fmt.Errorf("unknown compression %q, expected %q or %q", s, Gzip, Zstd)
+	}
+}
+
+// LayerConvertFunc returns a converter.ConvertFunc re-compressing layers
+// with the given algorithm. Media type is changed accordingly, e.g.
+// "application/vnd.oci.image.layer.v1.tar+gzip" -> "application/vnd.oci.image.layer.v1.tar+zstd".
+func LayerConvertFunc(compressionType Type) converter.ConvertFunc {
+	return func(ctx context.Context, cs content.Store, desc ocispec.Descriptor) (*ocispec.Descriptor, error) {
+		newMediaType, ok := convertMediaType(desc.MediaType, compressionType)
+		if !images.IsLayerType(desc.MediaType) || !ok || newMediaType == desc.MediaType {
+			// No conversion. No need to return an error here.
+			return nil, nil
+		}
+		info, err := cs.Info(ctx, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+		readerAt, err := cs.ReaderAt(ctx, desc)
+		if err != nil {
+			return nil, err
+		}
+		defer readerAt.Close()
+		sr := io.NewSectionReader(readerAt, 0, desc.Size)
+		newR, err := compression.DecompressStream(sr)
+		if err != nil {
+			return nil, err
+		}
+		defer newR.Close()
+
+		ref := fmt.Sprintf("convert-recompress-%s-from-%s", compressionType, desc.Digest)
+		w, err := content.OpenWriter(ctx, cs, content.WithRef(ref))
+		if err != nil {
+			return nil, err
+		}
+		defer w.Close()
+
+		// Reset the writing position
+		// Old writer possibly remains without aborted
+		// (e.g. conversion interrupted by a signal)
+		if err := w.Truncate(0); err != nil {
+			return nil, err
+		}
+
+		cw, err := newCompressor(w, compressionType)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.Copy(cw, newR); err != nil {
+			return nil, err
+		}
+		if err := cw.Close(); err != nil {
+			return nil, err
+		}
+		if err := newR.Close(); err != nil {
+			return nil, err
+		}
+
+		// Retain labels such as "containerd.io/distribution.source.*",
+		// including the uncompressed digest which is unchanged.
+		if err = w.Commit(ctx, 0, "", content.WithLabels(info.Labels)); err != nil && !errdefs.IsAlreadyExists(err) {
+			return nil, err
+		}
+		st, err := cs.Info(ctx, w.Digest())
+		if err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+
+		newDesc := desc
+		newDesc.Digest = st.Digest
+		newDesc.Size = st.Size
+		newDesc.MediaType = newMediaType
+		return &newDesc, nil
+	}
+}
+
+func newCompressor(w io.Writer, compressionType Type) (io.WriteCloser, error) {
+	switch compressionType {
+	case Gzip:
+		return gzip.NewWriter(w), nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	default:
+		return nil, fmt.Errorf("unknown compression %q", compressionType)
+	}
+}
+
+// convertMediaType maps a layer media type onto the equivalent media type
+// with the target compression. It reports false for media types that do
+// not support the target compression, e.g. Docker media types with zstd.
+func convertMediaType(mt string, compressionType Type) (string, bool) {
+	switch mt {
+	case images.MediaTypeDockerSchema2Layer, images.MediaTypeDockerSchema2LayerGzip:
+		if compressionType == Zstd {
+			// There is no Docker media type for zstd layers.
+			return mt, false
+		}
+		return images.MediaTypeDockerSchema2LayerGzip, true
+	case ocispec.MediaTypeImageLayer, ocispec.MediaTypeImageLayerGzip, ocispec.MediaTypeImageLayerZstd:
+		switch compressionType {
+		case Gzip:
+			return ocispec.MediaTypeImageLayerGzip, true
+		case Zstd:
+			return ocispec.MediaTypeImageLayerZstd, true
+		}
+	}
+	return mt, false
+}
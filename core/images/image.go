@@ -31,6 +31,12 @@ import (
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
+// IsPinned reports whether the image is marked as pinned and must be kept
+// by garbage collection and prune.
+func IsPinned(img Image) bool {
+	return img.Labels[PinnedLabel] == PinnedLabelValue
+}
+
 // Image provides the model for how containerd views container images.
 type Image struct {
 	// Name of the image.
@@ -125,3 +125,10 @@ func TestValidateMediaType(t *testing.T) {
 		assert.Error(t, err, "document should not be valid")
 	})
 }
+
+func TestIsPinned(t *testing.T) {
+	assert.False(t, IsPinned(Image{}))
+	assert.False(t, IsPinned(Image{Labels: map[string]string{PinnedLabel: ""}}))
+	assert.False(t, IsPinned(Image{Labels: map[string]string{PinnedLabel: "true"}}))
+	assert.True(t, IsPinned(Image{Labels: map[string]string{PinnedLabel: PinnedLabelValue}}))
+}
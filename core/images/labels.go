@@ -18,4 +18,10 @@ package images
 
 const (
 	ConvertedDockerSchema1LabelKey = "io.containerd.image/converted-docker-schema1"
+
+	// PinnedLabel marks an image as pinned: garbage collection, prune and
+	// CRI image GC keep pinned images regardless of use.
+	PinnedLabel = "containerd.io/image.pinned"
+	// PinnedLabelValue is the value of PinnedLabel on pinned images.
+	PinnedLabelValue = "pinned"
 )
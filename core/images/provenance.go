@@ -0,0 +1,73 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ProvenanceLabel carries the JSON encoded Provenance record of an image,
+// recorded when an image enters the store through pull, import, convert
+// or commit, for supply-chain audits.
+const ProvenanceLabel = "containerd.io/provenance"
+
+// Provenance records where an image came from.
+type Provenance struct {
+	// Source is the operation that produced the image: "pull", "import",
+	// "convert" or "commit".
+	Source string `json:"source"`
+	// Ref is the reference the image was pulled from, or the source
+	// reference for conversions.
+	Ref string `json:"ref,omitempty"`
+	// Digest is the registry digest the reference resolved to.
+	Digest string `json:"digest,omitempty"`
+	// File is the archive path for imports, when known.
+	File string `json:"file,omitempty"`
+	// CreatedAt is when the image entered the store.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// WithProvenance records the provenance on the image's labels. Errors
+// marshalling are ignored since provenance is best effort metadata.
+func WithProvenance(labels map[string]string, p Provenance) map[string]string {
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now().UTC()
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return labels
+	}
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ProvenanceLabel] = string(data)
+	return labels
+}
+
+// GetProvenance returns the provenance recorded on the image, if any.
+func GetProvenance(img Image) (Provenance, bool) {
+	value, ok := img.Labels[ProvenanceLabel]
+	if !ok {
+		return Provenance{}, false
+	}
+	var p Provenance
+	if err := json.Unmarshal([]byte(value), &p); err != nil {
+		return Provenance{}, false
+	}
+	return p, true
+}
@@ -0,0 +1,94 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+
+	"github.com/containerd/platforms"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/containerd/containerd/v2/core/content"
+)
+
+// WalkOpt configures WalkPlatforms.
+type WalkOpt func(*walkConfig)
+
+type walkConfig struct {
+	platform    platforms.Matcher
+	concurrency int64
+	progress    func(ocispec.Descriptor)
+}
+
+// WithWalkPlatform restricts the walk to descriptors matching the
+// platform. The default is the host platform; use platforms.All to walk
+// every platform explicitly.
+func WithWalkPlatform(m platforms.Matcher) WalkOpt {
+	return func(c *walkConfig) {
+		c.platform = m
+	}
+}
+
+// WithWalkConcurrency bounds how many descriptors are handled in
+// parallel. The default is 1 (sequential).
+func WithWalkConcurrency(n int64) WalkOpt {
+	return func(c *walkConfig) {
+		c.concurrency = n
+	}
+}
+
+// WithWalkProgress invokes the callback for every descriptor before it is
+// handled, e.g. to drive progress output.
+func WithWalkProgress(fn func(ocispec.Descriptor)) WalkOpt {
+	return func(c *walkConfig) {
+		c.progress = fn
+	}
+}
+
+// WalkPlatforms dispatches the handler over the platform-matching subset
+// of the image graph rooted at root, with bounded parallelism and an
+// optional progress callback. It wraps the Dispatch/ChildrenHandler/
+// FilterPlatforms combination that downstream consumers otherwise
+// assemble by hand, defaulting to the host platform so callers do not
+// walk every platform by accident.
+func WalkPlatforms(ctx context.Context, provider content.Provider, root ocispec.Descriptor, handler HandlerFunc, opts ...WalkOpt) error {
+	config := walkConfig{
+		platform:    platforms.Default(),
+		concurrency: 1,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	children := FilterPlatforms(ChildrenHandler(provider), config.platform)
+	walker := HandlerFunc(func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+		if config.progress != nil {
+			config.progress(desc)
+		}
+		if _, err := handler(ctx, desc); err != nil {
+			return nil, err
+		}
+		return children(ctx, desc)
+	})
+
+	var limiter *semaphore.Weighted
+	if config.concurrency > 1 {
+		limiter = semaphore.NewWeighted(config.concurrency)
+	}
+	return Dispatch(ctx, walker, limiter, root)
+}
@@ -0,0 +1,146 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/containerd/platforms"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/containerd/v2/core/content"
+)
+
+// blobProvider serves blobs from memory for walking tests.
+type blobProvider struct {
+	blobs map[digest.Digest][]byte
+}
+
+func (p *blobProvider) add(data []byte) ocispec.Descriptor {
+	if p.blobs == nil {
+		p.blobs = map[digest.Digest][]byte{}
+	}
+	dgst := digest.FromBytes(data)
+	p.blobs[dgst] = data
+	return ocispec.Descriptor{Digest: dgst, Size: int64(len(data))}
+}
+
+func (p *blobProvider) ReaderAt(ctx context.Context, desc ocispec.Descriptor) (content.ReaderAt, error) {
+	data, ok := p.blobs[desc.Digest]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found", desc.Digest)
+	}
+	return &readerAt{Reader: bytes.NewReader(data), size: int64(len(data))}, nil
+}
+
+type readerAt struct {
+	*bytes.Reader
+	size int64
+}
+
+func (r *readerAt) Close() error { return nil }
+
+func (r *readerAt) Size() int64 { return r.size }
+
+func buildTestIndex(t *testing.T, provider *blobProvider) (ocispec.Descriptor, map[string]digest.Digest) {
+	manifests := map[string]digest.Digest{}
+	var descs []ocispec.Descriptor
+	for _, plat := range []ocispec.Platform{
+		{OS: "linux", Architecture: "amd64"},
+		{OS: "linux", Architecture: "arm64"},
+	} {
+		configDesc := provider.add([]byte(`{"os":"` + plat.OS + `","architecture":"` + plat.Architecture + `"}`))
+		configDesc.MediaType = ocispec.MediaTypeImageConfig
+		layerDesc := provider.add([]byte("layer-" + plat.Architecture))
+		layerDesc.MediaType = ocispec.MediaTypeImageLayerGzip
+
+		manifest := ocispec.Manifest{
+			Versioned: specs.Versioned{SchemaVersion: 2},
+			MediaType: ocispec.MediaTypeImageManifest,
+			Config:    configDesc,
+			Layers:    []ocispec.Descriptor{layerDesc},
+		}
+		data, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		desc := provider.add(data)
+		desc.MediaType = ocispec.MediaTypeImageManifest
+		plat := plat
+		desc.Platform = &plat
+		manifests[plat.Architecture] = desc.Digest
+		descs = append(descs, desc)
+	}
+
+	index := ocispec.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispec.MediaTypeImageIndex,
+		Manifests: descs,
+	}
+	data, err := json.Marshal(index)
+	require.NoError(t, err)
+	desc := provider.add(data)
+	desc.MediaType = ocispec.MediaTypeImageIndex
+	return desc, manifests
+}
+
+func TestWalkPlatforms(t *testing.T) {
+	provider := &blobProvider{}
+	root, manifests := buildTestIndex(t, provider)
+
+	visited := func(opts ...WalkOpt) map[digest.Digest]bool {
+		var mu sync.Mutex
+		seen := map[digest.Digest]bool{}
+		err := WalkPlatforms(context.Background(), provider, root, func(ctx context.Context, desc ocispec.Descriptor) ([]ocispec.Descriptor, error) {
+			mu.Lock()
+			seen[desc.Digest] = true
+			mu.Unlock()
+			return nil, nil
+		}, opts...)
+		require.NoError(t, err)
+		return seen
+	}
+
+	// Only the matching platform's manifest is walked.
+	seen := visited(WithWalkPlatform(platforms.OnlyStrict(ocispec.Platform{OS: "linux", Architecture: "amd64"})))
+	assert.True(t, seen[root.Digest])
+	assert.True(t, seen[manifests["amd64"]])
+	assert.False(t, seen[manifests["arm64"]])
+
+	// platforms.All walks everything, also with parallelism and progress.
+	var progressCount int
+	var mu sync.Mutex
+	seen = visited(
+		WithWalkPlatform(platforms.All),
+		WithWalkConcurrency(4),
+		WithWalkProgress(func(ocispec.Descriptor) {
+			mu.Lock()
+			progressCount++
+			mu.Unlock()
+		}),
+	)
+	assert.True(t, seen[manifests["amd64"]])
+	assert.True(t, seen[manifests["arm64"]])
+	assert.Equal(t, len(seen), progressCount)
+}
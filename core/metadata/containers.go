@@ -84,8 +84,34 @@ func (s *containerStore) List(ctx context.Context, fs ...string) ([]containers.C
 		return nil, fmt.Errorf("%s: %w", err.Error(), errdefs.ErrInvalidArgument)
 	}
 
+	// Serve simple label filters from the label index once it has been
+	// built for the namespace, avoiding a scan over every container for
+	// queries such as the restart monitor's status label filter.
+	if len(fs) == 1 && s.db.containerLabels.ready(namespace) {
+		if key, value, hasValue, ok := parseLabelFilter(fs[0]); ok {
+			var m []containers.Container
+			for _, id := range s.db.containerLabels.ids(namespace, key, value, hasValue) {
+				container, err := s.Get(ctx, id)
+				if err != nil {
+					if errdefs.IsNotFound(err) {
+						continue
+					}
+					return nil, err
+				}
+				if filter.Match(adaptContainer(container)) {
+					m = append(m, container)
+				}
+			}
+			return m, nil
+		}
+	}
+
 	var m []containers.Container
 
+	// The generation must be captured before the read view opens so a
+	// commit racing with the scan discards the build.
+	buildGeneration := s.db.containerLabels.startBuild(namespace)
+
 	if err := view(ctx, s.db, func(tx *bolt.Tx) error {
 		bkt := getContainersBucket(tx, namespace)
 		if bkt == nil {
@@ -103,6 +129,7 @@ func (s *containerStore) List(ctx context.Context, fs ...string) ([]containers.C
 				return fmt.Errorf("failed to read container %q: %w", string(k), err)
 			}
 
+			s.db.containerLabels.set(namespace, container.ID, container.Labels)
 			if filter.Match(adaptContainer(container)) {
 				m = append(m, container)
 			}
@@ -111,6 +138,7 @@ func (s *containerStore) List(ctx context.Context, fs ...string) ([]containers.C
 	}); err != nil {
 		return nil, err
 	}
+	s.db.containerLabels.finishBuild(namespace, buildGeneration)
 
 	return m, nil
 }
@@ -259,7 +287,7 @@ func (s *containerStore) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	return update(ctx, s.db, func(tx *bolt.Tx) error {
+	if err := update(ctx, s.db, func(tx *bolt.Tx) error {
 		bkt := getContainersBucket(tx, namespace)
 		if bkt == nil {
 			return fmt.Errorf("cannot delete container %q in namespace %q: %w", id, namespace, errdefs.ErrNotFound)
@@ -275,7 +303,10 @@ func (s *containerStore) Delete(ctx context.Context, id string) error {
 		atomic.AddUint32(&s.db.dirty, 1)
 
 		return nil
-	})
+	}); err != nil {
+		return err
+	}
+	return nil
 }
 
 func validateContainer(container *containers.Container) error {
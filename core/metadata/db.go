@@ -96,6 +96,10 @@ type DB struct {
 	// atomically if outside of wlock.Lock.
 	dirty uint32
 
+	// containerLabels is the in-memory secondary index of container
+	// labels serving simple label filters without a full scan.
+	containerLabels *labelIndex
+
 	// dirtySS and dirtyCS flags keeps track of datastores which have had
 	// deletions since the last garbage collection. These datastores will
 	// be garbage collected during the next garbage collection. These
@@ -117,9 +121,10 @@ type DB struct {
 // bolt database, content store, and snapshotters.
 func NewDB(db *bolt.DB, cs content.Store, ss map[string]snapshots.Snapshotter, opts ...DBOpt) *DB {
 	m := &DB{
-		db:      db,
-		ss:      make(map[string]*snapshotter, len(ss)),
-		dirtySS: map[string]struct{}{},
+		db:              db,
+		ss:              make(map[string]*snapshotter, len(ss)),
+		dirtySS:         map[string]struct{}{},
+		containerLabels: newLabelIndex(),
 		dbopts: dbOptions{
 			shared: true,
 		},
@@ -129,6 +134,13 @@ func NewDB(db *bolt.DB, cs content.Store, ss map[string]snapshots.Snapshotter, o
 		opt(&m.dbopts)
 	}
 
+	// The label index must only reflect committed state; mutation
+	// callbacks run after a successful commit, while the stores' own
+	// writes may happen inside a caller transaction that can still fail.
+	m.RegisterMutationCallback(func(bool) {
+		m.containerLabels.invalidateAll()
+	})
+
 	// Initialize data stores
 	m.cs = newContentStore(m, m.dbopts.shared, cs)
 	for name, sn := range ss {
@@ -0,0 +1,152 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package metadata
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// labelFilterRe matches the simple label filter forms `labels."key"`,
+// `labels.key`, `labels."key"==value` and `labels.key=="value"` which are
+// served from the label index instead of scanning every record.
+var labelFilterRe = regexp.MustCompile(`^labels\.(?:"([^"\\]+)"|([A-Za-z0-9_./-]+))(?:==(?:"([^"\\]*)"|([A-Za-z0-9_./-]+)))?$`)
+
+// parseLabelFilter extracts the label key, and optionally the value, from
+// a simple label filter expression. hasValue reports whether the filter
+// constrains the value as well as requiring key presence.
+func parseLabelFilter(filter string) (key, value string, hasValue, ok bool) {
+	m := labelFilterRe.FindStringSubmatch(filter)
+	if m == nil {
+		return "", "", false, false
+	}
+	key = m[1]
+	if key == "" {
+		key = m[2]
+	}
+	if m[3] != "" || m[4] != "" {
+		value = m[3]
+		if value == "" {
+			value = m[4]
+		}
+		hasValue = true
+	}
+	return key, value, hasValue, true
+}
+
+// labelIndex is an in-memory secondary index from label key/value to
+// record ids, maintained per namespace. It is built lazily during a full
+// scan of a namespace and dropped whenever the database commits a
+// mutation (see DB.RegisterMutationCallback in NewDB), so it only ever
+// reflects committed state. Builds are tagged with a generation captured
+// before the scan's read view opens; a build that overlaps a commit sees
+// a changed generation and is discarded, since its view may predate the
+// commit. Frequent label filters (such as the restart monitor's status
+// label query) are served from the index between mutations.
+type labelIndex struct {
+	mu sync.RWMutex
+	// entries maps namespace -> id -> labels.
+	entries map[string]map[string]map[string]string
+	// built tracks which namespaces have been fully indexed.
+	built map[string]bool
+	// generation increments on every committed mutation.
+	generation uint64
+}
+
+func newLabelIndex() *labelIndex {
+	return &labelIndex{
+		entries: map[string]map[string]map[string]string{},
+		built:   map[string]bool{},
+	}
+}
+
+// ready reports whether the namespace has been indexed.
+func (i *labelIndex) ready(namespace string) bool {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return i.built[namespace]
+}
+
+// startBuild prepares a namespace rebuild, clearing any partial entries
+// and returning the generation the build is based on. It must be called
+// before the scan's read view is opened.
+func (i *labelIndex) startBuild(namespace string) uint64 {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.built, namespace)
+	delete(i.entries, namespace)
+	return i.generation
+}
+
+// finishBuild marks the namespace as fully indexed unless a mutation
+// committed since startBuild, in which case the build may be based on a
+// stale read view and is discarded.
+func (i *labelIndex) finishBuild(namespace string, generation uint64) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.generation != generation {
+		delete(i.entries, namespace)
+		return
+	}
+	i.built[namespace] = true
+}
+
+// invalidateAll drops the whole index after a committed mutation.
+func (i *labelIndex) invalidateAll() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.generation++
+	i.built = map[string]bool{}
+	i.entries = map[string]map[string]map[string]string{}
+}
+
+// set records the labels of the record, replacing any previous entry.
+func (i *labelIndex) set(namespace, id string, labels map[string]string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	ns, ok := i.entries[namespace]
+	if !ok {
+		ns = map[string]map[string]string{}
+		i.entries[namespace] = ns
+	}
+	copied := make(map[string]string, len(labels))
+	for k, v := range labels {
+		copied[k] = v
+	}
+	ns[id] = copied
+}
+
+// ids returns the ids in the namespace carrying the label key, optionally
+// constrained to the value, in sorted order.
+func (i *labelIndex) ids(namespace, key, value string, hasValue bool) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	var ids []string
+	for id, labels := range i.entries[namespace] {
+		v, ok := labels[key]
+		if !ok {
+			continue
+		}
+		if hasValue && v != value {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
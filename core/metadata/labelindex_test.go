@@ -0,0 +1,95 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelFilter(t *testing.T) {
+	for _, tc := range []struct {
+		filter   string
+		key      string
+		value    string
+		hasValue bool
+		ok       bool
+	}{
+		{filter: `labels."containerd.io/restart.status"`, key: "containerd.io/restart.status", ok: true},
+		{filter: `labels.foo`, key: "foo", ok: true},
+		{filter: `labels.foo==bar`, key: "foo", value: "bar", hasValue: true, ok: true},
+		{filter: `labels."foo"=="bar baz"`, key: "foo", value: "bar baz", hasValue: true, ok: true},
+		{filter: `id==abc`, ok: false},
+		{filter: `labels.foo!=bar`, ok: false},
+		{filter: `labels.foo~=bar`, ok: false},
+	} {
+		key, value, hasValue, ok := parseLabelFilter(tc.filter)
+		assert.Equal(t, tc.ok, ok, tc.filter)
+		if !tc.ok {
+			continue
+		}
+		assert.Equal(t, tc.key, key, tc.filter)
+		assert.Equal(t, tc.value, value, tc.filter)
+		assert.Equal(t, tc.hasValue, hasValue, tc.filter)
+	}
+}
+
+func TestLabelIndex(t *testing.T) {
+	idx := newLabelIndex()
+	assert.False(t, idx.ready("default"))
+
+	gen := idx.startBuild("default")
+	idx.set("default", "c1", map[string]string{"status": "running", "app": "db"})
+	idx.set("default", "c2", map[string]string{"status": "stopped"})
+	idx.finishBuild("default", gen)
+	assert.True(t, idx.ready("default"))
+
+	assert.Equal(t, []string{"c1", "c2"}, idx.ids("default", "status", "", false))
+	assert.Equal(t, []string{"c1"}, idx.ids("default", "status", "running", true))
+	assert.Empty(t, idx.ids("default", "missing", "", false))
+
+	// Updates replace the previous labels.
+	idx.set("default", "c1", map[string]string{"status": "stopped"})
+	assert.Equal(t, []string{"c1", "c2"}, idx.ids("default", "status", "stopped", true))
+	assert.Empty(t, idx.ids("default", "app", "", false))
+
+	// A committed mutation drops the index, forcing a rebuild.
+	idx.invalidateAll()
+	assert.False(t, idx.ready("default"))
+	assert.Empty(t, idx.ids("default", "status", "", false))
+}
+
+func TestLabelIndexDiscardsRacingBuild(t *testing.T) {
+	idx := newLabelIndex()
+
+	// A commit between startBuild and finishBuild means the build's read
+	// view may predate the committed data; the build must be discarded.
+	gen := idx.startBuild("default")
+	idx.set("default", "c1", map[string]string{"status": "running"})
+	idx.invalidateAll()
+	idx.finishBuild("default", gen)
+	assert.False(t, idx.ready("default"))
+	assert.Empty(t, idx.ids("default", "status", "", false))
+
+	// The next build, untouched by commits, becomes ready.
+	gen = idx.startBuild("default")
+	idx.set("default", "c1", map[string]string{"status": "running"})
+	idx.finishBuild("default", gen)
+	assert.True(t, idx.ready("default"))
+	assert.Equal(t, []string{"c1"}, idx.ids("default", "status", "", false))
+}
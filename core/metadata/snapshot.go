@@ -19,6 +19,7 @@ package metadata
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -651,6 +652,21 @@ func (s *snapshotter) Commit(ctx context.Context, name, key string, opts ...snap
 	}
 
 	if rerr == nil {
+		// Record the committed layer's size and inode count on the
+		// snapshot info, so capacity tracking tools do not have to
+		// re-walk upperdirs after every build or commit.
+		if usage, err := s.Snapshotter.Usage(ctx, bname); err == nil {
+			if _, err := s.Update(ctx, snapshots.Info{
+				Name: name,
+				Labels: map[string]string{
+					snapshots.LabelSnapshotCommittedSize:   strconv.FormatInt(usage.Size, 10),
+					snapshots.LabelSnapshotCommittedInodes: strconv.FormatInt(usage.Inodes, 10),
+				},
+			}, "labels."+snapshots.LabelSnapshotCommittedSize, "labels."+snapshots.LabelSnapshotCommittedInodes); err != nil {
+				log.G(ctx).WithError(err).WithField("snapshotter", s.name).Warnf("failed to record committed size of snapshot %q", name)
+			}
+		}
+
 		if publisher := s.db.Publisher(ctx); publisher != nil {
 			if err := publisher.Publish(ctx, "/snapshot/commit", &eventstypes.SnapshotCommit{
 				Key:         key,
@@ -728,6 +744,7 @@ func (s *snapshotter) Remove(ctx context.Context, key string) error {
 			Snapshotter: s.name,
 		})
 	}
+
 	return nil
 }
 
@@ -20,6 +20,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	cgroups "github.com/containerd/cgroups/v3/cgroup1"
 	eventstypes "github.com/containerd/containerd/api/events"
@@ -57,6 +58,12 @@ type cgroupTask interface {
 	Cgroup() (cgroups.Cgroup, error)
 }
 
+// SetCacheTTL caches collected metrics for the duration, see
+// Collector.SetCacheTTL.
+func (m *cgroupsMonitor) SetCacheTTL(d time.Duration) {
+	m.collector.SetCacheTTL(d)
+}
+
 func (m *cgroupsMonitor) Monitor(c runtime.Task, labels map[string]string) error {
 	if err := m.collector.Add(c, labels); err != nil {
 		return err
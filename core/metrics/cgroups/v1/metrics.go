@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	cgroups "github.com/containerd/cgroups/v3/cgroup1"
 	cmetrics "github.com/containerd/containerd/v2/core/metrics"
@@ -102,6 +103,19 @@ type Collector struct {
 	mu      sync.RWMutex
 	tasks   map[string]entry
 	metrics []*metric
+
+	// cacheTTL, when non-zero, caches collected metrics for the duration
+	// so frequent scrapes do not re-stat every container.
+	cacheTTL time.Duration
+	cacheMu  sync.Mutex
+	cacheAt  time.Time
+	cache    []prometheus.Metric
+}
+
+// SetCacheTTL caches collected metrics for the duration so frequent
+// scrapes do not re-stat every container. Zero collects on every scrape.
+func (c *Collector) SetCacheTTL(d time.Duration) {
+	c.cacheTTL = d
 }
 
 // Describe prometheus metrics
@@ -113,6 +127,42 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect prometheus metrics
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	if c.cacheTTL > 0 {
+		c.collectCached(ch)
+		return
+	}
+	c.collectAll(ch)
+}
+
+// collectCached serves metrics from the cache while it is fresh and
+// refreshes it otherwise.
+func (c *Collector) collectCached(ch chan<- prometheus.Metric) {
+	c.cacheMu.Lock()
+	defer c.cacheMu.Unlock()
+	if time.Since(c.cacheAt) < c.cacheTTL {
+		for _, m := range c.cache {
+			ch <- m
+		}
+		return
+	}
+	inner := make(chan prometheus.Metric, 128)
+	done := make(chan struct{})
+	var collected []prometheus.Metric
+	go func() {
+		for m := range inner {
+			collected = append(collected, m)
+			ch <- m
+		}
+		close(done)
+	}()
+	c.collectAll(inner)
+	close(inner)
+	<-done
+	c.cache = collected
+	c.cacheAt = time.Now()
+}
+
+func (c *Collector) collectAll(ch chan<- prometheus.Metric) {
 	c.mu.RLock()
 	wg := &sync.WaitGroup{}
 	for _, t := range c.tasks {
@@ -20,6 +20,7 @@ package v2
 
 import (
 	"context"
+	"time"
 
 	"github.com/containerd/containerd/v2/core/events"
 	"github.com/containerd/containerd/v2/core/runtime"
@@ -42,6 +43,12 @@ type cgroupsMonitor struct {
 	publisher events.Publisher
 }
 
+// SetCacheTTL caches collected metrics for the duration, see
+// Collector.SetCacheTTL.
+func (m *cgroupsMonitor) SetCacheTTL(d time.Duration) {
+	m.collector.SetCacheTTL(d)
+}
+
 func (m *cgroupsMonitor) Monitor(c runtime.Task, labels map[string]string) error {
 	if err := m.collector.Add(c, labels); err != nil {
 		return err
@@ -0,0 +1,157 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package shims exports resource usage of the shim processes themselves
+// (RSS, open fds, threads) labeled by namespace and id, so operators can
+// spot leaking shims; the containers' cgroups alone do not cover the
+// shims.
+package shims
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	goMetrics "github.com/docker/go-metrics"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "shim-metrics",
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			ns := goMetrics.NewNamespace("containerd", "shim", nil)
+			c := newCollector(ns)
+			ns.Add(c)
+			goMetrics.Register(ns)
+			return c, nil
+		},
+	})
+}
+
+type collector struct {
+	ns      *goMetrics.Namespace
+	rss     *prometheus.Desc
+	fds     *prometheus.Desc
+	threads *prometheus.Desc
+}
+
+func newCollector(ns *goMetrics.Namespace) *collector {
+	labels := []string{"namespace", "id"}
+	return &collector{
+		ns:      ns,
+		rss:     prometheus.NewDesc("containerd_shim_rss_bytes", "Resident set size of the shim process", labels, nil),
+		fds:     prometheus.NewDesc("containerd_shim_open_fds", "Open file descriptors of the shim process", labels, nil),
+		threads: prometheus.NewDesc("containerd_shim_threads", "OS threads of the shim process", labels, nil),
+	}
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rss
+	ch <- c.fds
+	ch <- c.threads
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, shim := range scanShims() {
+		labels := []string{shim.namespace, shim.id}
+		ch <- prometheus.MustNewConstMetric(c.rss, prometheus.GaugeValue, float64(shim.rssBytes), labels...)
+		ch <- prometheus.MustNewConstMetric(c.fds, prometheus.GaugeValue, float64(shim.openFDs), labels...)
+		ch <- prometheus.MustNewConstMetric(c.threads, prometheus.GaugeValue, float64(shim.threads), labels...)
+	}
+}
+
+type shimProc struct {
+	namespace string
+	id        string
+	rssBytes  int64
+	openFDs   int
+	threads   int64
+}
+
+// scanShims finds shim processes via /proc and reads their resource
+// usage. Shims are identified by their command name and labeled from the
+// -namespace and -id arguments they were started with.
+func scanShims() []shimProc {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil
+	}
+	var shims []shimProc
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join("/proc", e.Name(), "comm"))
+		if err != nil || !strings.HasPrefix(strings.TrimSpace(string(comm)), "containerd-shim") {
+			continue
+		}
+		cmdline, err := os.ReadFile(filepath.Join("/proc", e.Name(), "cmdline"))
+		if err != nil {
+			continue
+		}
+		shim := shimProc{}
+		args := strings.Split(string(cmdline), "\x00")
+		for i := 0; i < len(args)-1; i++ {
+			switch args[i] {
+			case "-namespace":
+				shim.namespace = args[i+1]
+			case "-id":
+				shim.id = args[i+1]
+			}
+		}
+		if shim.id == "" {
+			continue
+		}
+		shim.rssBytes, shim.threads = readStatus(pid)
+		if fds, err := os.ReadDir(filepath.Join("/proc", e.Name(), "fd")); err == nil {
+			shim.openFDs = len(fds)
+		}
+		shims = append(shims, shim)
+	}
+	return shims
+}
+
+// readStatus reads RSS and thread count from /proc/<pid>/status.
+func readStatus(pid int) (rssBytes, threads int64) {
+	data, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "status"))
+	if err != nil {
+		return 0, 0
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "VmRSS:":
+			if kb, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				rssBytes = kb * 1024
+			}
+		case "Threads:":
+			threads, _ = strconv.ParseInt(fields[1], 10, 64)
+		}
+	}
+	return rssBytes, threads
+}
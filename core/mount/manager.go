@@ -0,0 +1,165 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/containerd/log"
+)
+
+// Record describes a tracked mount performed on behalf of an owner, kept
+// on disk so orphaned mounts can be cleaned up after a crash.
+type Record struct {
+	// Namespace of the owner.
+	Namespace string `json:"namespace"`
+	// Owner is the id of the object the mount belongs to, typically a
+	// container id.
+	Owner string `json:"owner"`
+	// Target is the mount point.
+	Target string `json:"target"`
+	// CreatedAt is when the mount was recorded.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Manager records every mount performed through it in a state directory
+// and cleans up orphaned mounts on startup, eliminating leftover overlay
+// mounts after crashes that otherwise require manual umount.
+type Manager struct {
+	mu  sync.Mutex
+	dir string
+}
+
+// NewManager returns a Manager persisting mount records under dir.
+func NewManager(dir string) (*Manager, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	return &Manager{dir: dir}, nil
+}
+
+func (m *Manager) recordPath(target string) string {
+	sum := sha256.Sum256([]byte(target))
+	return filepath.Join(m.dir, hex.EncodeToString(sum[:16])+".json")
+}
+
+// Mount records the target for the owner and performs the mounts,
+// removing the record again when mounting fails.
+func (m *Manager) Mount(mounts []Mount, target, namespace, owner string) error {
+	if err := m.record(target, namespace, owner); err != nil {
+		return err
+	}
+	if err := All(mounts, target); err != nil {
+		m.forget(target)
+		return err
+	}
+	return nil
+}
+
+// Unmount unmounts the target and removes its record.
+func (m *Manager) Unmount(target string, flags int) error {
+	if err := UnmountAll(target, flags); err != nil {
+		return err
+	}
+	m.forget(target)
+	return nil
+}
+
+// record persists a mount record before the mount is performed, so a
+// crash between record and mount at worst leaves a record for an
+// unmounted target, which cleanup handles gracefully.
+func (m *Manager) record(target, namespace, owner string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, err := json.Marshal(Record{
+		Namespace: namespace,
+		Owner:     owner,
+		Target:    target,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(m.recordPath(target), data, 0600)
+}
+
+// forget drops the record of a target.
+func (m *Manager) forget(target string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := os.Remove(m.recordPath(target)); err != nil && !os.IsNotExist(err) {
+		log.L.WithError(err).Warnf("failed to remove mount record for %q", target)
+	}
+}
+
+// Records returns all persisted mount records.
+func (m *Manager) Records() ([]Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var records []Record
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(m.dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var r Record
+		if err := json.Unmarshal(data, &r); err != nil {
+			log.L.WithError(err).Warnf("ignoring corrupt mount record %q", e.Name())
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, nil
+}
+
+// Cleanup unmounts and forgets every recorded mount whose owner is no
+// longer alive according to the callback. It is called on startup to
+// clean up mounts orphaned by a crash and returns the cleaned targets.
+func (m *Manager) Cleanup(ctx context.Context, alive func(namespace, owner string) bool) ([]string, error) {
+	records, err := m.Records()
+	if err != nil {
+		return nil, err
+	}
+	var cleaned []string
+	for _, r := range records {
+		if alive != nil && alive(r.Namespace, r.Owner) {
+			continue
+		}
+		log.G(ctx).Infof("cleaning up orphaned mount %q of %s/%s", r.Target, r.Namespace, r.Owner)
+		if err := UnmountAll(r.Target, 0); err != nil {
+			return cleaned, fmt.Errorf("failed to unmount orphaned mount %q: %w", r.Target, err)
+		}
+		m.forget(r.Target)
+		cleaned = append(cleaned, r.Target)
+	}
+	return cleaned, nil
+}
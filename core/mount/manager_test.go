@@ -0,0 +1,65 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package mount
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManagerRecords(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, mgr.record("/mnt/a", "default", "c1"))
+	require.NoError(t, mgr.record("/mnt/b", "default", "c2"))
+
+	records, err := mgr.Records()
+	require.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	mgr.forget("/mnt/a")
+	records, err = mgr.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "/mnt/b", records[0].Target)
+	assert.Equal(t, "c2", records[0].Owner)
+}
+
+func TestManagerCleanup(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	require.NoError(t, err)
+
+	// The targets are not mounted, so cleanup only drops the records.
+	require.NoError(t, mgr.record(t.TempDir(), "default", "alive"))
+	dead := t.TempDir()
+	require.NoError(t, mgr.record(dead, "default", "dead"))
+
+	cleaned, err := mgr.Cleanup(context.Background(), func(ns, owner string) bool {
+		return owner == "alive"
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{dead}, cleaned)
+
+	records, err := mgr.Records()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "alive", records[0].Owner)
+}
@@ -36,6 +36,7 @@ import (
 	tomlu "github.com/pelletier/go-toml/v2/unstable"
 
 	"github.com/containerd/containerd/v2/core/remotes/docker"
+	"github.com/containerd/containerd/v2/core/remotes/docker/credentials"
 )
 
 // UpdateClientFunc is a function that lets you to amend http Client behavior used by registry clients.
@@ -54,7 +55,16 @@ type hostConfig struct {
 
 	header http.Header
 
-	// TODO: Add credential configuration (domain alias, username)
+	// credentialHelper is the name of a Docker-style credential helper
+	// binary providing credentials for this host.
+	credentialHelper string
+
+	// fetchSource names a registered peer-to-peer fetch source serving
+	// blob fetches for this host before falling back to the registry.
+	fetchSource string
+
+	// deprioritizeOnFailure opts the host into health-aware ordering.
+	deprioritizeOnFailure bool
 }
 
 // HostOptions is used to configure registry hosts
@@ -237,10 +247,14 @@ func ConfigureHosts(ctx context.Context, options HostOptions) docker.RegistryHos
 				}
 
 				rhosts[i].Client = &c
-				rhosts[i].Authorizer = docker.NewDockerAuthorizer(append(authOpts, docker.WithAuthClient(&c))...)
+				rhosts[i].Authorizer = docker.NewDockerAuthorizer(append(hostAuthOpts(host, authOpts), docker.WithAuthClient(&c))...)
 			} else {
 				rhosts[i].Client = client
-				rhosts[i].Authorizer = authorizer
+				if host.credentialHelper != "" {
+					rhosts[i].Authorizer = docker.NewDockerAuthorizer(append(hostAuthOpts(host, authOpts), docker.WithAuthClient(client))...)
+				} else {
+					rhosts[i].Authorizer = authorizer
+				}
 			}
 
 			// When TLS has been configured for the operation or host and
@@ -263,6 +277,8 @@ func ConfigureHosts(ctx context.Context, options HostOptions) docker.RegistryHos
 			rhosts[i].Path = host.path
 			rhosts[i].Capabilities = host.capabilities
 			rhosts[i].Header = host.header
+			rhosts[i].FetchSource = host.fetchSource
+			rhosts[i].DeprioritizeOnFailure = host.deprioritizeOnFailure
 		}
 
 		return rhosts, nil
@@ -270,6 +286,17 @@ func ConfigureHosts(ctx context.Context, options HostOptions) docker.RegistryHos
 
 }
 
+// hostAuthOpts amends the shared authorizer options with the host's
+// credential helper when one is configured; helper credentials take
+// precedence over statically configured ones.
+func hostAuthOpts(host hostConfig, authOpts []docker.AuthorizerOpt) []docker.AuthorizerOpt {
+	if host.credentialHelper == "" {
+		return authOpts
+	}
+	helper := credentials.NewHelper(host.credentialHelper)
+	return append(append([]docker.AuthorizerOpt{}, authOpts...), docker.WithAuthCreds(helper.Get))
+}
+
 // HostDirFromRoot returns a function which finds a host directory
 // based at the given root.
 func HostDirFromRoot(root string) func(string) (string, error) {
@@ -352,7 +379,20 @@ type hostFileConfig struct {
 	// API root endpoint.
 	OverridePath bool `toml:"override_path"`
 
-	// TODO: Credentials: helper? name? username? alternate domain? token?
+	// CredentialHelper is the name of a Docker-style credential helper
+	// binary ("docker-credential-<name> get") invoked to obtain
+	// short-lived credentials for this host.
+	CredentialHelper string `toml:"credential_helper"`
+
+	// FetchSource names a registered peer-to-peer fetch source (e.g. a
+	// Dragonfly or Spegel integration) serving blob fetches for this host
+	// before falling back to the registry.
+	FetchSource string `toml:"fetch_source"`
+
+	// DeprioritizeOnFailure moves this host behind the remaining hosts
+	// while it is failing, so a dead first mirror does not slow every
+	// pull. The configured order is kept while the host is healthy.
+	DeprioritizeOnFailure bool `toml:"deprioritize_on_failure"`
 }
 
 func parseHostsFile(baseDir string, b []byte) ([]hostConfig, error) {
@@ -512,6 +552,10 @@ func parseHostConfig(server string, baseDir string, config hostFileConfig) (host
 		result.header = header
 	}
 
+	result.credentialHelper = config.CredentialHelper
+	result.fetchSource = config.FetchSource
+	result.deprioritizeOnFailure = config.DeprioritizeOnFailure
+
 	return result, nil
 }
 
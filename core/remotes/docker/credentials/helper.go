@@ -0,0 +1,139 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package credentials invokes Docker-style credential helper binaries to
+// obtain short-lived registry credentials, with caching and expiry
+// handling, as an alternative to static auth configuration.
+//
+// A helper implements the protocol from
+// https://github.com/docker/docker-credential-helpers: it is invoked as
+// `docker-credential-<name> get` with the registry host on stdin and
+// responds with a JSON payload carrying Username and Secret.
+package credentials
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultExpiry bounds how long credentials returned by a helper are
+// cached. Helpers vend short-lived credentials (e.g. registry tokens from
+// cloud providers), so the cache only bridges bursts of requests during a
+// single pull.
+const defaultExpiry = 5 * time.Minute
+
+// tokenUsername is the conventional username signalling that the secret is
+// an identity token rather than a password.
+const tokenUsername = "<token>"
+
+type helperResponse struct {
+	Username string
+	Secret   string
+}
+
+type cachedCredentials struct {
+	username  string
+	secret    string
+	expiresAt time.Time
+}
+
+// Helper invokes a credential helper binary and caches its responses until
+// they expire.
+type Helper struct {
+	// binary is the resolved helper binary name.
+	binary string
+	// expiry is how long responses are cached.
+	expiry time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedCredentials
+}
+
+// HelperOpt configures a Helper.
+type HelperOpt func(*Helper)
+
+// WithExpiry overrides how long helper responses are cached.
+func WithExpiry(expiry time.Duration) HelperOpt {
+	return func(h *Helper) {
+		h.expiry = expiry
+	}
+}
+
+// NewHelper returns a Helper invoking the named credential helper. The
+// name is either a full binary name (or path), or the suffix of a
+// `docker-credential-<name>` binary found on PATH.
+func NewHelper(name string, opts ...HelperOpt) *Helper {
+	binary := name
+	if !strings.Contains(name, "/") && !strings.HasPrefix(name, "docker-credential-") {
+		binary = "docker-credential-" + name
+	}
+	h := &Helper{
+		binary: binary,
+		expiry: defaultExpiry,
+		cache:  map[string]cachedCredentials{},
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Get returns the credentials for the host, invoking the helper unless
+// cached credentials have not expired yet. Following the resolver's
+// credential convention, an empty username with a non-empty secret means
+// the secret is an identity token.
+func (h *Helper) Get(host string) (string, string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if creds, ok := h.cache[host]; ok && time.Now().Before(creds.expiresAt) {
+		return creds.username, creds.secret, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.binary, "get")
+	cmd.Stdin = strings.NewReader(host)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", "", fmt.Errorf("credential helper %s failed for %q: %s: %w", h.binary, host, strings.TrimSpace(stderr.String()), err)
+	}
+
+	var resp helperResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return "", "", fmt.Errorf("failed to parse output of credential helper %s: %w", h.binary, err)
+	}
+
+	username := resp.Username
+	if username == tokenUsername {
+		username = ""
+	}
+	h.cache[host] = cachedCredentials{
+		username:  username,
+		secret:    resp.Secret,
+		expiresAt: time.Now().Add(h.expiry),
+	}
+	return username, resp.Secret, nil
+}
@@ -0,0 +1,89 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package credentials
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeHelper writes a fake credential helper recording its invocations to
+// a counter file.
+func writeHelper(t *testing.T, response string) (helper, counter string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test helper script requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	helper = filepath.Join(dir, "docker-credential-test")
+	counter = filepath.Join(dir, "count")
+	script := fmt.Sprintf("#!/bin/sh\necho x >> %s\necho '%s'\n", counter, response)
+	require.NoError(t, os.WriteFile(helper, []byte(script), 0o755))
+	return helper, counter
+}
+
+func TestHelperGet(t *testing.T) {
+	helper, _ := writeHelper(t, `{"Username":"user","Secret":"pass"}`)
+
+	username, secret, err := NewHelper(helper).Get("example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "user", username)
+	assert.Equal(t, "pass", secret)
+}
+
+func TestHelperGetIdentityToken(t *testing.T) {
+	helper, _ := writeHelper(t, `{"Username":"<token>","Secret":"idtoken"}`)
+
+	username, secret, err := NewHelper(helper).Get("example.com")
+	require.NoError(t, err)
+	assert.Empty(t, username)
+	assert.Equal(t, "idtoken", secret)
+}
+
+func TestHelperCaching(t *testing.T) {
+	helper, counter := writeHelper(t, `{"Username":"user","Secret":"pass"}`)
+
+	h := NewHelper(helper)
+	for i := 0; i < 3; i++ {
+		_, _, err := h.Get("example.com")
+		require.NoError(t, err)
+	}
+	data, err := os.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "x\n", string(data), "expected a single helper invocation")
+
+	// Expired credentials trigger a new invocation.
+	hExpired := NewHelper(helper, WithExpiry(-time.Second))
+	_, _, err = hExpired.Get("example.com")
+	require.NoError(t, err)
+	_, _, err = hExpired.Get("example.com")
+	require.NoError(t, err)
+	data, err = os.ReadFile(counter)
+	require.NoError(t, err)
+	assert.Equal(t, "x\nx\nx\n", string(data))
+}
+
+func TestHelperError(t *testing.T) {
+	_, _, err := NewHelper("this-helper-does-not-exist").Get("example.com")
+	assert.Error(t, err)
+}
@@ -125,6 +125,18 @@ func (r dockerFetcher) Fetch(ctx context.Context, desc ocispec.Descriptor) (io.R
 		// Finally use blobs endpoints
 		var firstErr error
 		for _, host := range r.hosts {
+			// Try the host's peer-to-peer fetch source, if any, before
+			// hitting the registry.
+			if source := getFetchSource(host.FetchSource); source != nil {
+				rc, err := source.Fetch(ctx, host.Host, desc, offset)
+				if err == nil {
+					return rc, nil
+				}
+				if !errdefs.IsNotFound(err) {
+					log.G(ctx).WithError(err).WithField("source", host.FetchSource).Warn("fetch source failed, falling back to registry")
+				}
+			}
+
 			req := r.request(host, http.MethodGet, "blobs", desc.Digest.String())
 			if err := req.addNamespace(r.refspec.Hostname()); err != nil {
 				return nil, err
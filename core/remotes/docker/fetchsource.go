@@ -0,0 +1,61 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// FetchSource serves layer byte ranges from an alternative distribution
+// source, such as a peer-to-peer distributor (Dragonfly/Spegel-style),
+// reducing registry egress during large rollouts. Implementations return
+// an error wrapping errdefs.ErrNotFound when they cannot serve a blob, in
+// which case the fetcher falls back to the registry.
+type FetchSource interface {
+	// Fetch returns a reader for the blob starting at offset. The host is
+	// the registry host the blob would otherwise be fetched from.
+	Fetch(ctx context.Context, host string, desc ocispec.Descriptor, offset int64) (io.ReadCloser, error)
+}
+
+var (
+	fetchSourcesMu sync.RWMutex
+	fetchSources   = map[string]FetchSource{}
+)
+
+// RegisterFetchSource registers a named FetchSource which registry hosts
+// can select with the `fetch_source` key in hosts.toml. Registration is
+// typically done from an init function of a distributor integration.
+func RegisterFetchSource(name string, source FetchSource) {
+	fetchSourcesMu.Lock()
+	defer fetchSourcesMu.Unlock()
+	if _, ok := fetchSources[name]; ok {
+		panic(fmt.Sprintf("fetch source %q already registered", name))
+	}
+	fetchSources[name] = source
+}
+
+// getFetchSource returns the registered FetchSource with the name, or nil.
+func getFetchSource(name string) FetchSource {
+	fetchSourcesMu.RLock()
+	defer fetchSourcesMu.RUnlock()
+	return fetchSources[name]
+}
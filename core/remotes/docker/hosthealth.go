@@ -0,0 +1,151 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// unhealthyRetryInterval is how long a host stays deprioritized after a
+// connection failure before it is re-probed.
+const unhealthyRetryInterval = 30 * time.Second
+
+// HostHealth is a snapshot of the recorded health of a registry host.
+type HostHealth struct {
+	// Host is the registry host name.
+	Host string `json:"host"`
+	// Healthy is false while the host is in its failure backoff window.
+	Healthy bool `json:"healthy"`
+	// Failures counts consecutive connection failures.
+	Failures int `json:"failures"`
+	// LastFailure is the time of the most recent failure.
+	LastFailure time.Time `json:"lastFailure,omitempty"`
+	// Latency is the most recently observed request latency.
+	Latency time.Duration `json:"latency"`
+}
+
+type hostHealthEntry struct {
+	failures    int
+	lastFailure time.Time
+	latency     time.Duration
+}
+
+// HealthTracker records per-host connection outcomes and latencies so
+// mirror lists can be ordered healthiest first instead of statically. A
+// host that failed is deprioritized until the retry interval has passed,
+// after which it is probed again.
+type HealthTracker struct {
+	mu    sync.Mutex
+	hosts map[string]*hostHealthEntry
+}
+
+// defaultHealthTracker is shared by all resolvers in the process, so
+// health observed by one pull benefits the next.
+var defaultHealthTracker = NewHealthTracker()
+
+// NewHealthTracker returns an empty HealthTracker.
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{
+		hosts: map[string]*hostHealthEntry{},
+	}
+}
+
+// HostHealthReport returns the health of every registry host observed by
+// the process-wide tracker, for exposure via introspection.
+func HostHealthReport() []HostHealth {
+	return defaultHealthTracker.Report()
+}
+
+func (t *HealthTracker) entry(host string) *hostHealthEntry {
+	e, ok := t.hosts[host]
+	if !ok {
+		e = &hostHealthEntry{}
+		t.hosts[host] = e
+	}
+	return e
+}
+
+// recordSuccess records a successful request with its latency.
+func (t *HealthTracker) recordSuccess(host string, latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := t.entry(host)
+	e.failures = 0
+	e.latency = latency
+}
+
+// recordFailure records a connection failure.
+func (t *HealthTracker) recordFailure(host string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e := t.entry(host)
+	e.failures++
+	e.lastFailure = time.Now()
+}
+
+func (e *hostHealthEntry) healthy() bool {
+	return e.failures == 0 || time.Since(e.lastFailure) > unhealthyRetryInterval
+}
+
+// order moves hosts that opted into health-aware ordering (see
+// RegistryHost.DeprioritizeOnFailure) to the back while they are inside
+// their failure backoff window. The configured mirror order is preserved
+// in every other respect: healthy hosts are never re-sorted, and hosts
+// without the opt-in are never moved, so deliberately prioritized mirrors
+// keep winning over the upstream registry.
+func (t *HealthTracker) order(hosts []RegistryHost) []RegistryHost {
+	if len(hosts) < 2 {
+		return hosts
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var (
+		ordered = make([]RegistryHost, 0, len(hosts))
+		demoted []RegistryHost
+	)
+	for _, host := range hosts {
+		if host.DeprioritizeOnFailure {
+			if e, ok := t.hosts[host.Host]; ok && !e.healthy() {
+				demoted = append(demoted, host)
+				continue
+			}
+		}
+		ordered = append(ordered, host)
+	}
+	return append(ordered, demoted...)
+}
+
+// Report returns a snapshot of the tracked host health.
+func (t *HealthTracker) Report() []HostHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	report := make([]HostHealth, 0, len(t.hosts))
+	for host, e := range t.hosts {
+		report = append(report, HostHealth{
+			Host:        host,
+			Healthy:     e.healthy(),
+			Failures:    e.failures,
+			LastFailure: e.lastFailure,
+			Latency:     e.latency,
+		})
+	}
+	sort.Slice(report, func(i, j int) bool { return report[i].Host < report[j].Host })
+	return report
+}
@@ -0,0 +1,87 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hostNames(hosts []RegistryHost) []string {
+	names := make([]string, len(hosts))
+	for i, h := range hosts {
+		names[i] = h.Host
+	}
+	return names
+}
+
+func TestHealthTrackerOrder(t *testing.T) {
+	hosts := []RegistryHost{
+		{Host: "mirror1", DeprioritizeOnFailure: true},
+		{Host: "mirror2", DeprioritizeOnFailure: true},
+		{Host: "upstream"},
+	}
+
+	tracker := NewHealthTracker()
+	// Without observations the configured order is kept.
+	assert.Equal(t, []string{"mirror1", "mirror2", "upstream"}, hostNames(tracker.order(hosts)))
+
+	// A failing opted-in mirror is demoted behind the rest.
+	tracker.recordFailure("mirror1")
+	assert.Equal(t, []string{"mirror2", "upstream", "mirror1"}, hostNames(tracker.order(hosts)))
+
+	// Healthy hosts keep the configured order regardless of latency.
+	tracker.recordSuccess("mirror2", 50*time.Millisecond)
+	tracker.recordSuccess("upstream", 10*time.Millisecond)
+	assert.Equal(t, []string{"mirror2", "upstream", "mirror1"}, hostNames(tracker.order(hosts)))
+
+	// A success clears the failure state and restores configured order.
+	tracker.recordSuccess("mirror1", time.Millisecond)
+	assert.Equal(t, []string{"mirror1", "mirror2", "upstream"}, hostNames(tracker.order(hosts)))
+
+	// Hosts without the opt-in are never moved, even when failing.
+	tracker.recordFailure("upstream")
+	assert.Equal(t, []string{"mirror1", "mirror2", "upstream"}, hostNames(tracker.order(hosts)))
+}
+
+func TestHealthTrackerReprobe(t *testing.T) {
+	tracker := NewHealthTracker()
+	tracker.recordFailure("mirror1")
+
+	e := tracker.hosts["mirror1"]
+	assert.False(t, e.healthy())
+
+	// After the retry interval the host is considered again.
+	e.lastFailure = time.Now().Add(-2 * unhealthyRetryInterval)
+	assert.True(t, e.healthy())
+}
+
+func TestHealthTrackerReport(t *testing.T) {
+	tracker := NewHealthTracker()
+	tracker.recordSuccess("a", time.Millisecond)
+	tracker.recordFailure("b")
+
+	report := tracker.Report()
+	assert.Len(t, report, 2)
+	assert.Equal(t, "a", report[0].Host)
+	assert.True(t, report[0].Healthy)
+	assert.Equal(t, "b", report[1].Host)
+	assert.False(t, report[1].Healthy)
+	assert.Equal(t, 1, report[1].Failures)
+}
@@ -0,0 +1,134 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ReferrersLister lists artifacts referring to a subject digest through
+// the OCI referrers API, e.g. signatures, SBOMs and attestations.
+type ReferrersLister interface {
+	// Referrers returns the descriptors of artifacts whose subject is the
+	// digest under the ref's repository, optionally filtered to one
+	// artifact type.
+	Referrers(ctx context.Context, ref string, dgst digest.Digest, artifactType string) ([]ocispec.Descriptor, error)
+}
+
+// Referrers implements the OCI referrers API, falling back to the
+// referrers tag schema for registries without native support.
+func (r *dockerResolver) Referrers(ctx context.Context, ref string, dgst digest.Digest, artifactType string) ([]ocispec.Descriptor, error) {
+	base, err := r.resolveDockerBase(ref)
+	if err != nil {
+		return nil, err
+	}
+	hosts := base.filterHosts(HostCapabilityResolve)
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no resolve hosts: %w", errdefs.ErrNotFound)
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		req := base.request(host, http.MethodGet, "referrers", dgst.String())
+		if artifactType != "" {
+			req.path += "?artifactType=" + artifactType
+		}
+		descs, err := fetchReferrersIndex(ctx, req)
+		if err == nil {
+			return filterArtifactType(descs, artifactType), nil
+		}
+		if !errdefs.IsNotFound(err) {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		// Fall back to the referrers tag schema,
+		// e.g. sha256-abcd... as a tag holding an image index.
+		log.G(ctx).WithField("host", host.Host).Debug("falling back to referrers tag schema")
+		tag := strings.ReplaceAll(dgst.String(), ":", "-")
+		req = base.request(host, http.MethodGet, "manifests", tag)
+		if err := req.addNamespace(base.refspec.Hostname()); err != nil {
+			return nil, err
+		}
+		descs, err = fetchReferrersIndex(ctx, req)
+		if err == nil {
+			return filterArtifactType(descs, artifactType), nil
+		}
+		if errdefs.IsNotFound(err) {
+			// No referrers recorded for the subject.
+			return nil, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// fetchReferrersIndex fetches and parses an OCI index response.
+func fetchReferrersIndex(ctx context.Context, req *request) ([]ocispec.Descriptor, error) {
+	req.header.Set("Accept", ocispec.MediaTypeImageIndex)
+	resp, err := req.doWithRetries(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("referrers of %v: %w", req.path, errdefs.ErrNotFound)
+	default:
+		return nil, fmt.Errorf("unexpected status fetching referrers: %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 8<<20))
+	if err != nil {
+		return nil, err
+	}
+	var index ocispec.Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return nil, fmt.Errorf("failed to parse referrers index: %w", err)
+	}
+	return index.Manifests, nil
+}
+
+// filterArtifactType drops descriptors not matching the artifact type,
+// for registries which ignore the query parameter.
+func filterArtifactType(descs []ocispec.Descriptor, artifactType string) []ocispec.Descriptor {
+	if artifactType == "" {
+		return descs
+	}
+	filtered := descs[:0]
+	for _, d := range descs {
+		if d.ArtifactType == artifactType {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
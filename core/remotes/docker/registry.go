@@ -74,6 +74,14 @@ type RegistryHost struct {
 	Path         string
 	Capabilities HostCapabilities
 	Header       http.Header
+	// FetchSource optionally names a registered FetchSource serving blob
+	// fetches for this host before falling back to the registry.
+	FetchSource string
+	// DeprioritizeOnFailure opts the host into health-aware ordering:
+	// while the host is inside its failure backoff window it is tried
+	// after the remaining hosts. Configured order is never changed
+	// otherwise.
+	DeprioritizeOnFailure bool
 }
 
 func (h RegistryHost) isProxy(refhost string) bool {
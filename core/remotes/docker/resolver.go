@@ -29,6 +29,7 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
@@ -485,7 +486,9 @@ func (r *dockerBase) filterHosts(caps HostCapabilities) (hosts []RegistryHost) {
 			hosts = append(hosts, host)
 		}
 	}
-	return
+	// Demote hosts that opted into health-aware ordering while they are
+	// failing, so a dead first mirror does not slow down every pull.
+	return defaultHealthTracker.order(hosts)
 }
 
 func (r *dockerBase) request(host RegistryHost, method string, ps ...string) *request {
@@ -601,10 +604,13 @@ func (r *request) do(ctx context.Context) (*http.Response, error) {
 
 	tracing.UpdateHTTPClient(client, tracing.Name("remotes.docker.resolver", "HTTPRequest"))
 
+	start := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
+		defaultHealthTracker.recordFailure(r.host.Host)
 		return nil, fmt.Errorf("failed to do request: %w", err)
 	}
+	defaultHealthTracker.recordSuccess(r.host.Host, time.Since(start))
 	log.G(ctx).WithFields(responseFields(resp)).Debug("fetch response received")
 	return resp, nil
 }
@@ -70,6 +70,8 @@ func GetTopic(e interface{}) string {
 		return TaskResumedEventTopic
 	case *events.TaskCheckpointed:
 		return TaskCheckpointedEventTopic
+	case *TaskFDBudgetExceeded:
+		return TaskFDBudgetEventTopic
 	default:
 		log.L.Warnf("no topic for type %#v", e)
 	}
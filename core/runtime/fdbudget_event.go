@@ -0,0 +1,51 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/containerd/typeurl/v2"
+)
+
+// TaskFDBudgetEventTopic is published with a TaskFDBudgetExceeded payload
+// when a shim refuses fd-consuming work because its file descriptor
+// budget is exhausted.
+const TaskFDBudgetEventTopic = "/tasks/fd-budget-exceeded"
+
+// TaskFDBudgetExceeded describes one enforcement of a shim's fd budget.
+// The budget is accounted per shim process — file descriptors are a
+// process-wide resource and cannot be attributed to individual tasks
+// without shim-internal bookkeeping — but the event names the task and
+// exec that hit the limit, giving operators the attribution needed to
+// find the leaking workload.
+type TaskFDBudgetExceeded struct {
+	// ContainerID of the task whose request was refused.
+	ContainerID string `json:"container_id"`
+	// ExecID of the refused exec, when the refused request was an exec.
+	ExecID string `json:"exec_id,omitempty"`
+	// OpenFDs is the shim's open descriptor count at enforcement time.
+	OpenFDs int `json:"open_fds"`
+	// Budget is the configured limit.
+	Budget int `json:"budget"`
+	// Timestamp of the enforcement.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func init() {
+	typeurl.Register(&TaskFDBudgetExceeded{}, "io.containerd.runtime.TaskFDBudgetExceeded")
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package restart
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/log"
+)
+
+// PolicyHandler implements a restart policy. It reports whether a
+// container with the given task status and labels should be restarted.
+// args are the colon separated arguments following the policy name in the
+// restart.policy label.
+type PolicyHandler func(args []string, status containerd.Status, labels map[string]string) bool
+
+// PolicyValidator checks the policy arguments when the policy label is
+// parsed, so misconfiguration fails at container configuration time
+// instead of silently in the monitor loop.
+type PolicyValidator func(args []string) error
+
+type policyDefinition struct {
+	validate PolicyValidator
+	decide   PolicyHandler
+}
+
+var (
+	policiesMu sync.RWMutex
+	policies   = map[string]policyDefinition{}
+)
+
+// RegisterPolicy registers a restart policy usable in the restart.policy
+// label as "<name>[:<arg>[:<arg>...]]". The built-in policies are
+// registered this way; downstream users can add their own (e.g.
+// "on-failure-backoff", "scheduled") without forking the monitor loop.
+func RegisterPolicy(name string, validate PolicyValidator, decide PolicyHandler) {
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+	if _, ok := policies[name]; ok {
+		panic(fmt.Sprintf("restart policy %q already registered", name))
+	}
+	policies[name] = policyDefinition{
+		validate: validate,
+		decide:   decide,
+	}
+}
+
+func lookupPolicy(name string) (policyDefinition, bool) {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+	def, ok := policies[name]
+	return def, ok
+}
+
+// noArgs rejects any policy arguments.
+func noArgs(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("restart policy does not support arguments")
+	}
+	return nil
+}
+
+func init() {
+	RegisterPolicy("no", noArgs, func(args []string, status containerd.Status, labels map[string]string) bool {
+		return false
+	})
+	RegisterPolicy("always", noArgs, func(args []string, status containerd.Status, labels map[string]string) bool {
+		return true
+	})
+	RegisterPolicy("unless-stopped", noArgs, func(args []string, status containerd.Status, labels map[string]string) bool {
+		explicitlyStopped, _ := strconv.ParseBool(labels[ExplicitlyStoppedLabel])
+		return !explicitlyStopped
+	})
+	RegisterPolicy("on-failure", func(args []string) error {
+		if len(args) > 1 {
+			return fmt.Errorf("expected at most one max retry count argument")
+		}
+		if len(args) == 1 {
+			if _, err := strconv.Atoi(args[0]); err != nil {
+				return fmt.Errorf("invalid max retry count: %s", args[0])
+			}
+		}
+		return nil
+	}, func(args []string, status containerd.Status, labels map[string]string) bool {
+		var maximumRetryCount int
+		if len(args) == 1 {
+			maximumRetryCount, _ = strconv.Atoi(args[0])
+		}
+		restartCount, err := strconv.Atoi(labels[CountLabel])
+		if err != nil && labels[CountLabel] != "" {
+			log.L.WithError(err).Error("policy reconcile")
+			return false
+		}
+		exitStatus := status.ExitStatus
+		if status.Status == "" {
+			// The failed task has already been deleted and yields an
+			// empty status; fall back to the exit status persisted by the
+			// exit event handler.
+			if v := labels[LastExitStatusLabel]; v != "" {
+				if code, err := strconv.Atoi(v); err == nil {
+					exitStatus = uint32(code)
+				}
+			}
+		}
+		return exitStatus != 0 && (maximumRetryCount == 0 || restartCount < maximumRetryCount)
+	})
+}
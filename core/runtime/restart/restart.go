@@ -53,6 +53,11 @@ const (
 	CountLabel = "containerd.io/restart.count"
 	// ExplicitlyStoppedLabel sets the restart explicitly stopped label for a container
 	ExplicitlyStoppedLabel = "containerd.io/restart.explicitly-stopped"
+	// LastExitStatusLabel records the exit status of the last task exit,
+	// written by the restart monitor's exit event handler. It lets the
+	// on-failure policy be evaluated even when the failed task has
+	// already been deleted and its status is no longer available.
+	LastExitStatusLabel = "containerd.io/restart.last-exit-status"
 )
 
 // Policy represents the restart policies of a container.
@@ -61,40 +66,34 @@ type Policy struct {
 	maximumRetryCount int
 }
 
-// NewPolicy creates a restart policy with the specified name.
-// supports the following restart policies:
+// NewPolicy creates a restart policy with the specified name. The name is
+// looked up in the policy registry, which ships with:
 // - no, Do not restart the container.
 // - always, Always restart the container regardless of the exit status.
 // - on-failure[:max-retries], Restart only if the container exits with a non-zero exit status.
 // - unless-stopped, Always restart the container unless it is stopped.
+// Additional policies can be added with RegisterPolicy.
 func NewPolicy(policy string) (*Policy, error) {
 	policySlice := strings.Split(policy, ":")
-	var (
-		err        error
-		retryCount int
-	)
-	switch policySlice[0] {
-	case "", "no", "always", "unless-stopped":
-		policy = policySlice[0]
-		if policy == "" {
-			policy = "always"
-		}
-		if len(policySlice) > 1 {
-			return nil, fmt.Errorf("restart policy %q not support max retry count", policySlice[0])
-		}
-	case "on-failure":
-		policy = policySlice[0]
-		if len(policySlice) > 1 {
-			retryCount, err = strconv.Atoi(policySlice[1])
-			if err != nil {
-				return nil, fmt.Errorf("invalid max retry count: %s", policySlice[1])
-			}
-		}
-	default:
+	name, args := policySlice[0], policySlice[1:]
+	if name == "" {
+		name = "always"
+	}
+	def, ok := lookupPolicy(name)
+	if !ok {
 		return nil, fmt.Errorf("restart policy %q not supported", policy)
 	}
+	if def.validate != nil {
+		if err := def.validate(args); err != nil {
+			return nil, fmt.Errorf("restart policy %q: %w", name, err)
+		}
+	}
+	var retryCount int
+	if name == "on-failure" && len(args) == 1 {
+		retryCount, _ = strconv.Atoi(args[0])
+	}
 	return &Policy{
-		name:              policy,
+		name:              name,
 		maximumRetryCount: retryCount,
 	}, nil
 }
@@ -121,25 +120,12 @@ func Reconcile(status containerd.Status, labels map[string]string) bool {
 		log.L.WithError(err).Error("policy reconcile")
 		return false
 	}
-	switch rp.Name() {
-	case "", "always":
-		return true
-	case "on-failure":
-		restartCount, err := strconv.Atoi(labels[CountLabel])
-		if err != nil && labels[CountLabel] != "" {
-			log.L.WithError(err).Error("policy reconcile")
-			return false
-		}
-		if status.ExitStatus != 0 && (rp.maximumRetryCount == 0 || restartCount < rp.maximumRetryCount) {
-			return true
-		}
-	case "unless-stopped":
-		explicitlyStopped, _ := strconv.ParseBool(labels[ExplicitlyStoppedLabel])
-		if !explicitlyStopped {
-			return true
-		}
+	def, ok := lookupPolicy(rp.name)
+	if !ok {
+		return false
 	}
-	return false
+	args := strings.Split(labels[PolicyLabel], ":")[1:]
+	return def.decide(args, status, labels)
 }
 
 // WithLogURI sets the specified log uri for a container.
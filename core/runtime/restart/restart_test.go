@@ -219,3 +219,87 @@ func TestRestartPolicyReconcile(t *testing.T) {
 		assert.Equal(t, testCase.want, result, testCase)
 	}
 }
+
+func TestReconcileDeletedTask(t *testing.T) {
+	// A failed task that was already deleted yields an empty status; the
+	// policy falls back to the exit status persisted in the label by the
+	// exit event handler.
+	for _, tt := range []struct {
+		name   string
+		status containerd.Status
+		labels map[string]string
+		want   bool
+	}{
+		{
+			name:   "empty status with failed exit label",
+			status: containerd.Status{},
+			labels: map[string]string{
+				PolicyLabel:         "on-failure",
+				LastExitStatusLabel: "137",
+			},
+			want: true,
+		},
+		{
+			name:   "empty status with clean exit label",
+			status: containerd.Status{},
+			labels: map[string]string{
+				PolicyLabel:         "on-failure",
+				LastExitStatusLabel: "0",
+			},
+			want: false,
+		},
+		{
+			name:   "empty status without exit label",
+			status: containerd.Status{},
+			labels: map[string]string{
+				PolicyLabel: "on-failure",
+			},
+			want: false,
+		},
+		{
+			name: "live status wins over stale label",
+			status: containerd.Status{
+				Status:     containerd.Stopped,
+				ExitStatus: 0,
+			},
+			labels: map[string]string{
+				PolicyLabel:         "on-failure",
+				LastExitStatusLabel: "137",
+			},
+			want: false,
+		},
+		{
+			name:   "retry count exhausted",
+			status: containerd.Status{},
+			labels: map[string]string{
+				PolicyLabel:         "on-failure:2",
+				CountLabel:          "2",
+				LastExitStatusLabel: "1",
+			},
+			want: false,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Reconcile(tt.status, tt.labels))
+		})
+	}
+}
+
+func TestRegisterPolicy(t *testing.T) {
+	// A registered custom policy is parseable and drives Reconcile.
+	RegisterPolicy("test-even-exit", nil, func(args []string, status containerd.Status, labels map[string]string) bool {
+		return status.ExitStatus%2 == 0
+	})
+
+	p, err := NewPolicy("test-even-exit")
+	assert.NoError(t, err)
+	assert.Equal(t, "test-even-exit", p.Name())
+
+	labels := map[string]string{PolicyLabel: "test-even-exit"}
+	assert.True(t, Reconcile(containerd.Status{Status: containerd.Stopped, ExitStatus: 2}, labels))
+	assert.False(t, Reconcile(containerd.Status{Status: containerd.Stopped, ExitStatus: 1}, labels))
+
+	assert.Panics(t, func() {
+		RegisterPolicy("always", nil, nil)
+	})
+}
@@ -0,0 +1,124 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
+
+	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/containerd/containerd/v2/core/runtime"
+)
+
+// CleanupReportEventTopic is published with a CleanupReport payload after
+// the cleanup barrier ran for a failed task start.
+const CleanupReportEventTopic = "/runtime/cleanup-report"
+
+// CleanupReport is the event payload describing what the cleanup barrier
+// could not clean after a failed start.
+type CleanupReport struct {
+	// ID of the task whose start failed.
+	ID string `json:"id"`
+	// Bundle path of the task.
+	Bundle string `json:"bundle"`
+	// Failures lists the resources that could not be cleaned, with
+	// reasons. Empty when everything was cleaned.
+	Failures []string `json:"failures,omitempty"`
+	// Timestamp of the cleanup pass.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func init() {
+	typeurl.Register(&CleanupReport{}, "io.containerd.runtime.v2.CleanupReport")
+}
+
+// cleanupFailure records one resource the cleanup barrier could not
+// clean.
+type cleanupFailure struct {
+	step     string
+	resource string
+	err      error
+}
+
+// cleanupBarrier tears down everything a failed task start may have left
+// behind: the rootfs mounts, the task FIFOs, stray logger processes
+// started for the task, the task's cgroup and finally the bundle
+// directory. Every step runs regardless of earlier failures, and a
+// single report listing anything that could not be cleaned is logged and
+// published as a CleanupReport event, instead of the partial silent
+// cleanup that used to leak IO resources.
+func cleanupBarrier(ctx context.Context, bundle *Bundle, io runtime.IO, publisher events.Publisher) {
+	var failures []cleanupFailure
+	record := func(step, resource string, err error) {
+		if err != nil {
+			failures = append(failures, cleanupFailure{step: step, resource: resource, err: err})
+		}
+	}
+
+	rootfs := filepath.Join(bundle.Path, "rootfs")
+	record("unmount rootfs", rootfs, mount.UnmountRecursive(rootfs, 0))
+
+	// Only stdio paths inside the bundle are owned by the runtime; caller
+	// provided paths elsewhere belong to the client and stay untouched.
+	for _, fifo := range []string{io.Stdin, io.Stdout, io.Stderr} {
+		if fifo == "" || !filepath.IsAbs(fifo) {
+			continue
+		}
+		if rel, err := filepath.Rel(bundle.Path, fifo); err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		if err := os.Remove(fifo); err != nil && !os.IsNotExist(err) {
+			record("remove fifo", fifo, err)
+		}
+	}
+
+	record("kill stray loggers", bundle.Path, killStrayProcesses(bundle.Path))
+	record("remove cgroup", bundle.Path, removeTaskCgroup(bundle.Path))
+	record("delete bundle", bundle.Path, bundle.Delete())
+
+	report := &CleanupReport{
+		ID:        bundle.ID,
+		Bundle:    bundle.Path,
+		Timestamp: time.Now().UTC(),
+	}
+	for _, f := range failures {
+		report.Failures = append(report.Failures, fmt.Sprintf("%s %s: %v", f.step, f.resource, f.err))
+	}
+	if publisher != nil {
+		if err := publisher.Publish(ctx, CleanupReportEventTopic, report); err != nil {
+			log.G(ctx).WithError(err).Error("failed to publish cleanup report event")
+		}
+	}
+
+	if len(failures) == 0 {
+		log.G(ctx).WithField("id", bundle.ID).Debug("cleanup barrier completed")
+		return
+	}
+	fields := log.Fields{"id": bundle.ID}
+	for i, f := range failures {
+		fields[fmt.Sprintf("failure.%d", i)] = fmt.Sprintf("%s %s: %v", f.step, f.resource, f.err)
+	}
+	log.G(ctx).WithFields(fields).Warnf("cleanup barrier left %d resources behind", len(failures))
+}
@@ -0,0 +1,123 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/containerd/cgroups/v3"
+	"github.com/containerd/cgroups/v3/cgroup1"
+	cgroupsv2 "github.com/containerd/cgroups/v3/cgroup2"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// killStrayProcesses kills helper processes left behind for the bundle,
+// such as logger binaries spawned by the dead shim. A process only
+// qualifies when it holds an open file descriptor inside the bundle
+// directory — merely mentioning the path on a command line (an operator's
+// shell, editor or ctr invocation) does not.
+func killStrayProcesses(bundlePath string) error {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return err
+	}
+	self := os.Getpid()
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil || pid == self {
+			continue
+		}
+		if !processHoldsPath(e.Name(), bundlePath) {
+			continue
+		}
+		if err := syscall.Kill(pid, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+			return fmt.Errorf("failed to kill stray process %d: %w", pid, err)
+		}
+	}
+	return nil
+}
+
+// processHoldsPath reports whether the process has an open file
+// descriptor resolving to a path under dir.
+func processHoldsPath(pid, dir string) bool {
+	fdDir := filepath.Join("/proc", pid, "fd")
+	fds, err := os.ReadDir(fdDir)
+	if err != nil {
+		return false
+	}
+	prefix := dir + string(os.PathSeparator)
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(fdDir, fd.Name()))
+		if err != nil {
+			continue
+		}
+		if target == dir || strings.HasPrefix(target, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeTaskCgroup deletes the cgroup the failed task was placed in, read
+// from the bundle's OCI config. Systemd managed cgroups (the
+// "slice:prefix:name" form) are owned by systemd and left alone, as are
+// cgroups that no longer exist.
+func removeTaskCgroup(bundlePath string) error {
+	data, err := os.ReadFile(filepath.Join(bundlePath, "config.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var spec specs.Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("failed to parse bundle config: %w", err)
+	}
+	if spec.Linux == nil || spec.Linux.CgroupsPath == "" || strings.Contains(spec.Linux.CgroupsPath, ":") {
+		return nil
+	}
+	path := spec.Linux.CgroupsPath
+
+	if cgroups.Mode() == cgroups.Unified {
+		cg, err := cgroupsv2.Load(path)
+		if err != nil {
+			return nil
+		}
+		if err := cg.Delete(); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete cgroup %q: %w", path, err)
+		}
+		return nil
+	}
+	control, err := cgroup1.Load(cgroup1.StaticPath(path))
+	if err != nil {
+		// The cgroup was never created or is already gone.
+		return nil
+	}
+	if err := control.Delete(); err != nil {
+		return fmt.Errorf("failed to delete cgroup %q: %w", path, err)
+	}
+	return nil
+}
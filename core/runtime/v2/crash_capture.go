@@ -0,0 +1,191 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
+
+	"github.com/containerd/containerd/v2/core/events"
+)
+
+// ShimCrashEventTopic is published with a ShimCrash payload when a shim
+// disconnects unexpectedly and crash capture is enabled.
+const ShimCrashEventTopic = "/runtime/shim-crash"
+
+// ShimCrash is the event payload describing an unexpected shim
+// disconnect.
+type ShimCrash struct {
+	// ID of the shim's task.
+	ID string `json:"id"`
+	// Bundle path of the task.
+	Bundle string `json:"bundle"`
+	// ReportPath is the crash report written for post-mortem analysis.
+	ReportPath string `json:"reportPath"`
+	// Timestamp of the disconnect.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func init() {
+	typeurl.Register(&ShimCrash{}, "io.containerd.runtime.v2.ShimCrash")
+}
+
+// crashCapture holds the opt-in shim crash capture configuration. When a
+// directory is configured, shims run with GOTRACEBACK=crash and the
+// daemon writes a bounded, rotated crash report per dead shim so
+// post-mortem analysis is possible in production.
+var crashCapture struct {
+	mu       sync.Mutex
+	dir      string
+	maxFiles int
+	// expected holds shim ids whose connection is about to close as part
+	// of an orderly delete or daemon shutdown; their disconnects are not
+	// crashes.
+	expected map[string]struct{}
+}
+
+// configureCrashCapture enables crash capture into dir, keeping at most
+// maxFiles reports.
+func configureCrashCapture(dir string, maxFiles int) {
+	crashCapture.mu.Lock()
+	defer crashCapture.mu.Unlock()
+	crashCapture.dir = dir
+	if maxFiles <= 0 {
+		maxFiles = 16
+	}
+	crashCapture.maxFiles = maxFiles
+	crashCapture.expected = make(map[string]struct{})
+}
+
+// markShimShutdownExpected records that the shim's connection is about to
+// close on purpose, suppressing the crash report its disconnect callback
+// would otherwise write. The mark is consumed by the disconnect and
+// cleared when a new shim starts for the id, since task ids are reused
+// (the restart monitor deletes a failed task and starts a new one under
+// the same id).
+func markShimShutdownExpected(id string) {
+	crashCapture.mu.Lock()
+	defer crashCapture.mu.Unlock()
+	if crashCapture.expected != nil {
+		crashCapture.expected[id] = struct{}{}
+	}
+}
+
+// clearShimShutdownExpected forgets a pending orderly-shutdown mark, so a
+// fresh shim for a reused id gets crash reports again.
+func clearShimShutdownExpected(id string) {
+	crashCapture.mu.Lock()
+	defer crashCapture.mu.Unlock()
+	delete(crashCapture.expected, id)
+}
+
+// captureShimCrash writes a crash report for the dead shim, including the
+// shim's log file when present, publishes a crash event and rotates old
+// reports.
+func captureShimCrash(ctx context.Context, id string, bundle *Bundle, publisher events.Publisher) {
+	crashCapture.mu.Lock()
+	dir, maxFiles := crashCapture.dir, crashCapture.maxFiles
+	_, orderly := crashCapture.expected[id]
+	// Consume the mark so only this disconnect is excused; a later shim
+	// under the same id reports crashes again.
+	delete(crashCapture.expected, id)
+	crashCapture.mu.Unlock()
+	if dir == "" || orderly {
+		// Task deletion and daemon initiated shutdown close the shim
+		// connection too; only unexpected disconnects are crashes.
+		return
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		log.G(ctx).WithError(err).Error("failed to create shim crash directory")
+		return
+	}
+
+	report := fmt.Sprintf("shim %s disconnected unexpectedly at %s\nbundle: %s\n",
+		id, time.Now().UTC().Format(time.RFC3339), bundle.Path)
+
+	// The shim's Go panic output ends up in the bundle log file when it
+	// is a regular file; include it in the report.
+	logPath := filepath.Join(bundle.Path, "log")
+	if fi, err := os.Stat(logPath); err == nil && fi.Mode().IsRegular() {
+		const maxLogBytes = 64 * 1024
+		if data, err := os.ReadFile(logPath); err == nil {
+			if len(data) > maxLogBytes {
+				data = data[len(data)-maxLogBytes:]
+			}
+			report += "\nshim log tail:\n" + string(data)
+		}
+	}
+
+	name := fmt.Sprintf("%s-%d.crash", id, time.Now().UnixNano())
+	reportPath := filepath.Join(dir, name)
+	if err := os.WriteFile(reportPath, []byte(report), 0600); err != nil {
+		log.G(ctx).WithError(err).Error("failed to write shim crash report")
+		return
+	}
+	log.G(ctx).Warnf("captured crash report for shim %s in %s", id, reportPath)
+	if publisher != nil {
+		if err := publisher.Publish(ctx, ShimCrashEventTopic, &ShimCrash{
+			ID:         id,
+			Bundle:     bundle.Path,
+			ReportPath: reportPath,
+			Timestamp:  time.Now().UTC(),
+		}); err != nil {
+			log.G(ctx).WithError(err).Error("failed to publish shim crash event")
+		}
+	}
+	rotateCrashReports(ctx, dir, maxFiles)
+}
+
+// rotateCrashReports removes the oldest reports beyond the bound.
+func rotateCrashReports(ctx context.Context, dir string, maxFiles int) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	var names []string
+	for _, e := range entries {
+		if filepath.Ext(e.Name()) == ".crash" {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) <= maxFiles {
+		return
+	}
+	// The timestamp suffix makes lexical order chronological per shim;
+	// sort by modification time to be safe across ids.
+	sort.Slice(names, func(i, j int) bool {
+		fi, _ := os.Stat(filepath.Join(dir, names[i]))
+		fj, _ := os.Stat(filepath.Join(dir, names[j]))
+		if fi == nil || fj == nil {
+			return names[i] < names[j]
+		}
+		return fi.ModTime().Before(fj.ModTime())
+	})
+	for _, name := range names[:len(names)-maxFiles] {
+		if err := os.Remove(filepath.Join(dir, name)); err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to rotate crash report %q", name)
+		}
+	}
+}
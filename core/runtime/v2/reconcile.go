@@ -0,0 +1,164 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	goMetrics "github.com/docker/go-metrics"
+
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+)
+
+var (
+	orphanedShimCounter goMetrics.Counter
+	staleBundleCounter  goMetrics.Counter
+)
+
+func init() {
+	ns := goMetrics.NewNamespace("containerd", "shim_reconcile", nil)
+	orphanedShimCounter = ns.NewCounter("orphaned_shims", "number of orphaned shims cleaned up by reconciliation")
+	staleBundleCounter = ns.NewCounter("stale_bundles", "number of stale bundle directories removed by reconciliation")
+	goMetrics.Register(ns)
+}
+
+// ReconcileReport summarizes the discrepancies found while cross-checking
+// the metadata store against live shims and on-disk bundles in a namespace.
+type ReconcileReport struct {
+	// Namespace the report applies to.
+	Namespace string `json:"namespace"`
+	// OrphanedShims are ids of live shims with no container or sandbox
+	// record, which have been shut down and removed.
+	OrphanedShims []string `json:"orphanedShims,omitempty"`
+	// StaleBundles are ids of bundle directories with neither a live shim
+	// nor a container record, which have been removed.
+	StaleBundles []string `json:"staleBundles,omitempty"`
+	// Errors are messages for discrepancies that could not be repaired.
+	Errors []string `json:"errors,omitempty"`
+	// Timestamp is when the reconciliation pass ran.
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Reconcile cross-checks the metadata store against live shims and on-disk
+// bundle state under stateDir, cleaning up orphaned shims and stale bundle
+// directories left behind by an unclean daemon restart. It returns one
+// report per namespace; the reports from the most recent pass are also kept
+// for inspection via LastReconcile.
+func (m *ShimManager) Reconcile(ctx context.Context, stateDir string) ([]ReconcileReport, error) {
+	nsDirs, err := os.ReadDir(stateDir)
+	if err != nil {
+		return nil, err
+	}
+	var reports []ReconcileReport
+	for _, nsd := range nsDirs {
+		if !nsd.IsDir() || nsd.Name()[0] == '.' {
+			continue
+		}
+		ns := nsd.Name()
+		report := m.reconcileNamespace(namespaces.WithNamespace(ctx, ns), stateDir, ns)
+		if len(report.OrphanedShims) > 0 || len(report.StaleBundles) > 0 || len(report.Errors) > 0 {
+			log.G(ctx).WithField("namespace", ns).Infof("shim reconciliation cleaned %d orphaned shims, %d stale bundles with %d errors",
+				len(report.OrphanedShims), len(report.StaleBundles), len(report.Errors))
+		}
+		reports = append(reports, report)
+	}
+
+	m.reconcileMu.Lock()
+	m.lastReconcile = reports
+	m.reconcileMu.Unlock()
+	return reports, nil
+}
+
+// LastReconcile returns the reports from the most recent reconciliation
+// pass, or nil when no pass has run yet.
+func (m *ShimManager) LastReconcile() []ReconcileReport {
+	m.reconcileMu.Lock()
+	defer m.reconcileMu.Unlock()
+	return m.lastReconcile
+}
+
+func (m *ShimManager) reconcileNamespace(ctx context.Context, stateDir, ns string) ReconcileReport {
+	report := ReconcileReport{
+		Namespace: ns,
+		Timestamp: time.Now(),
+	}
+
+	shimDirs, err := os.ReadDir(filepath.Join(stateDir, ns))
+	if err != nil {
+		report.Errors = append(report.Errors, fmt.Sprintf("failed to read namespace state dir: %v", err))
+		return report
+	}
+	for _, sd := range shimDirs {
+		if !sd.IsDir() || sd.Name()[0] == '.' {
+			continue
+		}
+		id := sd.Name()
+
+		if _, err := m.containers.Get(ctx, id); err == nil || !errdefs.IsNotFound(err) {
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to query container %q: %v", id, err))
+			}
+			continue
+		}
+		if _, err := m.sandboxStore.Get(ctx, id); err == nil || !errdefs.IsNotFound(err) {
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to query sandbox %q: %v", id, err))
+			}
+			continue
+		}
+
+		// Neither a container nor a sandbox record exists for this id. A
+		// live shim is orphaned and shut down; a dead bundle directory
+		// with its FIFOs is removed.
+		if shim, err := m.shims.Get(ctx, id); err == nil {
+			st, err := newShimTask(shim)
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to connect to orphaned shim %q: %v", id, err))
+				continue
+			}
+			log.G(ctx).WithField("id", id).Info("cleaning up orphaned shim")
+			if _, err := st.delete(ctx, false, func(ctx context.Context, id string) {}); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("failed to delete orphaned shim %q: %v", id, err))
+				continue
+			}
+			m.shims.Delete(ctx, id)
+			report.OrphanedShims = append(report.OrphanedShims, id)
+			orphanedShimCounter.Inc()
+			continue
+		}
+
+		bundle, err := LoadBundle(ctx, stateDir, id)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to load stale bundle %q: %v", id, err))
+			continue
+		}
+		log.G(ctx).WithField("id", id).Info("removing stale bundle directory")
+		if err := bundle.Delete(); err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("failed to delete stale bundle %q: %v", id, err))
+			continue
+		}
+		report.StaleBundles = append(report.StaleBundles, id)
+		staleBundleCounter.Inc()
+	}
+	return report
+}
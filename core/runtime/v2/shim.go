@@ -262,10 +262,15 @@ func makeConnection(ctx context.Context, id string, params client.BootstrapParam
 
 	switch strings.ToLower(params.Protocol) {
 	case "ttrpc":
-		conn, err := client.Connect(params.Address, client.AnonReconnectDialer)
+		conn, handled, err := dialShimConn(ctx, params.Address, params.TLS)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create TTRPC connection: %w", err)
 		}
+		if !handled {
+			if conn, err = client.Connect(params.Address, client.AnonReconnectDialer); err != nil {
+				return nil, fmt.Errorf("failed to create TTRPC connection: %w", err)
+			}
+		}
 		defer func() {
 			if retErr != nil {
 				conn.Close()
@@ -281,6 +286,15 @@ func makeConnection(ctx context.Context, id string, params client.BootstrapParam
 			grpc.WithTransportCredentials(insecure.NewCredentials()),
 			grpc.WithBlock(),
 		}
+		if scheme, _ := splitShimAddress(params.Address); scheme != "unix" {
+			// Remote shims are reached through the vsock/tcp dialer, with
+			// mTLS applied on the raw connection when configured.
+			gopts = append(gopts, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				conn, _, err := dialShimConn(ctx, params.Address, params.TLS)
+				return conn, err
+			}))
+			return grpcDial(ctx, "passthrough:///"+params.Address, onClose, gopts...)
+		}
 		return grpcDialContext(ctx, params.Address, onClose, gopts...)
 	default:
 		return nil, fmt.Errorf("unexpected protocol: %q", params.Protocol)
@@ -314,7 +328,17 @@ func grpcDialContext(
 	}
 	conn.Close()
 
-	target := dialer.DialAddress(address)
+	return grpcDial(ctx, dialer.DialAddress(address), onClose, gopts...)
+}
+
+// grpcDial dials the grpc target directly, used for both local unix and
+// remote vsock/tcp shim addresses.
+func grpcDial(
+	ctx context.Context,
+	target string,
+	onClose func(),
+	gopts ...grpc.DialOption,
+) (*grpcConn, error) {
 	client, err := grpc.DialContext(ctx, target, gopts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GRPC connection: %w", err)
@@ -407,6 +431,7 @@ func (s *shim) Close() error {
 }
 
 func (s *shim) Delete(ctx context.Context) error {
+	markShimShutdownExpected(s.ID())
 	var result []error
 
 	if ttrpcClient, ok := s.client.(*ttrpc.Client); ok {
@@ -459,6 +484,7 @@ func newShimTask(shim ShimInstance) (*shimTask, error) {
 }
 
 func (s *shimTask) Shutdown(ctx context.Context) error {
+	markShimShutdownExpected(s.ID())
 	_, err := s.task.Shutdown(ctx, &task.ShutdownRequest{
 		ID: s.ID(),
 	})
@@ -487,6 +513,7 @@ func (s *shimTask) PID(ctx context.Context) (uint32, error) {
 }
 
 func (s *shimTask) delete(ctx context.Context, sandboxed bool, removeTask func(ctx context.Context, id string)) (*runtime.Exit, error) {
+	markShimShutdownExpected(s.ID())
 	response, shimErr := s.task.Delete(ctx, &task.DeleteRequest{
 		ID: s.ID(),
 	})
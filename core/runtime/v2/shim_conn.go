@@ -0,0 +1,95 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	client "github.com/containerd/containerd/v2/pkg/shim"
+)
+
+// splitShimAddress splits a shim bootstrap address into its scheme and
+// endpoint. Addresses without a scheme are unix socket paths.
+func splitShimAddress(address string) (scheme, endpoint string) {
+	if s, e, ok := strings.Cut(address, "://"); ok {
+		return s, e
+	}
+	return "unix", address
+}
+
+// dialShimConn dials remote shim bootstrap addresses ("vsock://<cid>:<port>"
+// and "tcp://<host>:<port>"), used by shims running inside VMs or on
+// microVM hosts. It reports handled as false for unix socket addresses so
+// callers keep their local connection path.
+func dialShimConn(ctx context.Context, address string, tlsParams *client.TLSParams) (_ net.Conn, handled bool, _ error) {
+	scheme, endpoint := splitShimAddress(address)
+	switch scheme {
+	case "vsock":
+		conn, err := dialVsock(endpoint)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to dial vsock address %q: %w", address, err)
+		}
+		return conn, true, nil
+	case "tcp":
+		conn, err := (&net.Dialer{Timeout: 10 * time.Second}).DialContext(ctx, "tcp", endpoint)
+		if err != nil {
+			return nil, true, fmt.Errorf("failed to dial tcp address %q: %w", address, err)
+		}
+		if tlsParams != nil {
+			tlsConfig, err := loadShimTLSConfig(tlsParams)
+			if err != nil {
+				conn.Close()
+				return nil, true, err
+			}
+			conn = tls.Client(conn, tlsConfig)
+		}
+		return conn, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// loadShimTLSConfig builds the client side mTLS configuration for a tcp
+// shim connection.
+func loadShimTLSConfig(params *client.TLSParams) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(params.CertFile, params.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load shim client certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if params.CAFile != "" {
+		ca, err := os.ReadFile(params.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read shim CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("failed to parse shim CA certificate %q", params.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
@@ -0,0 +1,45 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package v2
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/mdlayher/vsock"
+)
+
+// dialVsock dials a "<cid>:<port>" vsock endpoint.
+func dialVsock(endpoint string) (net.Conn, error) {
+	cidStr, portStr, ok := strings.Cut(endpoint, ":")
+	if !ok {
+		return nil, fmt.Errorf("expected vsock endpoint <cid>:<port>, got %q", endpoint)
+	}
+	cid, err := strconv.ParseUint(cidStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vsock cid %q: %w", cidStr, err)
+	}
+	port, err := strconv.ParseUint(portStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vsock port %q: %w", portStr, err)
+	}
+	return vsock.Dial(uint32(cid), uint32(port), nil)
+}
@@ -58,6 +58,9 @@ func (m *ShimManager) LoadExistingShims(ctx context.Context, stateDir string, ro
 			continue
 		}
 	}
+	if _, err := m.Reconcile(ctx, stateDir); err != nil {
+		log.G(ctx).WithError(err).Error("failed to reconcile loaded shims against metadata store")
+	}
 	return nil
 }
 
@@ -157,9 +160,11 @@ func (m *ShimManager) loadShim(ctx context.Context, bundle *Bundle) error {
 			env:          m.env,
 		})
 	// TODO: It seems we can only call loadShim here if it is a sandbox shim?
+	clearShimShutdownExpected(id)
 	shim, err := loadShimTask(ctx, bundle, func() {
 		log.G(ctx).WithField("id", id).Info("shim disconnected")
 
+		captureShimCrash(cleanup.Background(ctx), id, bundle, m.events)
 		cleanupAfterDeadShim(cleanup.Background(ctx), id, m.shims, m.events, binaryCall)
 		// Remove self from the runtime task list.
 		m.shims.Delete(ctx, id)
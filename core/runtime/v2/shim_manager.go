@@ -48,6 +48,13 @@ import (
 type ShimConfig struct {
 	// Env is environment variables added to shim processes
 	Env []string `toml:"env"`
+	// CrashDir, when set, enables shim crash capture: shims run with
+	// GOTRACEBACK=crash and the daemon writes a rotated crash report per
+	// unexpectedly dead shim into the directory.
+	CrashDir string `toml:"crash_dir"`
+	// MaxCrashFiles bounds the number of crash reports kept. Defaults
+	// to 16.
+	MaxCrashFiles int `toml:"max_crash_files"`
 }
 
 func init() {
@@ -76,12 +83,17 @@ func init() {
 			events := ep.(*exchange.Exchange)
 			cs := metadata.NewContainerStore(m.(*metadata.DB))
 			ss := metadata.NewSandboxStore(m.(*metadata.DB))
+			env := config.Env
+			if config.CrashDir != "" {
+				configureCrashCapture(config.CrashDir, config.MaxCrashFiles)
+				env = append(append([]string{}, env...), "GOTRACEBACK=crash")
+			}
 			return NewShimManager(&ManagerConfig{
 				Address:      ic.Properties[plugins.PropertyGRPCAddress],
 				TTRPCAddress: ic.Properties[plugins.PropertyTTRPCAddress],
 				Events:       events,
 				Store:        cs,
-				ShimEnv:      config.Env,
+				ShimEnv:      env,
 				SandboxStore: ss,
 			})
 		},
@@ -155,6 +167,10 @@ type ShimManager struct {
 	// runtimePaths is a cache of `runtime names` -> `resolved fs path`
 	runtimePaths sync.Map
 	sandboxStore sandbox.Store
+	// reconcileMu guards lastReconcile, the reports from the most recent
+	// reconciliation pass.
+	reconcileMu   sync.Mutex
+	lastReconcile []ReconcileReport
 }
 
 // ID of the shim manager
@@ -216,6 +232,9 @@ func (m *ShimManager) Start(ctx context.Context, id string, bundle *Bundle, opts
 		return shim, nil
 	}
 
+	// Task ids are reused after deletion; a fresh shim must not inherit a
+	// stale orderly-shutdown mark.
+	clearShimShutdownExpected(id)
 	shim, err := m.startShim(ctx, bundle, id, opts)
 	if err != nil {
 		return nil, err
@@ -223,6 +242,9 @@ func (m *ShimManager) Start(ctx context.Context, id string, bundle *Bundle, opts
 	defer func() {
 		if retErr != nil {
 			m.cleanupShim(ctx, shim)
+			// Run the full cleanup barrier so a failed start cannot leak
+			// mounts, FIFOs, logger processes or cgroups.
+			cleanupBarrier(ctx, bundle, opts.IO, m.events)
 		}
 	}()
 
@@ -259,6 +281,7 @@ func (m *ShimManager) startShim(ctx context.Context, bundle *Bundle, id string,
 	shim, err := b.Start(ctx, protobuf.FromAny(topts), func() {
 		log.G(ctx).WithField("id", id).Info("shim disconnected")
 
+		captureShimCrash(cleanup.Background(ctx), id, bundle, m.events)
 		cleanupAfterDeadShim(cleanup.Background(ctx), id, m.shims, m.events, b)
 		// Remove self from the runtime task list. Even though the cleanupAfterDeadShim()
 		// would publish taskExit event, but the shim.Delete() would always failed with ttrpc
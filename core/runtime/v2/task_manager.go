@@ -26,6 +26,7 @@ import (
 	"slices"
 
 	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
 	"github.com/containerd/platforms"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
@@ -37,6 +38,7 @@ import (
 
 	"github.com/containerd/containerd/v2/core/runtime"
 	"github.com/containerd/containerd/v2/internal/cleanup"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/pkg/protobuf"
 	"github.com/containerd/containerd/v2/pkg/protobuf/proto"
 	"github.com/containerd/containerd/v2/pkg/timeout"
@@ -224,6 +226,40 @@ func (m *TaskManager) Delete(ctx context.Context, taskID string) (*runtime.Exit,
 	return exit, nil
 }
 
+// Shutdown stops every shim managed by the task manager. When preserve is
+// true connections to the shims are closed without asking them to shut
+// down, leaving containers running so a restarted daemon can re-parent
+// them through LoadExistingShims; this is used across containerd and shim
+// binary upgrades. Otherwise each shim is asked to shut down, tearing its
+// containers down with it.
+func (m *TaskManager) Shutdown(ctx context.Context, preserve bool) {
+	shims, err := m.manager.shims.GetAll(ctx, true)
+	if err != nil {
+		log.G(ctx).WithError(err).Error("failed to list shims for shutdown")
+		return
+	}
+	for _, shim := range shims {
+		nsCtx := namespaces.WithNamespace(ctx, shim.Namespace())
+		if preserve {
+			markShimShutdownExpected(shim.ID())
+			if err := shim.Close(); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to disconnect from shim %q", shim.ID())
+			}
+		} else {
+			st, err := newShimTask(shim)
+			if err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to connect to shim %q for shutdown", shim.ID())
+				continue
+			}
+			if err := st.waitShutdown(nsCtx); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to shutdown shim %q", shim.ID())
+				continue
+			}
+		}
+		m.manager.shims.Delete(nsCtx, shim.ID())
+	}
+}
+
 func (m *TaskManager) PluginInfo(ctx context.Context, request interface{}) (interface{}, error) {
 	req, ok := request.(*apitypes.RuntimeRequest)
 	if !ok {
@@ -0,0 +1,202 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package migrate exports a committed snapshot chain to a portable tar
+// archive and imports it on another node, preserving chain IDs. This is
+// used to pre-seed nodes or migrate container writable layers.
+package migrate
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/diff"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/pkg/rootfs"
+)
+
+// indexFile is the name of the archive entry describing the exported
+// layers. It is always the first entry in the archive.
+const indexFile = "index.json"
+
+// indexEntry describes one exported layer.
+type indexEntry struct {
+	// MediaType of the layer blob.
+	MediaType string `json:"mediaType"`
+	// Digest of the layer blob.
+	Digest digest.Digest `json:"digest"`
+	// Size of the layer blob in bytes.
+	Size int64 `json:"size"`
+	// DiffID is the digest of the uncompressed layer, used to rebuild the
+	// chain ID on import.
+	DiffID digest.Digest `json:"diffID"`
+}
+
+// Differ is the subset of the diff service needed for export and import.
+type Differ interface {
+	diff.Comparer
+	diff.Applier
+}
+
+// Export writes the committed snapshot chain ending at key to w as a
+// portable archive. Layer diffs are computed against each parent with the
+// differ and staged through the content store.
+func Export(ctx context.Context, w io.Writer, key string, sn snapshots.Snapshotter, cs content.Store, differ Differ) error {
+	// Collect the chain from the root down to the requested snapshot.
+	var chain []snapshots.Info
+	for next := key; next != ""; {
+		info, err := sn.Stat(ctx, next)
+		if err != nil {
+			return fmt.Errorf("failed to stat snapshot %q: %w", next, err)
+		}
+		if info.Kind != snapshots.KindCommitted {
+			return fmt.Errorf("snapshot %q is not committed, only committed chains can be exported", next)
+		}
+		chain = append([]snapshots.Info{info}, chain...)
+		next = info.Parent
+	}
+
+	var index []indexEntry
+	for _, info := range chain {
+		desc, err := rootfs.CreateDiff(ctx, info.Name, sn, differ)
+		if err != nil {
+			return fmt.Errorf("failed to diff snapshot %q: %w", info.Name, err)
+		}
+		diffID, err := images.GetDiffID(ctx, cs, desc)
+		if err != nil {
+			return fmt.Errorf("failed to get diff ID of layer for %q: %w", info.Name, err)
+		}
+		index = append(index, indexEntry{
+			MediaType: desc.MediaType,
+			Digest:    desc.Digest,
+			Size:      desc.Size,
+			DiffID:    diffID,
+		})
+	}
+
+	tw := tar.NewWriter(w)
+	indexBytes, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{
+		Name: indexFile,
+		Mode: 0644,
+		Size: int64(len(indexBytes)),
+	}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(indexBytes); err != nil {
+		return err
+	}
+
+	for _, entry := range index {
+		if err := tw.WriteHeader(&tar.Header{
+			Name: "blobs/" + entry.Digest.Encoded(),
+			Mode: 0644,
+			Size: entry.Size,
+		}); err != nil {
+			return err
+		}
+		ra, err := cs.ReaderAt(ctx, ocispec.Descriptor{Digest: entry.Digest, Size: entry.Size})
+		if err != nil {
+			return fmt.Errorf("failed to read layer %s: %w", entry.Digest, err)
+		}
+		_, err = io.Copy(tw, io.NewSectionReader(ra, 0, entry.Size))
+		ra.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// Import reads an archive written by Export, stages the layer blobs in the
+// content store and applies them with the differ, recreating the snapshot
+// chain with the same chain IDs. The chain ID of the imported top-level
+// snapshot is returned.
+func Import(ctx context.Context, r io.Reader, sn snapshots.Snapshotter, cs content.Store, differ Differ) (digest.Digest, error) {
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return "", err
+	}
+	if hdr.Name != indexFile {
+		return "", fmt.Errorf("unexpected first archive entry %q, expected %q", hdr.Name, indexFile)
+	}
+	indexBytes, err := io.ReadAll(tr)
+	if err != nil {
+		return "", err
+	}
+	var index []indexEntry
+	if err := json.Unmarshal(indexBytes, &index); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", indexFile, err)
+	}
+	if len(index) == 0 {
+		return "", fmt.Errorf("archive contains no layers")
+	}
+
+	byName := make(map[string]indexEntry, len(index))
+	for _, entry := range index {
+		byName["blobs/"+entry.Digest.Encoded()] = entry
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		entry, ok := byName[hdr.Name]
+		if !ok {
+			continue
+		}
+		desc := ocispec.Descriptor{
+			MediaType: entry.MediaType,
+			Digest:    entry.Digest,
+			Size:      entry.Size,
+		}
+		if err := content.WriteBlob(ctx, cs, "snapshot-import-"+entry.Digest.String(), tr, desc); err != nil {
+			return "", fmt.Errorf("failed to write layer %s: %w", entry.Digest, err)
+		}
+	}
+
+	layers := make([]rootfs.Layer, len(index))
+	for i, entry := range index {
+		layers[i] = rootfs.Layer{
+			Diff: ocispec.Descriptor{
+				MediaType: ocispec.MediaTypeImageLayer,
+				Digest:    entry.DiffID,
+			},
+			Blob: ocispec.Descriptor{
+				MediaType: entry.MediaType,
+				Digest:    entry.Digest,
+				Size:      entry.Size,
+			},
+		}
+	}
+	return rootfs.ApplyLayers(ctx, layers, sn, differ)
+}
@@ -0,0 +1,105 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package probe detects the capabilities of configured proxy snapshotters
+// at startup, so consumers can fall back to another snapshotter up front
+// instead of failing at container create time. Probe results are kept in
+// a process wide registry and surfaced as plugin exports through the
+// introspection API.
+package probe
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/containerd/errdefs"
+
+	"github.com/containerd/containerd/v2/core/snapshots"
+)
+
+// probeTimeout bounds each probe call against an unresponsive proxy.
+const probeTimeout = 5 * time.Second
+
+// Capabilities describes what a probed snapshotter supports.
+type Capabilities struct {
+	// Responsive is true when the snapshotter answered the probe at all.
+	// An unresponsive proxy snapshotter will fail every snapshot request.
+	Responsive bool `json:"responsive"`
+	// UsageReporting is true when the snapshotter implements Usage.
+	UsageReporting bool `json:"usageReporting"`
+	// Cleanup is true when the snapshotter implements the Cleaner
+	// interface.
+	Cleanup bool `json:"cleanup"`
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Capabilities{}
+)
+
+// Probe detects the snapshotter's capabilities with cheap requests and
+// records the result in the registry under name.
+func Probe(ctx context.Context, name string, sn snapshots.Snapshotter) Capabilities {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	var caps Capabilities
+
+	// A NotFound for a bogus key proves the round trip works; transport
+	// errors mean the proxy is unresponsive.
+	const probeKey = "io.containerd.snapshotter-probe"
+	if _, err := sn.Stat(ctx, probeKey); err == nil || errdefs.IsNotFound(err) {
+		caps.Responsive = true
+	}
+	if caps.Responsive {
+		if _, err := sn.Usage(ctx, probeKey); err == nil || errdefs.IsNotFound(err) {
+			caps.UsageReporting = true
+		}
+	}
+	if _, ok := sn.(snapshots.Cleaner); ok {
+		caps.Cleanup = true
+	}
+
+	Set(name, caps)
+	return caps
+}
+
+// Set records the capabilities of the snapshotter.
+func Set(name string, caps Capabilities) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = caps
+}
+
+// Get returns the recorded capabilities of the snapshotter. ok is false
+// when the snapshotter has not been probed.
+func Get(name string) (Capabilities, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	caps, ok := registry[name]
+	return caps, ok
+}
+
+// Export renders the capabilities for plugin exports.
+func (c Capabilities) Export() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -18,6 +18,7 @@ package proxy
 
 import (
 	"context"
+	"fmt"
 	"io"
 
 	snapshotsapi "github.com/containerd/containerd/api/services/snapshots/v1"
@@ -189,3 +190,26 @@ func (p *proxySnapshotter) Cleanup(ctx context.Context) error {
 	})
 	return errdefs.FromGRPC(err)
 }
+
+// BatchStat returns info for the given keys over a single streaming List
+// call, with one name filter per key evaluated server-side, so neither
+// one Stat RPC per snapshot nor a full sweep of the remote snapshotter is
+// needed. Keys that do not exist are left out of the result.
+func (p *proxySnapshotter) BatchStat(ctx context.Context, keys []string) ([]snapshots.Info, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	filters := make([]string, len(keys))
+	for i, key := range keys {
+		filters[i] = fmt.Sprintf("name==%q", key)
+	}
+	infos := make([]snapshots.Info, 0, len(keys))
+	err := p.Walk(ctx, func(ctx context.Context, info snapshots.Info) error {
+		infos = append(infos, info)
+		return nil
+	}, filters...)
+	if err != nil {
+		return nil, err
+	}
+	return infos, nil
+}
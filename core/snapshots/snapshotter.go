@@ -464,3 +464,21 @@ func FilterInheritedLabels(labels map[string]string) map[string]string {
 	}
 	return filtered
 }
+
+// LabelSnapshotCommittedSize records the byte size of a committed layer
+// on its snapshot info, written at commit time so capacity tracking tools
+// do not re-walk upperdirs.
+const LabelSnapshotCommittedSize = "containerd.io/snapshot.committed-size"
+
+// LabelSnapshotCommittedInodes records the inode count of a committed
+// layer on its snapshot info.
+const LabelSnapshotCommittedInodes = "containerd.io/snapshot.committed-inodes"
+
+// BatchStatter is optionally implemented by snapshotters that can return
+// info for many snapshots in one call, avoiding per-snapshot RPC overhead
+// during GC and stats collection.
+type BatchStatter interface {
+	// BatchStat returns info for the given keys; missing keys are simply
+	// left out of the result.
+	BatchStat(ctx context.Context, keys []string) ([]Info, error)
+}
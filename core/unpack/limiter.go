@@ -0,0 +1,106 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package unpack
+
+import (
+	"context"
+	"sync"
+)
+
+// PriorityLimiter bounds the number of concurrent unpacks while letting
+// higher priority unpacks (container starts) go ahead of lower priority
+// ones (background prefetch jobs) when slots free up.
+type PriorityLimiter struct {
+	mu      sync.Mutex
+	active  int
+	max     int
+	waiters []*limiterWaiter
+}
+
+type limiterWaiter struct {
+	priority int
+	ready    chan struct{}
+}
+
+// NewPriorityLimiter returns a limiter allowing max concurrent unpacks.
+func NewPriorityLimiter(max int) *PriorityLimiter {
+	return &PriorityLimiter{max: max}
+}
+
+// Acquire blocks until an unpack slot is available. When multiple callers
+// wait, the one with the highest priority is admitted first; equal
+// priorities are admitted in arrival order.
+func (l *PriorityLimiter) Acquire(ctx context.Context, priority int) error {
+	l.mu.Lock()
+	if l.active < l.max && len(l.waiters) == 0 {
+		l.active++
+		l.mu.Unlock()
+		return nil
+	}
+	w := &limiterWaiter{
+		priority: priority,
+		ready:    make(chan struct{}),
+	}
+	// Insert behind every waiter of equal or higher priority.
+	pos := len(l.waiters)
+	for pos > 0 && l.waiters[pos-1].priority < priority {
+		pos--
+	}
+	l.waiters = append(l.waiters, nil)
+	copy(l.waiters[pos+1:], l.waiters[pos:])
+	l.waiters[pos] = w
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		// Give the slot away if it was granted concurrently with the
+		// cancellation.
+		select {
+		case <-w.ready:
+			l.release()
+		default:
+			for i, waiter := range l.waiters {
+				if waiter == w {
+					l.waiters = append(l.waiters[:i], l.waiters[i+1:]...)
+					break
+				}
+			}
+		}
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Release frees an unpack slot, admitting the highest priority waiter.
+func (l *PriorityLimiter) Release() {
+	l.mu.Lock()
+	l.release()
+	l.mu.Unlock()
+}
+
+func (l *PriorityLimiter) release() {
+	if len(l.waiters) > 0 {
+		w := l.waiters[0]
+		l.waiters = l.waiters[1:]
+		close(w.ready)
+		return
+	}
+	l.active--
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package unpack
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriorityLimiterOrdering(t *testing.T) {
+	ctx := context.Background()
+	l := NewPriorityLimiter(1)
+	require.NoError(t, l.Acquire(ctx, 0))
+
+	order := make(chan int, 2)
+	acquired := func(priority int) chan struct{} {
+		done := make(chan struct{})
+		go func() {
+			if err := l.Acquire(ctx, priority); err == nil {
+				order <- priority
+			}
+			close(done)
+		}()
+		return done
+	}
+
+	// A low priority prefetch waits first, then a high priority start.
+	lowDone := acquired(0)
+	time.Sleep(50 * time.Millisecond)
+	highDone := acquired(10)
+	time.Sleep(50 * time.Millisecond)
+
+	// Releasing admits the high priority waiter first.
+	l.Release()
+	<-highDone
+	l.Release()
+	<-lowDone
+
+	assert.Equal(t, 10, <-order)
+	assert.Equal(t, 0, <-order)
+}
+
+func TestPriorityLimiterCancel(t *testing.T) {
+	l := NewPriorityLimiter(1)
+	require.NoError(t, l.Acquire(context.Background(), 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := l.Acquire(ctx, 0)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The slot is still usable after the cancelled waiter left.
+	l.Release()
+	require.NoError(t, l.Acquire(context.Background(), 0))
+	l.Release()
+}
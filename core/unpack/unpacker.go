@@ -63,6 +63,8 @@ type unpackerConfig struct {
 	content content.Store
 
 	limiter               *semaphore.Weighted
+	priorityLimiter       *PriorityLimiter
+	priority              int
 	duplicationSuppressor kmutex.KeyedLocker
 }
 
@@ -113,6 +115,17 @@ func WithLimiter(l *semaphore.Weighted) UnpackerOpt {
 	})
 }
 
+// WithPriorityLimiter bounds concurrent unpacks through the shared
+// limiter, with this unpacker's unpacks waiting at the given priority.
+// Higher priorities are admitted first when slots free up.
+func WithPriorityLimiter(l *PriorityLimiter, priority int) UnpackerOpt {
+	return UnpackerOpt(func(c *unpackerConfig) error {
+		c.priorityLimiter = l
+		c.priority = priority
+		return nil
+	})
+}
+
 func WithDuplicationSuppressor(d kmutex.KeyedLocker) UnpackerOpt {
 	return UnpackerOpt(func(c *unpackerConfig) error {
 		c.duplicationSuppressor = d
@@ -499,6 +512,9 @@ func (u *Unpacker) fetch(ctx context.Context, h images.Handler, layers []ocispec
 }
 
 func (u *Unpacker) acquire(ctx context.Context) error {
+	if u.priorityLimiter != nil {
+		return u.priorityLimiter.Acquire(ctx, u.priority)
+	}
 	if u.limiter == nil {
 		return nil
 	}
@@ -506,6 +522,10 @@ func (u *Unpacker) acquire(ctx context.Context) error {
 }
 
 func (u *Unpacker) release() {
+	if u.priorityLimiter != nil {
+		u.priorityLimiter.Release()
+		return
+	}
 	if u.limiter == nil {
 		return
 	}
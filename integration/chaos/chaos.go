@@ -0,0 +1,144 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package chaos provides a harness that randomly kills the daemon or
+// individual shims while integration tests run container lifecycles, so
+// tests can assert the crash-recovery guarantees: tasks are recovered,
+// FIFOs cleaned and the restart monitor converges.
+//
+// Lifecycle tests opt in with:
+//
+//	stop := chaos.Start(t, chaos.DaemonTarget(pid), chaos.ShimTarget())
+//	defer stop()
+//
+// and the harness stays inert unless CONTAINERD_CHAOS=1 is set, keeping
+// regular CI runs deterministic.
+package chaos
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// enabledEnv opts test runs into chaos mode.
+const enabledEnv = "CONTAINERD_CHAOS"
+
+// Enabled reports whether chaos mode is requested for this test run.
+func Enabled() bool {
+	return os.Getenv(enabledEnv) == "1"
+}
+
+// Target is something the harness can kill.
+type Target struct {
+	// Name for logging.
+	Name string
+	// Kill terminates the target. Returning an error is fine when the
+	// target does not currently exist.
+	Kill func() error
+}
+
+// DaemonTarget kills the test daemon process.
+func DaemonTarget(pid func() int) Target {
+	return Target{
+		Name: "containerd",
+		Kill: func() error {
+			return syscall.Kill(pid(), syscall.SIGKILL)
+		},
+	}
+}
+
+// ShimTarget kills one random running containerd shim process found via
+// /proc.
+func ShimTarget() Target {
+	return Target{
+		Name: "shim",
+		Kill: func() error {
+			pids, err := findShimPids()
+			if err != nil || len(pids) == 0 {
+				return err
+			}
+			return syscall.Kill(pids[rand.Intn(len(pids))], syscall.SIGKILL)
+		},
+	}
+}
+
+// findShimPids lists processes whose command name looks like a containerd
+// shim.
+func findShimPids() ([]int, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+	var pids []int
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		comm, err := os.ReadFile(filepath.Join("/proc", e.Name(), "comm"))
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(string(comm)), "containerd-shim") {
+			pids = append(pids, pid)
+		}
+	}
+	return pids, nil
+}
+
+// Start begins killing random targets at random intervals until the
+// returned stop function is called. It is a no-op unless chaos mode is
+// enabled.
+func Start(t *testing.T, targets ...Target) (stop func()) {
+	if !Enabled() || len(targets) == 0 {
+		return func() {}
+	}
+	var (
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			// Kill something every 2-10 seconds.
+			wait := 2*time.Second + time.Duration(rand.Int63n(int64(8*time.Second)))
+			select {
+			case <-done:
+				return
+			case <-time.After(wait):
+			}
+			target := targets[rand.Intn(len(targets))]
+			t.Logf("chaos: killing %s", target.Name)
+			if err := target.Kill(); err != nil {
+				t.Logf("chaos: killing %s failed: %v", target.Name, err)
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"syscall"
+	"testing"
+	"time"
+
+	. "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/integration/chaos"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/errdefs"
+)
+
+// TestChaosShimCrashRecovery runs container lifecycles while the chaos
+// harness randomly kills shims, asserting that every lifecycle still
+// converges: tasks can be cleaned up and new containers keep starting.
+// The test only runs with CONTAINERD_CHAOS=1.
+func TestChaosShimCrashRecovery(t *testing.T) {
+	if !chaos.Enabled() {
+		t.Skip("set CONTAINERD_CHAOS=1 to run chaos tests")
+	}
+
+	client, err := newClient(t, address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, cancel := testContext(t)
+	defer cancel()
+
+	image, err := client.GetImage(ctx, testImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stop := chaos.Start(t, chaos.ShimTarget())
+	defer stop()
+
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("%s-%d", t.Name(), i)
+		container, err := client.NewContainer(ctx, id,
+			WithNewSnapshot(id, image),
+			WithNewSpec(oci.WithImageConfig(image), withProcessArgs("sleep", "2")),
+		)
+		if err != nil {
+			t.Fatalf("iteration %d: create: %v", i, err)
+		}
+
+		task, err := container.NewTask(ctx, empty())
+		if err != nil {
+			container.Delete(ctx, WithSnapshotCleanup)
+			t.Fatalf("iteration %d: new task: %v", i, err)
+		}
+		statusC, err := task.Wait(ctx)
+		if err == nil {
+			if err := task.Start(ctx); err == nil {
+				// The shim may be killed at any point; either a clean
+				// exit or an error delivered through the wait channel is
+				// acceptable, the invariant is that cleanup below works.
+				select {
+				case <-statusC:
+				case <-time.After(30 * time.Second):
+					task.Kill(ctx, syscall.SIGKILL)
+					<-statusC
+				}
+			}
+		}
+
+		if _, err := task.Delete(ctx, WithProcessKill); err != nil && !errdefs.IsNotFound(err) {
+			t.Fatalf("iteration %d: task delete did not converge: %v", i, err)
+		}
+		if err := container.Delete(ctx, WithSnapshotCleanup); err != nil && !errdefs.IsNotFound(err) {
+			t.Fatalf("iteration %d: container delete did not converge: %v", i, err)
+		}
+	}
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/containerd/containerd/api/types/runc/options"
 	. "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/integration/leakcheck"
 	"github.com/containerd/containerd/v2/pkg/cio"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/containerd/containerd/v2/pkg/shim"
@@ -246,11 +247,7 @@ func TestShimInCgroup(t *testing.T) {
 }
 
 func TestShimDoesNotLeakPipes(t *testing.T) {
-	containerdPid := ctrd.cmd.Process.Pid
-	initialPipes, err := numPipes(containerdPid)
-	if err != nil {
-		t.Fatal(err)
-	}
+	checkLeaks := leakcheck.Check(t, ctrd.cmd.Process.Pid)
 
 	client, err := newClient(t, address)
 	if err != nil {
@@ -303,14 +300,7 @@ func TestShimDoesNotLeakPipes(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	currentPipes, err := numPipes(containerdPid)
-	if err != nil {
-		t.Fatal(err)
-	}
-
-	if initialPipes != currentPipes {
-		t.Errorf("Pipes have leaked after container has been deleted. Initially there were %d pipes, after container deletion there were %d pipes", initialPipes, currentPipes)
-	}
+	checkLeaks()
 }
 
 func TestShimDoesNotLeakSockets(t *testing.T) {
@@ -26,13 +26,15 @@ import (
 	"strings"
 	"syscall"
 	"time"
+
+	"github.com/containerd/containerd/v2/integration/failpoint"
 )
 
 // issue9103KillInitAfterCreate kills the runc.Init process after creating
 // command returns successfully.
 //
 // REF: https://github.com/containerd/containerd/issues/9103
-func issue9103KillInitAfterCreate(ctx context.Context, method invoker) error {
+func issue9103KillInitAfterCreate(ctx context.Context, method failpoint.Invoker) error {
 	isCreated := strings.Contains(strings.Join(os.Args, ","), ",create,")
 
 	if err := method(ctx); err != nil {
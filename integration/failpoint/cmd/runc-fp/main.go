@@ -22,26 +22,15 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
-	"syscall"
 
+	"github.com/containerd/containerd/v2/integration/failpoint"
 	"github.com/containerd/containerd/v2/pkg/oci"
 	"github.com/sirupsen/logrus"
 )
 
-const (
-	failpointProfileKey = "oci.runc.failpoint.profile"
-)
-
-type invoker func(context.Context) error
-
-type invokerInterceptor func(context.Context, invoker) error
-
-var (
-	failpointProfiles = map[string]invokerInterceptor{
-		"issue9103": issue9103KillInitAfterCreate,
-	}
-)
+func init() {
+	failpoint.RegisterProfile("issue9103", issue9103KillInitAfterCreate)
+}
 
 // setupLog setups messages into log file.
 func setupLog() {
@@ -76,33 +65,21 @@ func main() {
 	}
 
 	ctx := context.Background()
-	if err := fpProfile(ctx, defaultRuncInvoker); err != nil {
+	if err := fpProfile(ctx, failpoint.RuncInvoker); err != nil {
 		logrus.WithError(err).Fatal("failed to exec failpoint profile")
 	}
 }
 
-// defaultRuncInvoker is to call the runc command with same arguments.
-func defaultRuncInvoker(ctx context.Context) error {
-	cmd := exec.CommandContext(ctx, "runc", os.Args[1:]...)
-	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
-	return cmd.Run()
-}
-
 // failpointProfileFromOCIAnnotation gets the profile from OCI annotations.
-func failpointProfileFromOCIAnnotation() (invokerInterceptor, error) {
+func failpointProfileFromOCIAnnotation() (failpoint.InvokerInterceptor, error) {
 	spec, err := oci.ReadSpec(oci.ConfigFilename)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read %s: %w", oci.ConfigFilename, err)
 	}
 
-	profileName, ok := spec.Annotations[failpointProfileKey]
+	profileName, ok := spec.Annotations[failpoint.ProfileAnnotation]
 	if !ok {
 		return nil, fmt.Errorf("failpoint profile is required")
 	}
-
-	fp, ok := failpointProfiles[profileName]
-	if !ok {
-		return nil, fmt.Errorf("no such failpoint profile %s", profileName)
-	}
-	return fp, nil
+	return failpoint.GetProfile(profileName)
 }
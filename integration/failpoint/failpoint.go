@@ -0,0 +1,172 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package failpoint provides a failpoint injection framework for
+// integration tests. Failpoints are selected per container through OCI
+// annotations and applied by wrapper binaries such as runc-fp and
+// containerd-shim-runc-fp-v1 built from ./cmd.
+//
+// A failpoint profile is either registered by name with RegisterProfile,
+// or described declaratively in the annotation value:
+//
+//	fail:<command>[:<message>]        fail the given runc subcommand
+//	hang:<command>:<duration>         hang for the duration before the subcommand runs
+//	partial-write:<command>:<bytes>   truncate the subcommand's stdout after N bytes
+//
+// For example:
+//
+//	oci.WithAnnotations(map[string]string{
+//		failpoint.ProfileAnnotation: "hang:start:10s",
+//	})
+//
+// Shim-side task API failpoints use the fail(9)-style syntax from
+// internal/failpoint under the ShimAnnotationPrefix annotations instead.
+package failpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ProfileAnnotation is the OCI annotation selecting the failpoint
+	// profile applied by the runc wrapper binary.
+	ProfileAnnotation = "oci.runc.failpoint.profile"
+
+	// ShimAnnotationPrefix is the OCI annotation prefix controlling task
+	// API failpoints in the failpoint shim. The suffix is the task API
+	// method name and the value uses the internal/failpoint syntax, e.g.
+	//
+	//	io.containerd.runtime.v2.shim.failpoint.Create = 1*off->1*error(please retry)
+	ShimAnnotationPrefix = "io.containerd.runtime.v2.shim.failpoint."
+)
+
+// Invoker runs the wrapped runtime command.
+type Invoker func(context.Context) error
+
+// InvokerInterceptor wraps an Invoker to inject a failure around it.
+type InvokerInterceptor func(context.Context, Invoker) error
+
+var (
+	profilesMu sync.Mutex
+	profiles   = map[string]InvokerInterceptor{}
+
+	// parsers maps declarative profile kinds to their constructors. The
+	// platform specific kinds register themselves in init functions.
+	parsers = map[string]func(args []string) (InvokerInterceptor, error){
+		"fail": newFailProfile,
+		"hang": newHangProfile,
+	}
+)
+
+// RegisterProfile registers a named failpoint profile. Profiles must be
+// registered before the wrapper binary reads the annotation, typically
+// from an init function.
+func RegisterProfile(name string, fp InvokerInterceptor) {
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	if _, ok := profiles[name]; ok {
+		panic(fmt.Sprintf("failpoint profile %q already registered", name))
+	}
+	profiles[name] = fp
+}
+
+// GetProfile resolves a profile annotation value into an interceptor,
+// looking up registered profiles first and falling back to the
+// declarative syntax.
+func GetProfile(value string) (InvokerInterceptor, error) {
+	profilesMu.Lock()
+	fp, ok := profiles[value]
+	profilesMu.Unlock()
+	if ok {
+		return fp, nil
+	}
+
+	args := strings.Split(value, ":")
+	parser, ok := parsers[args[0]]
+	if !ok {
+		return nil, fmt.Errorf("no such failpoint profile %s", value)
+	}
+	fp, err := parser(args[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid failpoint profile %q: %w", value, err)
+	}
+	return fp, nil
+}
+
+// MatchCommand reports whether the wrapped invocation args contain the
+// given runtime subcommand, e.g. "create" or "update".
+func MatchCommand(args []string, command string) bool {
+	for _, a := range args {
+		if a == command {
+			return true
+		}
+	}
+	return false
+}
+
+// FailOnCommand fails the given runtime subcommand with the message
+// without invoking it. Other subcommands run unchanged.
+func FailOnCommand(command, message string) InvokerInterceptor {
+	return func(ctx context.Context, method Invoker) error {
+		if !MatchCommand(os.Args[1:], command) {
+			return method(ctx)
+		}
+		return errors.New(message)
+	}
+}
+
+// HangOnCommand delays the given runtime subcommand for the duration
+// before invoking it. Other subcommands run unchanged.
+func HangOnCommand(command string, d time.Duration) InvokerInterceptor {
+	return func(ctx context.Context, method Invoker) error {
+		if MatchCommand(os.Args[1:], command) {
+			select {
+			case <-time.After(d):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		return method(ctx)
+	}
+}
+
+func newFailProfile(args []string) (InvokerInterceptor, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return nil, errors.New("expected fail:<command>[:<message>]")
+	}
+	message := fmt.Sprintf("failpoint injected for %s", args[0])
+	if len(args) == 2 {
+		message = args[1]
+	}
+	return FailOnCommand(args[0], message), nil
+}
+
+func newHangProfile(args []string) (InvokerInterceptor, error) {
+	if len(args) != 2 {
+		return nil, errors.New("expected hang:<command>:<duration>")
+	}
+	d, err := time.ParseDuration(args[1])
+	if err != nil {
+		return nil, err
+	}
+	return HangOnCommand(args[0], d), nil
+}
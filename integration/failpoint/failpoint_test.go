@@ -0,0 +1,65 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package failpoint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetProfileRegistered(t *testing.T) {
+	called := false
+	RegisterProfile("test-registered", func(ctx context.Context, method Invoker) error {
+		called = true
+		return method(ctx)
+	})
+
+	fp, err := GetProfile("test-registered")
+	require.NoError(t, err)
+	require.NoError(t, fp(context.Background(), func(context.Context) error { return nil }))
+	assert.True(t, called)
+}
+
+func TestGetProfileDeclarative(t *testing.T) {
+	for _, value := range []string{
+		"fail:update",
+		"fail:update:injected error",
+		"hang:start:10s",
+	} {
+		_, err := GetProfile(value)
+		assert.NoError(t, err, value)
+	}
+
+	for _, value := range []string{
+		"no-such-profile",
+		"fail",
+		"hang:start",
+		"hang:start:not-a-duration",
+	} {
+		_, err := GetProfile(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestMatchCommand(t *testing.T) {
+	args := []string{"--root", "/run/containerd/runc/default", "--log", "/log.json", "create", "--bundle", "/bundle"}
+	assert.True(t, MatchCommand(args, "create"))
+	assert.False(t, MatchCommand(args, "update"))
+}
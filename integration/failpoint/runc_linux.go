@@ -0,0 +1,82 @@
+//go:build linux
+
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package failpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+func init() {
+	parsers["partial-write"] = newPartialWriteProfile
+}
+
+// RuncInvoker calls the real runc command with the same arguments the
+// wrapper binary was invoked with.
+func RuncInvoker(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, "runc", os.Args[1:]...)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+	return cmd.Run()
+}
+
+// PartialWriteOnCommand truncates the stdout of the given runc subcommand
+// after n bytes, emulating a torn IO stream towards the caller. Other
+// subcommands run unchanged.
+func PartialWriteOnCommand(command string, n int64) InvokerInterceptor {
+	return func(ctx context.Context, method Invoker) error {
+		if !MatchCommand(os.Args[1:], command) {
+			return method(ctx)
+		}
+
+		cmd := exec.CommandContext(ctx, "runc", os.Args[1:]...)
+		cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+		cmd.Stdin = os.Stdin
+		cmd.Stderr = os.Stderr
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return err
+		}
+		if err := cmd.Start(); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(os.Stdout, stdout, n); err != nil && err != io.EOF {
+			cmd.Wait()
+			return err
+		}
+		// Drain the remainder so the command is not blocked on a full pipe.
+		io.Copy(io.Discard, stdout)
+		return cmd.Wait()
+	}
+}
+
+func newPartialWriteProfile(args []string) (InvokerInterceptor, error) {
+	if len(args) != 2 {
+		return nil, errors.New("expected partial-write:<command>:<bytes>")
+	}
+	n, err := strconv.ParseInt(args[1], 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return PartialWriteOnCommand(args[0], n), nil
+}
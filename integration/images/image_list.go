@@ -20,6 +20,7 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"runtime"
 	"sync"
 
 	"github.com/containerd/log"
@@ -38,6 +39,14 @@ type ImageList struct {
 	VolumeOwnership  string
 	ArgsEscaped      string
 	DockerSchema1    string
+	// Arch maps an architecture (runtime.GOARCH values such as "arm64" or
+	// "ppc64le") to image references overriding the defaults above, so one
+	// list file can serve hosts of every architecture:
+	//
+	//	alpine = "docker.io/library/alpine:latest"
+	//	[arch.arm64]
+	//	alpine = "example.com/arm64/alpine:latest"
+	Arch map[string]ImageList
 }
 
 var (
@@ -71,6 +80,7 @@ func initImages(imageListFile string) {
 		if err != nil {
 			panic(fmt.Errorf("error unmarshalling '%v' TOML file: %v", imageList, err))
 		}
+		imageList = imageList.resolveArch(runtime.GOARCH)
 	}
 
 	log.L.Infof("Using the following image list: %+v", imageList)
@@ -111,6 +121,40 @@ func initImageMap(imageList ImageList) map[int]string {
 	return images
 }
 
+// resolveArch overlays the non-empty entries for the architecture over the
+// top level references.
+func (l ImageList) resolveArch(arch string) ImageList {
+	override, ok := l.Arch[arch]
+	if !ok {
+		return l
+	}
+	if override.Alpine != "" {
+		l.Alpine = override.Alpine
+	}
+	if override.BusyBox != "" {
+		l.BusyBox = override.BusyBox
+	}
+	if override.Pause != "" {
+		l.Pause = override.Pause
+	}
+	if override.ResourceConsumer != "" {
+		l.ResourceConsumer = override.ResourceConsumer
+	}
+	if override.VolumeCopyUp != "" {
+		l.VolumeCopyUp = override.VolumeCopyUp
+	}
+	if override.VolumeOwnership != "" {
+		l.VolumeOwnership = override.VolumeOwnership
+	}
+	if override.ArgsEscaped != "" {
+		l.ArgsEscaped = override.ArgsEscaped
+	}
+	if override.DockerSchema1 != "" {
+		l.DockerSchema1 = override.DockerSchema1
+	}
+	return l
+}
+
 // Get returns the fully qualified URI to an image (including version)
 func Get(image int) string {
 	initOnce.Do(func() {
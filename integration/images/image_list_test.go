@@ -0,0 +1,50 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveArch(t *testing.T) {
+	list := ImageList{
+		Alpine:  "docker.io/library/alpine:latest",
+		BusyBox: "docker.io/library/busybox:latest",
+		Arch: map[string]ImageList{
+			"arm64": {
+				Alpine: "example.com/arm64/alpine:latest",
+			},
+		},
+	}
+
+	resolved := list.resolveArch("arm64")
+	assert.Equal(t, "example.com/arm64/alpine:latest", resolved.Alpine)
+	// Entries without an override keep the top level reference.
+	assert.Equal(t, "docker.io/library/busybox:latest", resolved.BusyBox)
+
+	// Architectures without overrides resolve to the top level references.
+	resolved = list.resolveArch("ppc64le")
+	assert.Equal(t, "docker.io/library/alpine:latest", resolved.Alpine)
+}
+
+func TestLocalize(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:5000/containerd/busybox:1.36", Localize("ghcr.io/containerd/busybox:1.36"))
+	assert.Equal(t, "127.0.0.1:5000/pause:3.10", Localize("registry.k8s.io/pause:3.10"))
+	assert.Equal(t, "127.0.0.1:5000/busybox", Localize("busybox"))
+}
@@ -0,0 +1,82 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+)
+
+// LocalRegistry is the address of the local registry used by integration
+// tests.
+const LocalRegistry = "127.0.0.1:5000"
+
+// Localize rewrites an upstream image reference to point at the local test
+// registry, e.g. "ghcr.io/containerd/busybox:1.36" becomes
+// "127.0.0.1:5000/containerd/busybox:1.36".
+func Localize(ref string) string {
+	if _, remainder, ok := strings.Cut(ref, "/"); ok {
+		return LocalRegistry + "/" + remainder
+	}
+	return LocalRegistry + "/" + ref
+}
+
+// SeedLocalRegistry copies every image on the list from its upstream
+// registry into the local test registry, so tests referencing the local
+// registry run on any host architecture without hand-edited image lists.
+// Images that cannot be pulled for the host platform are skipped with a
+// warning since not every upstream image is multi-arch.
+func SeedLocalRegistry(ctx context.Context, client *containerd.Client) error {
+	initOnce.Do(func() {
+		initImages(*imageListFile)
+	})
+
+	resolver := docker.NewResolver(docker.ResolverOptions{
+		Hosts: docker.ConfigureDefaultRegistries(docker.WithPlainHTTP(docker.MatchLocalhost)),
+	})
+
+	for _, ref := range imageMap {
+		if ref == "" || strings.HasPrefix(ref, LocalRegistry) {
+			continue
+		}
+		localRef := Localize(ref)
+
+		img, err := client.Pull(ctx, ref, containerd.WithPullUnpack)
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("skipping local registry seed of %q", ref)
+			continue
+		}
+
+		tagged := img.Metadata()
+		tagged.Name = localRef
+		if _, err := client.ImageService().Create(ctx, tagged); err != nil && !errdefs.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to tag %q as %q: %w", ref, localRef, err)
+		}
+		if err := client.Push(ctx, localRef, img.Target(), containerd.WithResolver(resolver)); err != nil {
+			return fmt.Errorf("failed to push %q to local registry: %w", localRef, err)
+		}
+		log.G(ctx).Infof("seeded local registry with %q", localRef)
+	}
+	return nil
+}
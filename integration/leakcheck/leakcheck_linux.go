@@ -0,0 +1,136 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package leakcheck helps integration tests detect file descriptor, pipe
+// and goroutine leaks across task lifecycle operations. A test snapshots
+// the daemon (or shim) process before exercising a lifecycle and fails
+// when descriptor counts have grown afterwards:
+//
+//	check := leakcheck.Check(t, containerdPid)
+//	... run container lifecycle ...
+//	check()
+//
+// Counts are read from /proc/<pid>/fd, so the helper works for any local
+// process without external tools such as lsof.
+package leakcheck
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Snapshot holds descriptor counts for a process at a point in time.
+type Snapshot struct {
+	// PID of the inspected process.
+	PID int
+	// FDs is the total number of open file descriptors.
+	FDs int
+	// Pipes is the number of descriptors referring to pipes and FIFOs.
+	Pipes int
+	// Sockets is the number of descriptors referring to sockets.
+	Sockets int
+	// Goroutines is the goroutine count of the calling test process, not
+	// of PID.
+	Goroutines int
+}
+
+// Take returns a snapshot of the descriptor counts of the process.
+func Take(pid int) (Snapshot, error) {
+	s := Snapshot{
+		PID:        pid,
+		Goroutines: runtime.NumGoroutine(),
+	}
+	dir := fmt.Sprintf("/proc/%d/fd", pid)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return s, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	for _, e := range entries {
+		target, err := os.Readlink(filepath.Join(dir, e.Name()))
+		if err != nil {
+			// The descriptor may have been closed while walking.
+			continue
+		}
+		s.FDs++
+		switch {
+		case strings.HasPrefix(target, "pipe:"):
+			s.Pipes++
+		case strings.HasPrefix(target, "socket:"):
+			s.Sockets++
+		}
+	}
+	return s, nil
+}
+
+// Check snapshots the process and returns a function failing the test when
+// pipe or socket counts have grown since the snapshot. Since shim cleanup
+// is asynchronous the comparison is retried for a short grace period
+// before reporting a leak.
+func Check(t *testing.T, pid int) func() {
+	t.Helper()
+	before, err := Take(pid)
+	if err != nil {
+		t.Fatalf("failed to snapshot process %d: %v", pid, err)
+	}
+	return func() {
+		t.Helper()
+		var (
+			after Snapshot
+			err   error
+		)
+		for i := 0; i < 25; i++ {
+			after, err = Take(pid)
+			if err == nil && after.Pipes <= before.Pipes && after.Sockets <= before.Sockets {
+				return
+			}
+			time.Sleep(200 * time.Millisecond)
+		}
+		if err != nil {
+			t.Errorf("failed to snapshot process %d: %v", pid, err)
+			return
+		}
+		if after.Pipes > before.Pipes {
+			t.Errorf("process %d leaked pipes: %d before, %d after", pid, before.Pipes, after.Pipes)
+		}
+		if after.Sockets > before.Sockets {
+			t.Errorf("process %d leaked sockets: %d before, %d after", pid, before.Sockets, after.Sockets)
+		}
+	}
+}
+
+// CheckGoroutines snapshots the goroutine count of the test process and
+// returns a function failing the test when the count has grown, for use
+// in client-side lifecycle tests.
+func CheckGoroutines(t *testing.T) func() {
+	t.Helper()
+	before := runtime.NumGoroutine()
+	return func() {
+		t.Helper()
+		var after int
+		for i := 0; i < 25; i++ {
+			if after = runtime.NumGoroutine(); after <= before {
+				return
+			}
+			time.Sleep(200 * time.Millisecond)
+		}
+		t.Errorf("test process leaked goroutines: %d before, %d after", before, after)
+	}
+}
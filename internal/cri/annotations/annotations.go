@@ -130,3 +130,27 @@ func DefaultCRIAnnotations(
 	}
 	return append(opts, customopts.WithAnnotation(ContainerType, ctrType))
 }
+
+// PodTimezone is the pod annotation overriding the node level timezone
+// configuration for all containers of the pod. The value is a zoneinfo
+// name such as "Europe/Berlin", or "host" for the host's localtime.
+const PodTimezone = "io.containerd.cri/timezone"
+
+// PodResolvConfOptions is the pod annotation appending extra resolv.conf
+// options (space separated, e.g. "ndots:2 edns0") to the generated
+// resolv.conf.
+const PodResolvConfOptions = "io.containerd.cri/resolv-conf-options"
+
+// PodResolvConfPolicy is the pod annotation controlling how the generated
+// resolv.conf relates to the host's: "replace" (default) uses only the
+// pod DNS config, "merge" prepends the host resolv.conf content.
+const PodResolvConfPolicy = "io.containerd.cri/resolv-conf-policy"
+
+// CPUSetCPUs is the annotation pinning the container (or every container
+// of the pod when set on the sandbox) to the given cpuset, carrying the
+// topology manager's CPU affinity hints into the OCI spec.
+const CPUSetCPUs = "io.containerd.cri/cpuset-cpus"
+
+// CPUSetMems is the annotation pinning the container's memory allocations
+// to the given NUMA nodes.
+const CPUSetMems = "io.containerd.cri/cpuset-mems"
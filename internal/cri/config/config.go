@@ -125,6 +125,16 @@ type Runtime struct {
 	// we can also set it to "streaming" to create a stream by streaming api,
 	// and use it as a channel to transfer the io stream
 	IOType string `toml:"io_type" json:"io_type"`
+	// SandboxImage overrides the pause image for sandboxes using this
+	// runtime. The reference may be pinned by digest
+	// (e.g. "registry.k8s.io/pause@sha256:..."), in which case the digest
+	// is verified before each sandbox start.
+	SandboxImage string `toml:"sandbox_image" json:"sandboxImage"`
+	// HostResolvConfPath overrides the host resolv.conf used for pods of
+	// this runtime when they do not carry their own DNS config, e.g.
+	// "/run/systemd/resolve/resolv.conf" on nodes where /etc/resolv.conf
+	// points at the systemd-resolved stub listener.
+	HostResolvConfPath string `toml:"host_resolv_conf_path" json:"hostResolvConfPath"`
 }
 
 // ContainerdConfig contains toml config related to containerd
@@ -269,6 +279,12 @@ type ImageConfig struct {
 	// Snapshotter is the snapshotter used by containerd.
 	Snapshotter string `toml:"snapshotter" json:"snapshotter"`
 
+	// DeferredImageUnpack makes PullImage store content only, deferring
+	// the unpack to the first container create using the image. This keeps
+	// large prefetch pulls from competing with pod-start unpacks for disk
+	// IO.
+	DeferredImageUnpack bool `toml:"deferred_image_unpack" json:"deferredImageUnpack"`
+
 	// DisableSnapshotAnnotations disables to pass additional annotations (image
 	// related information) to snapshotters. These annotations are required by
 	// stargz snapshotter (https://github.com/containerd/stargz-snapshotter).
@@ -406,6 +422,45 @@ type RuntimeConfig struct {
 	// For example, the value can be '5h', '2h30m', '10s'.
 	DrainExecSyncIOTimeout string `toml:"drain_exec_sync_io_timeout" json:"drainExecSyncIOTimeout"`
 
+	// MaxConcurrentExecs is the maximum number of concurrent exec sessions
+	// allowed per container. Additional exec requests are rejected with an
+	// unavailable error. A zero value means no limit is enforced.
+	MaxConcurrentExecs int `toml:"max_concurrent_execs" json:"maxConcurrentExecs"`
+
+	// ForbidUnconfinedSeccomp rejects containers requesting the
+	// "unconfined" seccomp profile instead of silently running them
+	// without filtering.
+	ForbidUnconfinedSeccomp bool `toml:"forbid_unconfined_seccomp" json:"forbidUnconfinedSeccomp"`
+
+	// PodAnnotationPassthrough is a global allow-list of pod annotation
+	// patterns copied into the OCI runtime spec annotations for every
+	// runtime handler, in addition to each runtime's pod_annotations
+	// list. Available to hooks, NRI and VM runtimes.
+	PodAnnotationPassthrough []string `toml:"pod_annotation_passthrough" json:"podAnnotationPassthrough"`
+
+	// ContainerAnnotationPassthrough is the container level counterpart
+	// of PodAnnotationPassthrough.
+	ContainerAnnotationPassthrough []string `toml:"container_annotation_passthrough" json:"containerAnnotationPassthrough"`
+
+	// Timezone, when set, mounts the corresponding zoneinfo entry at
+	// /etc/localtime in every linux container. The special value "host"
+	// mounts the host's own /etc/localtime. Pods can override it with the
+	// "io.containerd.cri/timezone" annotation.
+	Timezone string `toml:"timezone" json:"timezone"`
+
+	// SwapBehavior controls how container swap limits are applied on the
+	// node, matching kubelet's NodeSwap feature:
+	//  - "limited" (default) applies the swap limit requested through CRI
+	//  - "disabled" pins the swap limit to the memory limit so containers
+	//    cannot swap
+	//  - "unlimited" removes the swap limit entirely
+	SwapBehavior string `toml:"swap_behavior" json:"swapBehavior"`
+
+	// MaxExecOutputSize is the maximum number of bytes buffered for each of
+	// stdout and stderr of an ExecSync request before the output is
+	// truncated. A zero value means the default of 16MiB is used.
+	MaxExecOutputSize int64 `toml:"max_exec_output_size" json:"maxExecOutputSize"`
+
 	// IgnoreDeprecationWarnings is the list of the deprecation IDs (such as "io.containerd.deprecation/pull-schema-1-image")
 	// that should be ignored for checking "ContainerdHasNoDeprecationWarnings" condition.
 	IgnoreDeprecationWarnings []string `toml:"ignore_deprecation_warnings" json:"ignoreDeprecationWarnings"`
@@ -317,6 +317,45 @@ func WithDevices(osi osinterface.OS, config *runtime.ContainerConfig, enableDevi
 	}
 }
 
+// Swap behavior values matching kubelet's NodeSwap feature.
+const (
+	// SwapBehaviorLimited applies the swap limit requested through CRI.
+	SwapBehaviorLimited = "limited"
+	// SwapBehaviorDisabled pins the swap limit to the memory limit so the
+	// container cannot use swap.
+	SwapBehaviorDisabled = "disabled"
+	// SwapBehaviorUnlimited removes the swap limit entirely.
+	SwapBehaviorUnlimited = "unlimited"
+)
+
+// WithSwapBehavior adjusts the container's swap limit according to the
+// node-level swap behavior. It must be applied after WithResources.
+func WithSwapBehavior(resources *runtime.LinuxContainerResources, behavior string) oci.SpecOpts {
+	return func(ctx context.Context, client oci.Client, c *containers.Container, s *runtimespec.Spec) error {
+		if behavior == "" || behavior == SwapBehaviorLimited {
+			return nil
+		}
+		if resources == nil || !SwapControllerAvailable() {
+			return nil
+		}
+		if s.Linux == nil || s.Linux.Resources == nil || s.Linux.Resources.Memory == nil {
+			return nil
+		}
+		switch behavior {
+		case SwapBehaviorDisabled:
+			if s.Linux.Resources.Memory.Limit != nil {
+				s.Linux.Resources.Memory.Swap = s.Linux.Resources.Memory.Limit
+			}
+		case SwapBehaviorUnlimited:
+			unlimited := int64(-1)
+			s.Linux.Resources.Memory.Swap = &unlimited
+		default:
+			return fmt.Errorf("unknown swap behavior %q", behavior)
+		}
+		return nil
+	}
+}
+
 // WithResources sets the provided resource restrictions
 func WithResources(resources *runtime.LinuxContainerResources, tolerateMissingHugetlbController, disableHugetlbController bool) oci.SpecOpts {
 	return func(ctx context.Context, client oci.Client, c *containers.Container, s *runtimespec.Spec) (err error) {
@@ -162,6 +162,20 @@ func (c *criService) CreateContainer(ctx context.Context, r *runtime.CreateConta
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sandbox runtime: %w", err)
 	}
+
+	// Mount requested image volumes as read-only views and expose them as
+	// regular host path mounts.
+	imageVolumeMounts, err := c.setupImageVolumes(ctx, id, containerRootDir, c.RuntimeSnapshotter(ctx, ociRuntime), config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up image volumes: %w", err)
+	}
+	defer func() {
+		if retErr != nil {
+			c.cleanupImageVolumes(ctx, id, containerRootDir, c.RuntimeSnapshotter(ctx, ociRuntime))
+		}
+	}()
+	volumeMounts = append(volumeMounts, imageVolumeMounts...)
+
 	var runtimeHandler *runtime.RuntimeHandler
 	for _, f := range c.runtimeHandlers {
 		f := f
@@ -606,6 +620,25 @@ func (c *criService) buildContainerSpec(
 	return c.runtimeSpec(id, platform, ociRuntime.BaseRuntimeSpec, specOpts...)
 }
 
+// topologyHint returns the container annotation value, falling back to
+// the pod level annotation.
+func topologyHint(config *runtime.ContainerConfig, sandboxConfig *runtime.PodSandboxConfig, key string) string {
+	if v, ok := config.GetAnnotations()[key]; ok {
+		return v
+	}
+	return sandboxConfig.GetAnnotations()[key]
+}
+
+// containerTimezone returns the timezone mounted into containers of the
+// sandbox: the pod annotation when present, otherwise the node level
+// configuration. Empty disables timezone injection.
+func (c *criService) containerTimezone(sandboxConfig *runtime.PodSandboxConfig) string {
+	if tz, ok := sandboxConfig.GetAnnotations()[annotations.PodTimezone]; ok {
+		return tz
+	}
+	return c.config.Timezone
+}
+
 func (c *criService) buildLinuxSpec(
 	id string,
 	sandboxID string,
@@ -747,10 +780,26 @@ func (c *criService) buildLinuxSpec(
 		specOpts = append(specOpts, oci.WithRootFSReadonly())
 	}
 
+	if tz := c.containerTimezone(sandboxConfig); tz != "" {
+		specOpts = append(specOpts, oci.WithTimezone(tz))
+	}
+
+	// Apply topology manager CPU/NUMA hints from container or pod
+	// annotations onto the cpuset, for NUMA-aware runtimes.
+	if cpus := topologyHint(config, sandboxConfig, annotations.CPUSetCPUs); cpus != "" {
+		specOpts = append(specOpts, oci.WithCPUs(cpus))
+	}
+	if mems := topologyHint(config, sandboxConfig, annotations.CPUSetMems); mems != "" {
+		specOpts = append(specOpts, oci.WithCPUsMems(mems))
+	}
+
 	if c.config.DisableCgroup {
 		specOpts = append(specOpts, customopts.WithDisabledCgroups)
 	} else {
-		specOpts = append(specOpts, customopts.WithResources(config.GetLinux().GetResources(), c.config.TolerateMissingHugetlbController, c.config.DisableHugetlbController))
+		specOpts = append(specOpts,
+			customopts.WithResources(config.GetLinux().GetResources(), c.config.TolerateMissingHugetlbController, c.config.DisableHugetlbController),
+			customopts.WithSwapBehavior(config.GetLinux().GetResources(), c.config.SwapBehavior),
+		)
 		if sandboxConfig.GetLinux().GetCgroupParent() != "" {
 			cgroupsPath := getCgroupsPath(sandboxConfig.GetLinux().GetCgroupParent(), id)
 			specOpts = append(specOpts, oci.WithCgroup(cgroupsPath))
@@ -782,12 +831,12 @@ func (c *criService) buildLinuxSpec(
 	}
 
 	for pKey, pValue := range getPassthroughAnnotations(sandboxConfig.Annotations,
-		ociRuntime.PodAnnotations) {
+		append(ociRuntime.PodAnnotations, c.config.PodAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
 	for pKey, pValue := range getPassthroughAnnotations(config.Annotations,
-		ociRuntime.ContainerAnnotations) {
+		append(ociRuntime.ContainerAnnotations, c.config.ContainerAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
@@ -917,12 +966,12 @@ func (c *criService) buildWindowsSpec(
 	specOpts = append(specOpts, oci.WithUser(username))
 
 	for pKey, pValue := range getPassthroughAnnotations(sandboxConfig.Annotations,
-		ociRuntime.PodAnnotations) {
+		append(ociRuntime.PodAnnotations, c.config.PodAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
 	for pKey, pValue := range getPassthroughAnnotations(config.Annotations,
-		ociRuntime.ContainerAnnotations) {
+		append(ociRuntime.ContainerAnnotations, c.config.ContainerAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
@@ -969,12 +1018,12 @@ func (c *criService) buildDarwinSpec(
 	specOpts = append(specOpts, customopts.WithDarwinMounts(c.os, config, extraMounts))
 
 	for pKey, pValue := range getPassthroughAnnotations(sandboxConfig.Annotations,
-		ociRuntime.PodAnnotations) {
+		append(ociRuntime.PodAnnotations, c.config.PodAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
 	for pKey, pValue := range getPassthroughAnnotations(config.Annotations,
-		ociRuntime.ContainerAnnotations) {
+		append(ociRuntime.ContainerAnnotations, c.config.ContainerAnnotationPassthrough...)) {
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
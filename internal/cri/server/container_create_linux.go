@@ -110,6 +110,10 @@ func (c *criService) containerSpecOpts(config *runtime.ContainerConfig, imageCon
 			return nil, fmt.Errorf("failed to generate seccomp spec opts: %w", err)
 		}
 	}
+	if c.config.ForbidUnconfinedSeccomp && !securityContext.GetPrivileged() &&
+		ssp.GetProfileType() == runtime.SecurityProfile_Unconfined {
+		return nil, errors.New("unconfined seccomp profiles are forbidden by the node configuration")
+	}
 	seccompSpecOpts, err := c.generateSeccompSpecOpts(
 		ssp,
 		securityContext.GetPrivileged(),
@@ -120,6 +124,11 @@ func (c *criService) containerSpecOpts(config *runtime.ContainerConfig, imageCon
 	if seccompSpecOpts != nil {
 		specOpts = append(specOpts, seccompSpecOpts)
 	}
+	// Record which seccomp profile was applied, both for the metrics
+	// aggregate and as a container annotation surfaced through status.
+	seccompKind := appliedSeccompKind(ssp, securityContext.GetPrivileged(), c.seccompEnabled())
+	seccompProfileCount.WithValues(seccompKind).Inc()
+	specOpts = append(specOpts, customopts.WithAnnotation(appliedSeccompAnnotation, seccompKind))
 	if c.config.EnableCDI {
 		specOpts = append(specOpts, customopts.WithCDI(config.Annotations, config.CDIDevices))
 	}
@@ -164,6 +173,27 @@ func generateSecurityProfile(profilePath string) (*runtime.SecurityProfile, erro
 	}
 }
 
+// appliedSeccompAnnotation records the applied seccomp profile on the
+// container's OCI annotations.
+const appliedSeccompAnnotation = "io.containerd.cri/applied-seccomp-profile"
+
+// appliedSeccompKind describes which seccomp profile ends up applied:
+// "default", "unconfined", "localhost/<ref>", "privileged" or "disabled".
+func appliedSeccompKind(sp *runtime.SecurityProfile, privileged, seccompEnabled bool) string {
+	switch {
+	case privileged:
+		return "privileged"
+	case !seccompEnabled:
+		return "disabled"
+	case sp == nil || sp.GetProfileType() == runtime.SecurityProfile_Unconfined:
+		return "unconfined"
+	case sp.GetProfileType() == runtime.SecurityProfile_Localhost:
+		return "localhost/" + strings.TrimPrefix(sp.GetLocalhostRef(), profileNamePrefix)
+	default:
+		return "default"
+	}
+}
+
 // generateSeccompSpecOpts generates containerd SpecOpts for seccomp.
 func (c *criService) generateSeccompSpecOpts(sp *runtime.SecurityProfile, privileged, seccompEnabled bool) (oci.SpecOpts, error) {
 	if privileged {
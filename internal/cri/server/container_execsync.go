@@ -72,7 +72,10 @@ func (cw *cappedWriter) isFull() bool {
 // ExecSync executes a command in the container, and returns the stdout output.
 // If command exits with a non-zero exit code, an error is returned.
 func (c *criService) ExecSync(ctx context.Context, r *runtime.ExecSyncRequest) (*runtime.ExecSyncResponse, error) {
-	const maxStreamSize = 1024 * 1024 * 16
+	maxStreamSize := int64(1024 * 1024 * 16)
+	if c.config.MaxExecOutputSize > 0 {
+		maxStreamSize = c.config.MaxExecOutputSize
+	}
 
 	var stdout, stderr bytes.Buffer
 
@@ -80,8 +83,8 @@ func (c *criService) ExecSync(ctx context.Context, r *runtime.ExecSyncRequest) (
 	// the ExecSyncResponse will hit the CRI plugin's gRPC response limit.
 	// Thus the callers outside of the containerd process (e.g. Kubelet) never see
 	// the truncated output.
-	cout := &cappedWriter{w: cioutil.NewNopWriteCloser(&stdout), remain: maxStreamSize}
-	cerr := &cappedWriter{w: cioutil.NewNopWriteCloser(&stderr), remain: maxStreamSize}
+	cout := &cappedWriter{w: cioutil.NewNopWriteCloser(&stdout), remain: int(maxStreamSize)}
+	cerr := &cappedWriter{w: cioutil.NewNopWriteCloser(&stderr), remain: int(maxStreamSize)}
 
 	exitCode, err := c.execInContainer(ctx, r.GetContainerId(), execOptions{
 		cmd:     r.GetCmd(),
@@ -158,6 +161,10 @@ func (c *criService) execInternal(ctx context.Context, container containerd.Cont
 	}
 	execID := util.GenerateID()
 	log.G(ctx).Debugf("Generated exec id %q for container %q", execID, id)
+	if err := c.execSessions.add(id, execID, opts.cmd); err != nil {
+		return nil, fmt.Errorf("failed to register exec %q: %w", execID, err)
+	}
+	defer c.execSessions.remove(id, execID)
 	volatileRootDir := c.getVolatileContainerRootDir(id)
 	var execIO *cio.ExecIO
 
@@ -0,0 +1,134 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/opencontainers/image-spec/identity"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/containerd/containerd/v2/core/mount"
+)
+
+// imageVolumesAnnotation carries the image volume requests for a container
+// as a JSON array of imageVolume objects. The CRI Mount message in the
+// vendored cri-api does not carry an image source yet; once it does this
+// annotation is replaced by the Mount.Image field with the same backing
+// implementation.
+const imageVolumesAnnotation = "io.containerd.cri/image-volumes"
+
+// imageVolumeDir is the directory under the container root dir holding
+// the mounted image views.
+const imageVolumeDir = "image-volumes"
+
+// imageVolume describes a read-only mount of an OCI image's rootfs into a
+// container.
+type imageVolume struct {
+	// Image is the reference of the image to mount.
+	Image string `json:"image"`
+	// ContainerPath is the path the image rootfs is mounted at.
+	ContainerPath string `json:"containerPath"`
+}
+
+// imageVolumeSnapshotKey returns the snapshot key of the i-th image volume
+// of the container.
+func imageVolumeSnapshotKey(id string, i int) string {
+	return "imgvol-" + id + "-" + strconv.Itoa(i)
+}
+
+// setupImageVolumes mounts a read-only snapshotter view of each requested
+// image's rootfs under the container root directory and returns the
+// corresponding CRI mounts. The view snapshots keep the image's unpacked
+// chain referenced, protecting it from garbage collection while the
+// container exists; cleanupImageVolumes removes them with the container.
+func (c *criService) setupImageVolumes(ctx context.Context, id, containerRootDir, snapshotter string, config *runtime.ContainerConfig) ([]*runtime.Mount, error) {
+	value, ok := config.GetAnnotations()[imageVolumesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	var volumes []imageVolume
+	if err := json.Unmarshal([]byte(value), &volumes); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation: %w", imageVolumesAnnotation, err)
+	}
+
+	sn := c.client.SnapshotService(snapshotter)
+	var mounts []*runtime.Mount
+	for i, iv := range volumes {
+		image, err := c.LocalResolve(iv.Image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve image volume source %q: %w", iv.Image, err)
+		}
+		containerdImage, err := c.toContainerdImage(ctx, image)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image from containerd %q: %w", image.ID, err)
+		}
+		diffIDs, err := containerdImage.RootFS(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rootfs of image %q: %w", iv.Image, err)
+		}
+		parent := identity.ChainID(diffIDs).String()
+
+		viewMounts, err := sn.View(ctx, imageVolumeSnapshotKey(id, i), parent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create image volume view of %q: %w", iv.Image, err)
+		}
+		target := filepath.Join(containerRootDir, imageVolumeDir, strconv.Itoa(i))
+		if err := c.os.MkdirAll(target, 0755); err != nil {
+			return nil, err
+		}
+		if err := mount.All(viewMounts, target); err != nil {
+			return nil, fmt.Errorf("failed to mount image volume %q at %q: %w", iv.Image, target, err)
+		}
+		mounts = append(mounts, &runtime.Mount{
+			ContainerPath: iv.ContainerPath,
+			HostPath:      target,
+			Readonly:      true,
+		})
+	}
+	return mounts, nil
+}
+
+// cleanupImageVolumes unmounts the image volume views of the container and
+// removes their backing snapshots, releasing the reference on the image's
+// unpacked chain.
+func (c *criService) cleanupImageVolumes(ctx context.Context, id, containerRootDir, snapshotter string) {
+	sn := c.client.SnapshotService(snapshotter)
+	dir := filepath.Join(containerRootDir, imageVolumeDir)
+	for i := 0; ; i++ {
+		key := imageVolumeSnapshotKey(id, i)
+		if _, err := sn.Stat(ctx, key); err != nil {
+			if !errdefs.IsNotFound(err) {
+				log.G(ctx).WithError(err).Errorf("Failed to stat image volume snapshot %q", key)
+			}
+			return
+		}
+		target := filepath.Join(dir, strconv.Itoa(i))
+		if err := mount.UnmountAll(target, 0); err != nil {
+			log.G(ctx).WithError(err).Errorf("Failed to unmount image volume %q", target)
+		}
+		if err := sn.Remove(ctx, key); err != nil && !errdefs.IsNotFound(err) {
+			log.G(ctx).WithError(err).Errorf("Failed to remove image volume snapshot %q", key)
+		}
+	}
+}
@@ -36,7 +36,14 @@ func (c *criService) ListContainers(ctx context.Context, r *runtime.ListContaine
 		containers = append(containers, toCRIContainer(container))
 	}
 
+	fields := extractFieldSelection(r.GetFilter().GetLabelSelector())
+	pageSize, pageToken := extractPageSelection(r.GetFilter().GetLabelSelector())
 	containers = c.filterCRIContainers(containers, r.GetFilter())
+	containers = trimContainers(containers, fields)
+	containers, err := pageContainers(containers, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
 
 	containerListTimer.UpdateSince(start)
 	return &runtime.ListContainersResponse{Containers: containers}, nil
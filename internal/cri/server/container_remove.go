@@ -97,6 +97,12 @@ func (c *criService) RemoveContainer(ctx context.Context, r *runtime.RemoveConta
 	// kubelet implementation, we'll never start a container once we decide to remove it,
 	// so we don't need the "Dead" state for now.
 
+	// Unmount image volume views and drop their backing snapshots before
+	// the container and its root directory are removed.
+	if info, err := container.Container.Info(ctx); err == nil && info.Snapshotter != "" {
+		c.cleanupImageVolumes(ctx, id, c.getContainerRootDir(id), info.Snapshotter)
+	}
+
 	// Delete containerd container.
 	if err := container.Container.Delete(ctx, containerd.WithSnapshotCleanup); err != nil {
 		if !errdefs.IsNotFound(err) {
@@ -21,6 +21,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/containerd/errdefs"
@@ -128,6 +130,7 @@ func (c *criService) StartContainer(ctx context.Context, r *runtime.StartContain
 
 	task, err := container.NewTask(ctx, ioCreation, taskOpts...)
 	if err != nil {
+		c.recordStartFailure(ctx, cntr, meta.LogPath, err)
 		return nil, fmt.Errorf("failed to create containerd task: %w", err)
 	}
 	defer func() {
@@ -166,6 +169,7 @@ func (c *criService) StartContainer(ctx context.Context, r *runtime.StartContain
 
 	// Start containerd task.
 	if err := task.Start(ctx); err != nil {
+		c.recordStartFailure(ctx, cntr, meta.LogPath, err)
 		return nil, fmt.Errorf("failed to start containerd task %q: %w", id, err)
 	}
 
@@ -193,6 +197,48 @@ func (c *criService) StartContainer(ctx context.Context, r *runtime.StartContain
 	return &runtime.StartContainerResponse{}, nil
 }
 
+// startFailureLogTail reads the last bytes of the container's CRI log,
+// which holds any early runtime or init process output written before the
+// failure.
+func startFailureLogTail(logPath string) string {
+	const tailSize = 2048
+	f, err := os.Open(logPath)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil || fi.Size() == 0 {
+		return ""
+	}
+	offset := fi.Size() - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	buf := make([]byte, fi.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(buf))
+}
+
+// recordStartFailure stores the real failure cause, including the runtime
+// error and any captured early container output, in the container status
+// so ContainerStatus surfaces it instead of a generic start error.
+func (c *criService) recordStartFailure(ctx context.Context, cntr containerstore.Container, logPath string, startErr error) {
+	message := startErr.Error()
+	if tail := startFailureLogTail(logPath); tail != "" {
+		message = fmt.Sprintf("%s\ncontainer output: %s", message, tail)
+	}
+	if err := cntr.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+		status.Reason = errorStartReason
+		status.Message = message
+		return status, nil
+	}); err != nil {
+		log.G(ctx).WithError(err).Errorf("Failed to record start failure of container %q", cntr.ID)
+	}
+}
+
 // setContainerStarting sets the container into starting state. In starting state, the
 // container will not be removed or started again.
 func setContainerStarting(container containerstore.Container) error {
@@ -74,7 +74,7 @@ func (c *criService) ContainerStatus(ctx context.Context, r *runtime.ContainerSt
 		status.CreatedAt = info.CreatedAt.UnixNano()
 	}
 
-	info, err := toCRIContainerInfo(ctx, container, r.GetVerbose())
+	info, err := toCRIContainerInfo(ctx, container, c.execSessions.list(container.ID), r.GetVerbose())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get verbose container info: %w", err)
 	}
@@ -147,10 +147,16 @@ type ContainerInfo struct {
 	RuntimeOptions interface{}              `json:"runtimeOptions"`
 	Config         *runtime.ContainerConfig `json:"config"`
 	RuntimeSpec    *runtimespec.Spec        `json:"runtimeSpec"`
+	// ExecSessions is the list of live exec sessions in the container.
+	ExecSessions []execSession `json:"execSessions,omitempty"`
+	// CPUSetCPUs and CPUSetMems are the applied cpuset pinning, for
+	// verifying NUMA placement of latency-sensitive workloads.
+	CPUSetCPUs string `json:"cpusetCpus,omitempty"`
+	CPUSetMems string `json:"cpusetMems,omitempty"`
 }
 
 // toCRIContainerInfo converts internal container object information to CRI container status response info map.
-func toCRIContainerInfo(ctx context.Context, container containerstore.Container, verbose bool) (map[string]string, error) {
+func toCRIContainerInfo(ctx context.Context, container containerstore.Container, execSessions []execSession, verbose bool) (map[string]string, error) {
 	if !verbose {
 		return nil, nil
 	}
@@ -160,10 +166,11 @@ func toCRIContainerInfo(ctx context.Context, container containerstore.Container,
 
 	// TODO(random-liu): Change CRI status info to use array instead of map.
 	ci := &ContainerInfo{
-		SandboxID: container.SandboxID,
-		Pid:       status.Pid,
-		Removing:  status.Removing,
-		Config:    meta.Config,
+		SandboxID:    container.SandboxID,
+		Pid:          status.Pid,
+		Removing:     status.Removing,
+		Config:       meta.Config,
+		ExecSessions: execSessions,
 	}
 
 	var err error
@@ -171,6 +178,10 @@ func toCRIContainerInfo(ctx context.Context, container containerstore.Container,
 	if err != nil {
 		return nil, fmt.Errorf("failed to get container runtime spec: %w", err)
 	}
+	if spec := ci.RuntimeSpec; spec != nil && spec.Linux != nil && spec.Linux.Resources != nil && spec.Linux.Resources.CPU != nil {
+		ci.CPUSetCPUs = spec.Linux.Resources.CPU.Cpus
+		ci.CPUSetMems = spec.Linux.Resources.CPU.Mems
+	}
 
 	ctrInfo, err := container.Container.Info(ctx)
 	if err != nil {
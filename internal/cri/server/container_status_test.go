@@ -189,6 +189,7 @@ func TestToCRIContainerInfo(t *testing.T) {
 
 	info, err := toCRIContainerInfo(context.Background(),
 		container,
+		nil,
 		false)
 	assert.NoError(t, err)
 	assert.Nil(t, info)
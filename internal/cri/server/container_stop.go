@@ -64,6 +64,11 @@ func (c *criService) StopContainer(ctx context.Context, r *runtime.StopContainer
 
 	containerStopTimer.WithValues(i.Runtime.Name).UpdateSince(start)
 
+	// Emit the stopped event from the synchronous stop path as well, so
+	// evented PLEG consumers observe the transition even when the async
+	// TaskExit event is delayed or dropped.
+	c.generateAndSendContainerEvent(ctx, container.ID, container.SandboxID, runtime.ContainerEventType_CONTAINER_STOPPED_EVENT)
+
 	return &runtime.StopContainerResponse{}, nil
 }
 
@@ -43,6 +43,9 @@ func updateOCIResource(ctx context.Context, spec *runtimespec.Spec, r *runtime.U
 	if err := opts.WithResources(r.GetLinux(), config.TolerateMissingHugetlbController, config.DisableHugetlbController)(ctx, nil, nil, &cloned); err != nil {
 		return nil, fmt.Errorf("unable to set linux container resources: %w", err)
 	}
+	if err := opts.WithSwapBehavior(r.GetLinux(), config.SwapBehavior)(ctx, nil, nil, &cloned); err != nil {
+		return nil, fmt.Errorf("unable to apply swap behavior: %w", err)
+	}
 	return &cloned, nil
 }
 
@@ -0,0 +1,82 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containerd/errdefs"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// DrainServiceName is the full gRPC service name of the drain admin service
+// registered alongside the CRI runtime service. While draining, new
+// RunPodSandbox requests are rejected with an unavailable error but existing
+// pods keep running and can be stopped normally, so node maintenance tooling
+// can fence a node at the runtime level.
+const DrainServiceName = "containerd.services.cri.v1.Drain"
+
+// SetDraining puts the CRI plugin into or out of drain mode.
+func (c *criService) SetDraining(draining bool) {
+	c.draining.Store(draining)
+}
+
+// IsDraining returns whether the CRI plugin is in drain mode.
+func (c *criService) IsDraining() bool {
+	return c.draining.Load()
+}
+
+// errDraining returns the typed error returned for sandbox creation
+// requests while the plugin is draining.
+func errDraining() error {
+	return fmt.Errorf("cri plugin is in drain mode and does not accept new pod sandboxes: %w", errdefs.ErrUnavailable)
+}
+
+// RegisterDrainService registers the drain admin service onto the gRPC
+// server serving the CRI API. The service is hand-rolled on top of
+// well-known protobuf types so it does not require generated stubs.
+func (c *criService) RegisterDrainService(s *grpc.Server) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: DrainServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Set",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					req := &wrapperspb.BoolValue{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+					c.SetDraining(req.GetValue())
+					return &emptypb.Empty{}, nil
+				},
+			},
+			{
+				MethodName: "Status",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(&emptypb.Empty{}); err != nil {
+						return nil, err
+					}
+					return wrapperspb.Bool(c.IsDraining()), nil
+				},
+			},
+		},
+	}, c)
+}
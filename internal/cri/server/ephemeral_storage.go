@@ -0,0 +1,115 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/containerd/log"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	containerstore "github.com/containerd/containerd/v2/internal/cri/store/container"
+)
+
+// ephemeralStorageLimitAnnotation is the pod annotation carrying the
+// ephemeral storage limit in bytes enforced by containerd itself. The
+// combined writable layer and log usage of the pod's containers counts
+// against the limit, and exceeding it stops the pod's containers without
+// waiting for the kubelet's slow eviction polling.
+const ephemeralStorageLimitAnnotation = "io.containerd.cri/ephemeral-storage-limit-bytes"
+
+// ephemeralStorageCheckPeriod is how often pod usage is evaluated.
+const ephemeralStorageCheckPeriod = 30 * time.Second
+
+// ephemeralStorageExceededReason is the status reason recorded on
+// containers stopped by the enforcement.
+const ephemeralStorageExceededReason = "EphemeralStorageExceeded"
+
+// ephemeralStorageMonitor enforces pod ephemeral storage limits until the
+// context is cancelled.
+func (c *criService) ephemeralStorageMonitor(ctx context.Context) {
+	ticker := time.NewTicker(ephemeralStorageCheckPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.enforceEphemeralStorageLimits(ctx)
+		}
+	}
+}
+
+func (c *criService) enforceEphemeralStorageLimits(ctx context.Context) {
+	for _, sandbox := range c.sandboxStore.List() {
+		value, ok := sandbox.Config.GetAnnotations()[ephemeralStorageLimitAnnotation]
+		if !ok {
+			continue
+		}
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || limit <= 0 {
+			log.G(ctx).Warnf("invalid %s annotation %q on sandbox %q", ephemeralStorageLimitAnnotation, value, sandbox.ID)
+			continue
+		}
+
+		var (
+			usage      int64
+			containers []containerstore.Container
+		)
+		for _, cntr := range c.containerStore.List() {
+			if cntr.SandboxID != sandbox.ID {
+				continue
+			}
+			containers = append(containers, cntr)
+			if info, err := cntr.Container.Info(ctx); err == nil {
+				if sn, err := c.GetSnapshot(cntr.ID, info.Snapshotter); err == nil {
+					usage += int64(sn.Size)
+				}
+			}
+			if cntr.LogPath != "" {
+				if fi, err := os.Stat(cntr.LogPath); err == nil {
+					usage += fi.Size()
+				}
+			}
+		}
+
+		if usage <= limit {
+			continue
+		}
+		log.G(ctx).Warnf("pod %q exceeds its ephemeral storage limit (%d > %d bytes), stopping its containers", sandbox.ID, usage, limit)
+		for _, cntr := range containers {
+			if cntr.Status.Get().State() != runtime.ContainerState_CONTAINER_RUNNING {
+				continue
+			}
+			if err := c.stopContainer(ctx, cntr, 0); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to stop container %q over ephemeral storage", cntr.ID)
+				continue
+			}
+			if err := cntr.Status.UpdateSync(func(status containerstore.Status) (containerstore.Status, error) {
+				status.Reason = ephemeralStorageExceededReason
+				status.Message = "pod exceeded its ephemeral storage limit"
+				return status, nil
+			}); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to record eviction reason of %q", cntr.ID)
+			}
+			c.generateAndSendContainerEvent(ctx, cntr.ID, sandbox.ID, runtime.ContainerEventType_CONTAINER_STOPPED_EVENT)
+		}
+	}
+}
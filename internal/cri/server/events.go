@@ -242,6 +242,9 @@ func (c *criService) handleContainerExit(ctx context.Context, e *eventtypes.Task
 			status.Pid = 0
 			status.FinishedAt = protobuf.FromTimestamp(e.ExitedAt).UnixNano()
 			status.ExitCode = int32(e.ExitStatus)
+			if message := terminationMessage(cntr, status.ExitCode); message != "" {
+				status.Message = message
+			}
 		}
 
 		// Unknown state can only transit to EXITED state, so we need
@@ -0,0 +1,106 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/containerd/errdefs"
+)
+
+// execSession describes a live exec session within a container.
+type execSession struct {
+	// ID is the generated exec id.
+	ID string `json:"id"`
+	// Cmd is the command being executed.
+	Cmd []string `json:"cmd"`
+	// CreatedAt is the time the exec session was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// execSessionTracker tracks live exec sessions per container and enforces
+// the configured per-container concurrency limit.
+type execSessionTracker struct {
+	mu sync.Mutex
+	// sessions maps container id to the exec sessions keyed by exec id.
+	sessions map[string]map[string]execSession
+	// maxPerContainer is the maximum number of concurrent exec sessions
+	// per container. Zero means no limit.
+	maxPerContainer int
+}
+
+func newExecSessionTracker(maxPerContainer int) *execSessionTracker {
+	return &execSessionTracker{
+		sessions:        make(map[string]map[string]execSession),
+		maxPerContainer: maxPerContainer,
+	}
+}
+
+// add registers an exec session for the container, returning an
+// unavailable error when the configured concurrency limit has been reached.
+func (t *execSessionTracker) add(containerID, execID string, cmd []string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sessions := t.sessions[containerID]
+	if t.maxPerContainer > 0 && len(sessions) >= t.maxPerContainer {
+		return fmt.Errorf("container %q already has %d concurrent exec sessions, limit is %d: %w",
+			containerID, len(sessions), t.maxPerContainer, errdefs.ErrUnavailable)
+	}
+	if sessions == nil {
+		sessions = make(map[string]execSession)
+		t.sessions[containerID] = sessions
+	}
+	sessions[execID] = execSession{
+		ID:        execID,
+		Cmd:       cmd,
+		CreatedAt: time.Now(),
+	}
+	return nil
+}
+
+// remove deregisters an exec session for the container.
+func (t *execSessionTracker) remove(containerID, execID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sessions := t.sessions[containerID]
+	delete(sessions, execID)
+	if len(sessions) == 0 {
+		delete(t.sessions, containerID)
+	}
+}
+
+// list returns the live exec sessions for the container ordered by
+// creation time.
+func (t *execSessionTracker) list(containerID string) []execSession {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sessions := t.sessions[containerID]
+	if len(sessions) == 0 {
+		return nil
+	}
+	list := make([]execSession, 0, len(sessions))
+	for _, s := range sessions {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+	return list
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecSessionTrackerLimit(t *testing.T) {
+	tracker := newExecSessionTracker(2)
+	require.NoError(t, tracker.add("c1", "e1", []string{"sh"}))
+	require.NoError(t, tracker.add("c1", "e2", []string{"ls"}))
+
+	// Third session exceeds the limit.
+	err := tracker.add("c1", "e3", []string{"ps"})
+	assert.True(t, errdefs.IsUnavailable(err))
+
+	// Other containers are not affected by c1's sessions.
+	assert.NoError(t, tracker.add("c2", "e3", []string{"ps"}))
+
+	// Removing a session frees a slot.
+	tracker.remove("c1", "e1")
+	assert.NoError(t, tracker.add("c1", "e3", []string{"ps"}))
+}
+
+func TestExecSessionTrackerUnlimited(t *testing.T) {
+	tracker := newExecSessionTracker(0)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, tracker.add("c1", string(rune('a'+i)), []string{"sh"}))
+	}
+	assert.Len(t, tracker.list("c1"), 100)
+}
+
+func TestExecSessionTrackerList(t *testing.T) {
+	tracker := newExecSessionTracker(0)
+	assert.Nil(t, tracker.list("c1"))
+
+	require.NoError(t, tracker.add("c1", "e1", []string{"sh"}))
+	require.NoError(t, tracker.add("c1", "e2", []string{"ls", "-l"}))
+
+	sessions := tracker.list("c1")
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "e1", sessions[0].ID)
+	assert.Equal(t, []string{"sh"}, sessions[0].Cmd)
+	assert.Equal(t, "e2", sessions[1].ID)
+
+	tracker.remove("c1", "e1")
+	tracker.remove("c1", "e2")
+	assert.Nil(t, tracker.list("c1"))
+}
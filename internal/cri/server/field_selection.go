@@ -0,0 +1,197 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+
+	"github.com/containerd/containerd/v2/pkg/pagination"
+)
+
+// fieldsSelectorKey is a reserved label selector key requesting a trimmed
+// list response. Its value is a comma separated list of field names to
+// include besides the id, e.g. "state,metadata". The key is removed from
+// the selector before label matching, so it never matches real container
+// labels. Trimmed responses cut the serialization cost of multi-thousand
+// container list calls issued every relist period.
+const fieldsSelectorKey = "io.containerd.cri/fields"
+
+// Reserved label selector keys requesting a paginated list response,
+// served through pkg/pagination: the page size caps the number of items
+// returned, and the token from a previous response's trailing
+// page-token entry resumes the listing. Like fieldsSelectorKey, the keys
+// are removed from the selector before label matching. The containerd
+// API List endpoints gain first-class page fields once the api module is
+// regenerated; this serves the same helper through the CRI surface in
+// the meantime.
+const (
+	pageSizeSelectorKey  = "io.containerd.cri/page-size"
+	pageTokenSelectorKey = "io.containerd.cri/page-token"
+
+	// nextPageTokenID carries the next page token back to the caller as
+	// a trailing pseudo item id, since the CRI list responses have no
+	// token field.
+	nextPageTokenID = "io.containerd.cri/next-page-token."
+)
+
+// extractPageSelection pops the reserved pagination entries from the
+// label selector, returning the page size and token. A zero size
+// disables pagination.
+func extractPageSelection(labelSelector map[string]string) (int32, string) {
+	var (
+		size  int32
+		token string
+	)
+	if v, ok := labelSelector[pageSizeSelectorKey]; ok {
+		delete(labelSelector, pageSizeSelectorKey)
+		if n, err := strconv.ParseInt(v, 10, 32); err == nil && n > 0 {
+			size = int32(n)
+		}
+	}
+	if v, ok := labelSelector[pageTokenSelectorKey]; ok {
+		delete(labelSelector, pageTokenSelectorKey)
+		token = v
+	}
+	return size, token
+}
+
+// pageContainers slices the filtered containers into the requested page,
+// appending the next page token as a trailing pseudo item when more
+// remain.
+func pageContainers(containers []*runtime.Container, size int32, token string) ([]*runtime.Container, error) {
+	if size <= 0 {
+		return containers, nil
+	}
+	// The page token names a position in id order, so the listing has to
+	// be sorted for tokens to stay valid across calls.
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Id < containers[j].Id })
+	page, next, err := pagination.Page(containers, func(c *runtime.Container) string { return c.Id }, size, token)
+	if err != nil {
+		return nil, err
+	}
+	if next != "" {
+		// The page aliases the sorted slice; cap the append so the token
+		// item cannot overwrite the following element.
+		page = append(page[:len(page):len(page)], &runtime.Container{Id: nextPageTokenID + next})
+	}
+	return page, nil
+}
+
+// pageSandboxes is the sandbox counterpart of pageContainers.
+func pageSandboxes(sandboxes []*runtime.PodSandbox, size int32, token string) ([]*runtime.PodSandbox, error) {
+	if size <= 0 {
+		return sandboxes, nil
+	}
+	sort.Slice(sandboxes, func(i, j int) bool { return sandboxes[i].Id < sandboxes[j].Id })
+	page, next, err := pagination.Page(sandboxes, func(s *runtime.PodSandbox) string { return s.Id }, size, token)
+	if err != nil {
+		return nil, err
+	}
+	if next != "" {
+		page = append(page[:len(page):len(page)], &runtime.PodSandbox{Id: nextPageTokenID + next})
+	}
+	return page, nil
+}
+
+// extractFieldSelection pops the reserved fields entry from the label
+// selector and returns the requested field set, or nil when full
+// responses are requested.
+func extractFieldSelection(labelSelector map[string]string) map[string]bool {
+	value, ok := labelSelector[fieldsSelectorKey]
+	if !ok {
+		return nil
+	}
+	delete(labelSelector, fieldsSelectorKey)
+	fields := make(map[string]bool)
+	for _, f := range strings.Split(value, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			fields[f] = true
+		}
+	}
+	return fields
+}
+
+// trimContainers reduces each container to the id plus the requested
+// fields.
+func trimContainers(containers []*runtime.Container, fields map[string]bool) []*runtime.Container {
+	if fields == nil {
+		return containers
+	}
+	trimmed := make([]*runtime.Container, len(containers))
+	for i, c := range containers {
+		t := &runtime.Container{Id: c.Id}
+		if fields["state"] {
+			t.State = c.State
+		}
+		if fields["metadata"] {
+			t.Metadata = c.Metadata
+		}
+		if fields["image"] {
+			t.Image = c.Image
+			t.ImageRef = c.ImageRef
+		}
+		if fields["labels"] {
+			t.Labels = c.Labels
+		}
+		if fields["annotations"] {
+			t.Annotations = c.Annotations
+		}
+		if fields["createdAt"] {
+			t.CreatedAt = c.CreatedAt
+		}
+		if fields["podSandboxId"] {
+			t.PodSandboxId = c.PodSandboxId
+		}
+		trimmed[i] = t
+	}
+	return trimmed
+}
+
+// trimSandboxes reduces each sandbox to the id plus the requested fields.
+func trimSandboxes(sandboxes []*runtime.PodSandbox, fields map[string]bool) []*runtime.PodSandbox {
+	if fields == nil {
+		return sandboxes
+	}
+	trimmed := make([]*runtime.PodSandbox, len(sandboxes))
+	for i, s := range sandboxes {
+		t := &runtime.PodSandbox{Id: s.Id}
+		if fields["state"] {
+			t.State = s.State
+		}
+		if fields["metadata"] {
+			t.Metadata = s.Metadata
+		}
+		if fields["labels"] {
+			t.Labels = s.Labels
+		}
+		if fields["annotations"] {
+			t.Annotations = s.Annotations
+		}
+		if fields["createdAt"] {
+			t.CreatedAt = s.CreatedAt
+		}
+		if fields["runtimeHandler"] {
+			t.RuntimeHandler = s.RuntimeHandler
+		}
+		trimmed[i] = t
+	}
+	return trimmed
+}
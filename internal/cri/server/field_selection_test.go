@@ -0,0 +1,72 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestExtractPageSelection(t *testing.T) {
+	selector := map[string]string{
+		pageSizeSelectorKey:  "2",
+		pageTokenSelectorKey: "tok",
+		"app":                "db",
+	}
+	size, token := extractPageSelection(selector)
+	assert.Equal(t, int32(2), size)
+	assert.Equal(t, "tok", token)
+	// The reserved keys are removed before label matching.
+	assert.Equal(t, map[string]string{"app": "db"}, selector)
+
+	size, token = extractPageSelection(map[string]string{})
+	assert.Zero(t, size)
+	assert.Empty(t, token)
+}
+
+func TestPageContainers(t *testing.T) {
+	containers := []*runtime.Container{{Id: "c3"}, {Id: "c1"}, {Id: "c2"}}
+
+	// Zero size returns everything untouched.
+	page, err := pageContainers(containers, 0, "")
+	require.NoError(t, err)
+	assert.Len(t, page, 3)
+
+	// Walk through in pages of two, following the trailing token item.
+	var got []string
+	token := ""
+	for {
+		page, err := pageContainers(containers, 2, token)
+		require.NoError(t, err)
+		token = ""
+		for _, c := range page {
+			if strings.HasPrefix(c.Id, nextPageTokenID) {
+				token = strings.TrimPrefix(c.Id, nextPageTokenID)
+				continue
+			}
+			got = append(got, c.Id)
+		}
+		if token == "" {
+			break
+		}
+	}
+	assert.Equal(t, []string{"c1", "c2", "c3"}, got)
+}
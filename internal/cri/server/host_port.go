@@ -0,0 +1,138 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/containerd/errdefs"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type hostPortKey struct {
+	ip       string
+	protocol runtime.Protocol
+	port     int32
+}
+
+// hostPortManager tracks host ports claimed by sandboxes so conflicting
+// pods fail at creation with a typed error instead of the workload hitting
+// a confusing bind error later.
+type hostPortManager struct {
+	mu sync.Mutex
+	// claimed maps a host port to the sandbox id holding it.
+	claimed map[hostPortKey]string
+}
+
+func newHostPortManager() *hostPortManager {
+	return &hostPortManager{
+		claimed: make(map[hostPortKey]string),
+	}
+}
+
+// claim registers the host ports of the port mappings for the sandbox. It
+// returns an already exists error when a port is held by another sandbox,
+// and a failed precondition error when the port is bound by another
+// process on the host.
+func (m *hostPortManager) claim(sandboxID string, mappings []*runtime.PortMapping) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var claimed []hostPortKey
+	rollback := func() {
+		for _, key := range claimed {
+			delete(m.claimed, key)
+		}
+	}
+	for _, mapping := range mappings {
+		if mapping.GetHostPort() <= 0 {
+			continue
+		}
+		key := hostPortKey{
+			ip:       mapping.GetHostIp(),
+			protocol: mapping.GetProtocol(),
+			port:     mapping.GetHostPort(),
+		}
+		if holder, ok := m.claimed[key]; ok && holder != sandboxID {
+			rollback()
+			return fmt.Errorf("host port %d/%s is already claimed by sandbox %q: %w",
+				key.port, key.protocol, holder, errdefs.ErrAlreadyExists)
+		}
+		if err := probeHostPort(key); err != nil {
+			rollback()
+			return fmt.Errorf("host port %d/%s is in use on the host: %v: %w",
+				key.port, key.protocol, err, errdefs.ErrFailedPrecondition)
+		}
+		m.claimed[key] = sandboxID
+		claimed = append(claimed, key)
+	}
+	return nil
+}
+
+// restore re-registers the host ports of a sandbox recovered across a
+// daemon restart. The ports are in use by the still-running pod, so no
+// bind probe is performed and conflicting stale claims are overwritten.
+func (m *hostPortManager) restore(sandboxID string, mappings []*runtime.PortMapping) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, mapping := range mappings {
+		if mapping.GetHostPort() <= 0 {
+			continue
+		}
+		m.claimed[hostPortKey{
+			ip:       mapping.GetHostIp(),
+			protocol: mapping.GetProtocol(),
+			port:     mapping.GetHostPort(),
+		}] = sandboxID
+	}
+}
+
+// release frees every host port held by the sandbox.
+func (m *hostPortManager) release(sandboxID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, holder := range m.claimed {
+		if holder == sandboxID {
+			delete(m.claimed, key)
+		}
+	}
+}
+
+// probeHostPort checks whether the host port is currently bindable, which
+// catches listeners owned by processes outside of containerd.
+func probeHostPort(key hostPortKey) error {
+	addr := net.JoinHostPort(key.ip, strconv.Itoa(int(key.port)))
+	switch key.protocol {
+	case runtime.Protocol_UDP:
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	default:
+		// TCP and SCTP mappings are probed over TCP; there is no portable
+		// probe for SCTP and the port space is commonly shared.
+		l, err := net.Listen("tcp", addr)
+		if err != nil {
+			return err
+		}
+		return l.Close()
+	}
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+func TestHostPortClaimConflict(t *testing.T) {
+	// Grab a free port to use for the test mappings.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := int32(l.Addr().(*net.TCPAddr).Port)
+	require.NoError(t, l.Close())
+
+	m := newHostPortManager()
+	mappings := []*runtime.PortMapping{
+		{Protocol: runtime.Protocol_TCP, ContainerPort: 80, HostPort: port, HostIp: "127.0.0.1"},
+	}
+	require.NoError(t, m.claim("sandbox1", mappings))
+
+	// Another sandbox claiming the same host port fails with a typed error.
+	err = m.claim("sandbox2", mappings)
+	assert.True(t, errdefs.IsAlreadyExists(err))
+
+	// Releasing frees the port for other sandboxes.
+	m.release("sandbox1")
+	require.NoError(t, m.claim("sandbox2", mappings))
+	m.release("sandbox2")
+}
+
+func TestHostPortClaimBoundOnHost(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	port := int32(l.Addr().(*net.TCPAddr).Port)
+
+	m := newHostPortManager()
+	err = m.claim("sandbox1", []*runtime.PortMapping{
+		{Protocol: runtime.Protocol_TCP, ContainerPort: 80, HostPort: port, HostIp: "127.0.0.1"},
+	})
+	assert.True(t, errdefs.IsFailedPrecondition(err))
+
+	// Nothing should remain claimed after the failure.
+	assert.Empty(t, m.claimed)
+}
+
+func TestHostPortClaimIgnoresUnsetPorts(t *testing.T) {
+	m := newHostPortManager()
+	require.NoError(t, m.claim("sandbox1", []*runtime.PortMapping{
+		{Protocol: runtime.Protocol_TCP, ContainerPort: 80},
+	}))
+	assert.Empty(t, m.claimed)
+}
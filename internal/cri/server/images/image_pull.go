@@ -18,8 +18,10 @@ package images
 
 import (
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
@@ -117,7 +119,64 @@ func (c *GRPCCRIImageService) PullImage(ctx context.Context, r *runtime.PullImag
 	return &runtime.PullImageResponse{ImageRef: ref}, nil
 }
 
-func (c *CRIImageService) PullImage(ctx context.Context, name string, credentials func(string) (string, string, error), sandboxConfig *runtime.PodSandboxConfig, runtimeHandler string) (_ string, err error) {
+// pullCredentialIdentity derives an opaque identity for the credentials a
+// request presents, so concurrent pulls only coalesce when they would
+// authenticate identically and a follower's invalid or absent pull secret
+// is still rejected by the registry. The secret itself never becomes part
+// of the key.
+func pullCredentialIdentity(namedRef distribution.Named, credentials func(string) (string, string, error)) string {
+	if credentials == nil {
+		return "anonymous"
+	}
+	username, secret, err := credentials(distribution.Domain(namedRef))
+	if err != nil {
+		// Failing credential resolution must not share another pull's
+		// result; make the identity unique per error.
+		sum := sha256.Sum256([]byte("error\x00" + err.Error()))
+		return hex.EncodeToString(sum[:])
+	}
+	if username == "" && secret == "" {
+		return "anonymous"
+	}
+	sum := sha256.Sum256([]byte(username + "\x00" + secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// inflightPull tracks an in-flight pull other callers of the same image
+// reference wait on.
+type inflightPull struct {
+	done    chan struct{}
+	imageID string
+	err     error
+}
+
+// joinInflightPull registers interest in a pull keyed by reference and
+// credential identity. It reports whether the caller is the leader that
+// has to perform the pull; followers receive the tracker to wait on
+// instead.
+func (c *CRIImageService) joinInflightPull(key string) (*inflightPull, bool) {
+	c.inflightPullsMu.Lock()
+	defer c.inflightPullsMu.Unlock()
+	if inflight, ok := c.inflightPulls[key]; ok {
+		return inflight, false
+	}
+	inflight := &inflightPull{done: make(chan struct{})}
+	c.inflightPulls[key] = inflight
+	return inflight, true
+}
+
+// finishInflightPull publishes the pull result to the waiting followers.
+func (c *CRIImageService) finishInflightPull(key, imageID string, err error) {
+	c.inflightPullsMu.Lock()
+	inflight := c.inflightPulls[key]
+	delete(c.inflightPulls, key)
+	c.inflightPullsMu.Unlock()
+	inflight.imageID = imageID
+	inflight.err = err
+	close(inflight.done)
+}
+
+func (c *CRIImageService) PullImage(ctx context.Context, name string, credentials func(string) (string, string, error), sandboxConfig *runtime.PodSandboxConfig, runtimeHandler string) (imageID string, err error) {
 	span := tracing.SpanFromContext(ctx)
 	defer func() {
 		// TODO: add domain label for imagePulls metrics, and we may need to provide a mechanism
@@ -142,6 +201,25 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 		log.G(ctx).Debugf("PullImage using normalized image ref: %q", ref)
 	}
 
+	// Coalesce concurrent pulls of the same reference into a single pull:
+	// when many pods referencing the same image start at once, followers
+	// wait for the leader's pull instead of opening duplicate resolver
+	// sessions and contending on ingest. The key includes the credential
+	// identity so requests only share a result when they would
+	// authenticate the same way.
+	inflightKey := ref + "|" + pullCredentialIdentity(namedRef, credentials)
+	inflight, leader := c.joinInflightPull(inflightKey)
+	if !leader {
+		log.G(ctx).Debugf("Waiting for in-flight pull of image %q", ref)
+		select {
+		case <-inflight.done:
+			return inflight.imageID, inflight.err
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	defer func() { c.finishInflightPull(inflightKey, imageID, err) }()
+
 	imagePullProgressTimeout, err := time.ParseDuration(c.config.ImagePullProgressTimeout)
 	if err != nil {
 		return "", fmt.Errorf("failed to parse image_pull_progress_timeout %q: %w", c.config.ImagePullProgressTimeout, err)
@@ -183,7 +261,6 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 		containerd.WithSchema1Conversion, //nolint:staticcheck // Ignore SA1019. Need to keep deprecated package for compatibility.
 		containerd.WithResolver(resolver),
 		containerd.WithPullSnapshotter(snapshotter),
-		containerd.WithPullUnpack,
 		containerd.WithPullLabels(labels),
 		containerd.WithMaxConcurrentDownloads(c.config.MaxConcurrentDownloads),
 		containerd.WithImageHandler(imageHandler),
@@ -192,6 +269,11 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 			containerd.WithUnpackApplyOpts(diff.WithSyncFs(c.config.ImagePullWithSyncFs)),
 		}),
 	}
+	if !c.config.DeferredImageUnpack {
+		pullOpts = append(pullOpts, containerd.WithPullUnpack)
+	} else {
+		log.G(ctx).Debugf("Deferring unpack of image %q to first container create", ref)
+	}
 
 	// Temporarily removed for v2 upgrade
 	//pullOpts = append(pullOpts, c.encryptedImagesPullOpts()...)
@@ -218,7 +300,7 @@ func (c *CRIImageService) PullImage(ctx context.Context, name string, credential
 	if err != nil {
 		return "", fmt.Errorf("get image config descriptor: %w", err)
 	}
-	imageID := configDesc.Digest.String()
+	imageID = configDesc.Digest.String()
 
 	repoDigest, repoTag := getRepoDigestAndTag(namedRef, image.Target().Digest, isSchema1)
 	for _, r := range []string{imageID, repoTag, repoDigest} {
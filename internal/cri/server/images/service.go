@@ -18,12 +18,14 @@ package images
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/content"
 	"github.com/containerd/containerd/v2/core/images"
 	"github.com/containerd/containerd/v2/core/snapshots"
+	"github.com/containerd/containerd/v2/core/snapshots/probe"
 	criconfig "github.com/containerd/containerd/v2/internal/cri/config"
 	imagestore "github.com/containerd/containerd/v2/internal/cri/store/image"
 	snapshotstore "github.com/containerd/containerd/v2/internal/cri/store/snapshot"
@@ -69,6 +71,10 @@ type CRIImageService struct {
 	// one in-flight fetch request or unpack handler for a given descriptor's
 	// or chain ID.
 	unpackDuplicationSuppressor kmutex.KeyedLocker
+	// inflightPullsMu guards inflightPulls, which coalesces concurrent
+	// pulls of the same image reference into a single pull.
+	inflightPullsMu sync.Mutex
+	inflightPulls   map[string]*inflightPull
 }
 
 type GRPCCRIImageService struct {
@@ -109,6 +115,7 @@ func NewService(config criconfig.ImageConfig, options *CRIImageServiceOptions) (
 		runtimePlatforms:            options.RuntimePlatforms,
 		snapshotStore:               snapshotstore.NewStore(),
 		unpackDuplicationSuppressor: kmutex.New(),
+		inflightPulls:               make(map[string]*inflightPull),
 	}
 
 	log.L.Info("Start snapshots syncer")
@@ -160,6 +167,15 @@ func (c *CRIImageService) RuntimeSnapshotter(ctx context.Context, ociRuntime cri
 		return c.config.Snapshotter
 	}
 
+	// Fall back to the default snapshotter when the configured proxy
+	// snapshotter was probed unresponsive at startup, instead of failing
+	// every container create.
+	if caps, ok := probe.Get(ociRuntime.Snapshotter); ok && !caps.Responsive {
+		log.G(ctx).Warnf("Snapshotter %s for runtime %s is unresponsive, falling back to %s",
+			ociRuntime.Snapshotter, ociRuntime.Type, c.config.Snapshotter)
+		return c.config.Snapshotter
+	}
+
 	log.G(ctx).Debugf("Set snapshotter for runtime %s to %s", ociRuntime.Type, ociRuntime.Snapshotter)
 	return ociRuntime.Snapshotter
 }
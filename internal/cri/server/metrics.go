@@ -32,6 +32,7 @@ var (
 	containerListTimer          metrics.Timer
 	containerRemoveTimer        metrics.LabeledTimer
 	containerCreateTimer        metrics.LabeledTimer
+	seccompProfileCount         metrics.LabeledCounter
 	containerStopTimer          metrics.LabeledTimer
 	containerStartTimer         metrics.LabeledTimer
 	containerEventsDroppedCount metrics.Counter
@@ -57,6 +58,7 @@ func init() {
 	containerListTimer = ns.NewTimer("container_list", "time to list containers")
 	containerRemoveTimer = ns.NewLabeledTimer("container_remove", "time to remove a container", "runtime")
 	containerCreateTimer = ns.NewLabeledTimer("container_create", "time to create a container", "runtime")
+	seccompProfileCount = ns.NewLabeledCounter("seccomp_profiles", "count of applied seccomp profiles by kind", "kind")
 	containerStopTimer = ns.NewLabeledTimer("container_stop", "time to stop a container", "runtime")
 	containerStartTimer = ns.NewLabeledTimer("container_start", "time to start a container", "runtime")
 	containerEventsDroppedCount = ns.NewCounter("container_events_dropped", "count container discarding event total from server start")
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/containerd/log"
 	"github.com/containerd/nri"
@@ -75,12 +76,16 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 		labels = map[string]string{}
 	)
 
-	sandboxImage := c.getSandboxImageName()
+	sandboxImage := c.getSandboxImageName(metadata.RuntimeHandler)
 	// Ensure sandbox container image snapshot.
 	image, err := c.ensureImageExists(ctx, sandboxImage, config, metadata.RuntimeHandler)
 	if err != nil {
 		return cin, fmt.Errorf("failed to get sandbox image %q: %w", sandboxImage, err)
 	}
+	if err := verifySandboxImageDigest(sandboxImage, image); err != nil {
+		return cin, err
+	}
+	c.pinSandboxImage(ctx, sandboxImage, image)
 
 	containerdImage, err := c.toContainerdImage(ctx, *image)
 	if err != nil {
@@ -99,7 +104,7 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 	// NOTE: sandboxContainerSpec SHOULD NOT have side
 	// effect, e.g. accessing/creating files, so that we can test
 	// it safely.
-	spec, err := c.sandboxContainerSpec(id, config, &image.ImageSpec.Config, metadata.NetNSPath, ociRuntime.PodAnnotations)
+	spec, err := c.sandboxContainerSpec(id, metadata.RuntimeHandler, config, &image.ImageSpec.Config, metadata.NetNSPath, append(ociRuntime.PodAnnotations, c.config.PodAnnotationPassthrough...))
 	if err != nil {
 		return cin, fmt.Errorf("failed to generate sandbox container spec: %w", err)
 	}
@@ -196,7 +201,7 @@ func (c *Controller) Start(ctx context.Context, id string) (cin sandbox.Controll
 	}()
 
 	// Setup files required for the sandbox.
-	if err = c.setupSandboxFiles(id, config); err != nil {
+	if err = c.setupSandboxFiles(id, metadata.RuntimeHandler, config); err != nil {
 		return cin, fmt.Errorf("failed to setup sandbox files: %w", err)
 	}
 	defer func() {
@@ -319,9 +324,14 @@ func (c *Controller) ensureImageExists(ctx context.Context, ref string, config *
 	return &newImage, nil
 }
 
-func (c *Controller) getSandboxImageName() string {
+func (c *Controller) getSandboxImageName(runtimeHandler string) string {
 	// returns the name of the sandbox image used to scope pod shared resources used by the pod's containers,
 	// if empty return the default sandbox image.
+	// A runtime handler level override takes precedence, so e.g. VM based
+	// runtime classes can use their own pause image.
+	if r, ok := c.config.Runtimes[runtimeHandler]; ok && r.SandboxImage != "" {
+		return r.SandboxImage
+	}
 	if c.imageService != nil {
 		sandboxImage := c.imageService.PinnedImage("sandbox")
 		if sandboxImage != "" {
@@ -330,3 +340,38 @@ func (c *Controller) getSandboxImageName() string {
 	}
 	return criconfig.DefaultSandboxImage
 }
+
+// verifySandboxImageDigest verifies that a digest pinned sandbox image
+// reference still resolves to an image carrying that reference, guarding
+// against tampered or re-tagged pause images between sandbox starts.
+func verifySandboxImageDigest(ref string, image *imagestore.Image) error {
+	if !strings.Contains(ref, "@") {
+		return nil
+	}
+	for _, r := range image.References {
+		if r == ref {
+			return nil
+		}
+	}
+	return fmt.Errorf("sandbox image %q does not match its pinned digest reference", ref)
+}
+
+// pinSandboxImage marks the sandbox image pinned in the image store so
+// image GC can never remove it while in use as a pause image.
+func (c *Controller) pinSandboxImage(ctx context.Context, ref string, image *imagestore.Image) {
+	img, err := c.client.ImageService().Get(ctx, ref)
+	if err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to pin sandbox image %q", ref)
+		return
+	}
+	if img.Labels[crilabels.PinnedImageLabelKey] == crilabels.PinnedImageLabelValue {
+		return
+	}
+	if img.Labels == nil {
+		img.Labels = map[string]string{}
+	}
+	img.Labels[crilabels.PinnedImageLabelKey] = crilabels.PinnedImageLabelValue
+	if _, err := c.client.ImageService().Update(ctx, img, "labels."+crilabels.PinnedImageLabelKey); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to pin sandbox image %q", ref)
+	}
+}
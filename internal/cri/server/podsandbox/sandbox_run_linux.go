@@ -35,7 +35,7 @@ import (
 	"github.com/containerd/containerd/v2/pkg/userns"
 )
 
-func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
+func (c *Controller) sandboxContainerSpec(id, runtimeHandler string, config *runtime.PodSandboxConfig,
 	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string) (_ *runtimespec.Spec, retErr error) {
 	// Creates a spec Generator with the default spec.
 	// TODO(random-liu): [P1] Compare the default settings with docker and containerd default.
@@ -179,11 +179,28 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 	}
 
 	if res := config.GetLinux().GetResources(); res != nil {
+		var (
+			cpuPeriod = res.CpuPeriod
+			cpuQuota  = res.CpuQuota
+			cpuShares = res.CpuShares
+			memory    = res.MemoryLimitInBytes
+		)
+		// Account the RuntimeClass pod overhead when sizing the sandbox,
+		// so VM based runtimes reserve what scheduling assumed.
+		if overhead := config.GetLinux().GetOverhead(); overhead != nil {
+			if cpuQuota > 0 && overhead.CpuQuota > 0 {
+				cpuQuota += overhead.CpuQuota
+			}
+			cpuShares += overhead.CpuShares
+			if memory > 0 && overhead.MemoryLimitInBytes > 0 {
+				memory += overhead.MemoryLimitInBytes
+			}
+		}
 		specOpts = append(specOpts,
-			customopts.WithAnnotation(annotations.SandboxCPUPeriod, strconv.FormatInt(res.CpuPeriod, 10)),
-			customopts.WithAnnotation(annotations.SandboxCPUQuota, strconv.FormatInt(res.CpuQuota, 10)),
-			customopts.WithAnnotation(annotations.SandboxCPUShares, strconv.FormatInt(res.CpuShares, 10)),
-			customopts.WithAnnotation(annotations.SandboxMem, strconv.FormatInt(res.MemoryLimitInBytes, 10)))
+			customopts.WithAnnotation(annotations.SandboxCPUPeriod, strconv.FormatInt(cpuPeriod, 10)),
+			customopts.WithAnnotation(annotations.SandboxCPUQuota, strconv.FormatInt(cpuQuota, 10)),
+			customopts.WithAnnotation(annotations.SandboxCPUShares, strconv.FormatInt(cpuShares, 10)),
+			customopts.WithAnnotation(annotations.SandboxMem, strconv.FormatInt(memory, 10)))
 	}
 
 	specOpts = append(specOpts, customopts.WithPodOOMScoreAdj(int(defaultSandboxOOMAdj), c.config.RestrictOOMScoreAdj))
@@ -193,7 +210,7 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 		specOpts = append(specOpts, customopts.WithAnnotation(pKey, pValue))
 	}
 
-	specOpts = append(specOpts, annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(), config, true)...)
+	specOpts = append(specOpts, annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(runtimeHandler), config, true)...)
 
 	return c.runtimeSpec(id, "", specOpts...)
 }
@@ -247,7 +264,7 @@ func (c *Controller) sandboxContainerSpecOpts(config *runtime.PodSandboxConfig,
 
 // setupSandboxFiles sets up necessary sandbox files including /dev/shm, /etc/hosts,
 // /etc/resolv.conf and /etc/hostname.
-func (c *Controller) setupSandboxFiles(id string, config *runtime.PodSandboxConfig) error {
+func (c *Controller) setupSandboxFiles(id, runtimeHandler string, config *runtime.PodSandboxConfig) error {
 	sandboxEtcHostname := c.getSandboxHostname(id)
 	hostname := config.GetHostname()
 	if hostname == "" {
@@ -270,18 +287,40 @@ func (c *Controller) setupSandboxFiles(id string, config *runtime.PodSandboxConf
 	// Set DNS options. Maintain a resolv.conf for the sandbox.
 	resolvPath := c.getResolvPath(id)
 
+	// The host resolv.conf can be overridden per runtime class, e.g. for
+	// nodes where /etc/resolv.conf points at the systemd-resolved stub
+	// listener.
+	hostResolvConfPath := resolvConfPath
+	if r, ok := c.config.Runtimes[runtimeHandler]; ok && r.HostResolvConfPath != "" {
+		hostResolvConfPath = r.HostResolvConfPath
+	}
+
 	if dnsConfig := config.GetDnsConfig(); dnsConfig != nil {
-		resolvContent, err := parseDNSOptions(dnsConfig.Servers, dnsConfig.Searches, dnsConfig.Options)
+		options := dnsConfig.Options
+		if extra := config.GetAnnotations()[annotations.PodResolvConfOptions]; extra != "" {
+			options = append(append([]string{}, options...), strings.Fields(extra)...)
+		}
+		resolvContent, err := parseDNSOptions(dnsConfig.Servers, dnsConfig.Searches, options)
 		if err != nil {
 			return fmt.Errorf("failed to parse sandbox DNSConfig %+v: %w", dnsConfig, err)
 		}
+		if config.GetAnnotations()[annotations.PodResolvConfPolicy] == "merge" {
+			// Resolvers try nameservers in listed order, so the pod's
+			// entries come first and the host's resolv.conf follows as
+			// fallback, with duplicate lines dropped.
+			hostContent, err := os.ReadFile(hostResolvConfPath)
+			if err != nil {
+				return fmt.Errorf("failed to read host resolv.conf for merging: %w", err)
+			}
+			resolvContent = mergeResolvConf(resolvContent, string(hostContent))
+		}
 		if err := c.os.WriteFile(resolvPath, []byte(resolvContent), 0644); err != nil {
 			return fmt.Errorf("failed to write resolv content to %q: %w", resolvPath, err)
 		}
 	} else {
 		// The DnsConfig was nil - we interpret that to mean "use the global
 		// default", which is dubious but backwards-compatible.
-		if err := c.os.CopyFile(resolvConfPath, resolvPath, 0644); err != nil {
+		if err := c.os.CopyFile(hostResolvConfPath, resolvPath, 0644); err != nil {
 			return fmt.Errorf("failed to copy host's resolv.conf to %q: %w", resolvPath, err)
 		}
 	}
@@ -305,6 +344,28 @@ func (c *Controller) setupSandboxFiles(id string, config *runtime.PodSandboxConf
 	return nil
 }
 
+// mergeResolvConf appends the host resolv.conf lines after the pod's,
+// dropping comments and lines the pod content already contains.
+func mergeResolvConf(podContent, hostContent string) string {
+	seen := make(map[string]struct{})
+	for _, line := range strings.Split(podContent, "\n") {
+		seen[strings.TrimSpace(line)] = struct{}{}
+	}
+	merged := podContent
+	for _, line := range strings.Split(hostContent, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";") {
+			continue
+		}
+		if _, ok := seen[trimmed]; ok {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		merged += trimmed + "\n"
+	}
+	return merged
+}
+
 // parseDNSOptions parse DNS options into resolv.conf format content,
 // if none option is specified, will return empty with no error.
 func parseDNSOptions(servers, searches, options []string) (string, error) {
@@ -406,7 +406,7 @@ func TestLinuxSandboxContainerSpec(t *testing.T) {
 			if test.configChange != nil {
 				test.configChange(config)
 			}
-			spec, err := c.sandboxContainerSpec(testID, config, imageConfig, nsPath, nil)
+			spec, err := c.sandboxContainerSpec(testID, "", config, imageConfig, nsPath, nil)
 			if test.expectErr {
 				assert.Error(t, err)
 				assert.Nil(t, spec)
@@ -688,7 +688,7 @@ options timeout:1
 					},
 				},
 			}
-			c.setupSandboxFiles(testID, cfg)
+			c.setupSandboxFiles(testID, "", cfg)
 			calls := c.os.(*ostesting.FakeOS).GetCalls()
 			assert.Len(t, calls, len(test.expectedCalls))
 			for i, expected := range test.expectedCalls {
@@ -762,7 +762,7 @@ func TestSandboxDisableCgroup(t *testing.T) {
 	config, imageConfig, _ := getRunPodSandboxTestData()
 	c := newControllerService()
 	c.config.DisableCgroup = true
-	spec, err := c.sandboxContainerSpec("test-id", config, imageConfig, "test-cni", []string{})
+	spec, err := c.sandboxContainerSpec("test-id", "", config, imageConfig, "test-cni", []string{})
 	require.NoError(t, err)
 
 	t.Log("resource limit should not be set")
@@ -105,7 +105,7 @@ func TestSandboxContainerSpec(t *testing.T) {
 			if test.imageConfigChange != nil {
 				test.imageConfigChange(imageConfig)
 			}
-			spec, err := c.sandboxContainerSpec(testID, config, imageConfig, nsPath,
+			spec, err := c.sandboxContainerSpec(testID, "", config, imageConfig, nsPath,
 				test.podAnnotations)
 			if test.expectErr {
 				assert.Error(t, err)
@@ -30,7 +30,7 @@ import (
 	customopts "github.com/containerd/containerd/v2/internal/cri/opts"
 )
 
-func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxConfig,
+func (c *Controller) sandboxContainerSpec(id, runtimeHandler string, config *runtime.PodSandboxConfig,
 	imageConfig *imagespec.ImageConfig, nsPath string, runtimePodAnnotations []string) (*runtimespec.Spec, error) {
 	// Creates a spec Generator with the default spec.
 	specOpts := []oci.SpecOpts{
@@ -83,7 +83,7 @@ func (c *Controller) sandboxContainerSpec(id string, config *runtime.PodSandboxC
 	specOpts = append(specOpts, customopts.WithAnnotation(annotations.WindowsHostProcess, strconv.FormatBool(config.GetWindows().GetSecurityContext().GetHostProcess())))
 
 	specOpts = append(specOpts,
-		annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(), config, true)...,
+		annotations.DefaultCRIAnnotations(id, "", c.getSandboxImageName(runtimeHandler), config, true)...,
 	)
 
 	return c.runtimeSpec(id, "", specOpts...)
@@ -95,7 +95,7 @@ func (c *Controller) sandboxContainerSpecOpts(config *runtime.PodSandboxConfig,
 }
 
 // No sandbox files needed for windows.
-func (c *Controller) setupSandboxFiles(id string, config *runtime.PodSandboxConfig) error {
+func (c *Controller) setupSandboxFiles(id, runtimeHandler string, config *runtime.PodSandboxConfig) error {
 	return nil
 }
 
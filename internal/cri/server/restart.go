@@ -84,6 +84,11 @@ func (c *criService) recover(ctx context.Context) error {
 			if err := c.sandboxStore.Add(sb); err != nil {
 				return fmt.Errorf("failed to add sandbox %q to store: %w", sandbox.ID(), err)
 			}
+			// Rebuild in-memory host port claims for sandboxes that keep
+			// running across the daemon restart.
+			if sb.Status.Get().State == sandboxstore.StateReady {
+				c.hostPorts.restore(sb.ID, sb.Config.GetPortMappings())
+			}
 			if err := c.sandboxNameIndex.Reserve(sb.Name, sb.ID); err != nil {
 				return fmt.Errorf("failed to reserve sandbox name %q: %w", sb.Name, err)
 			}
@@ -148,6 +153,9 @@ func (c *criService) recover(ctx context.Context) error {
 		if err := c.sandboxStore.Add(sb); err != nil {
 			return fmt.Errorf("failed to add stored sandbox %q to store: %w", sbx.ID, err)
 		}
+		if state == sandboxstore.StateReady {
+			c.hostPorts.restore(sb.ID, sb.Config.GetPortMappings())
+		}
 	}
 
 	for _, sb := range c.sandboxStore.List() {
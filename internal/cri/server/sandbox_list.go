@@ -38,7 +38,14 @@ func (c *criService) ListPodSandbox(ctx context.Context, r *runtime.ListPodSandb
 		))
 	}
 
+	fields := extractFieldSelection(r.GetFilter().GetLabelSelector())
+	pageSize, pageToken := extractPageSelection(r.GetFilter().GetLabelSelector())
 	sandboxes = c.filterCRISandboxes(sandboxes, r.GetFilter())
+	sandboxes = trimSandboxes(sandboxes, fields)
+	sandboxes, err := pageSandboxes(sandboxes, pageSize, pageToken)
+	if err != nil {
+		return nil, err
+	}
 
 	sandboxListTimer.UpdateSince(start)
 	return &runtime.ListPodSandboxResponse{Items: sandboxes}, nil
@@ -75,7 +75,20 @@ func (c *criService) portForward(ctx context.Context, id string, port int32, str
 			var errV6 error
 			conn, errV6 = net.Dial("tcp6", fmt.Sprintf("localhost:%d", port))
 			if errV6 != nil {
-				return fmt.Errorf("failed to connect to localhost:%d inside namespace %q, IPv4: %v IPv6 %v ", port, id, err, errV6)
+				// Nothing listens on TCP; fall back to UDP so forwarding
+				// to DNS/QUIC services works. A UDP "connection" cannot
+				// be probed, so the relay is bounded by the session
+				// lifetime and an idle timeout instead.
+				udpConn, errUDP := net.Dial("udp4", fmt.Sprintf("localhost:%d", port))
+				if errUDP != nil {
+					udpConn, errUDP = net.Dial("udp6", fmt.Sprintf("localhost:%d", port))
+				}
+				if errUDP != nil {
+					return fmt.Errorf("failed to connect to localhost:%d inside namespace %q, IPv4: %v IPv6 %v ", port, id, err, errV6)
+				}
+				defer udpConn.Close()
+				log.G(ctx).Debugf("PortForward falling back to UDP in network namespace %q port %d", id, port)
+				return forwardUDPConn(ctx, stream, udpConn)
 			}
 		}
 		defer conn.Close()
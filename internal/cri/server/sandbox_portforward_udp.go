@@ -0,0 +1,88 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"time"
+)
+
+// portForwardUDPIdleTimeout bounds how long a forwarded UDP session stays
+// open without traffic from the service, since UDP has no connection
+// close to detect.
+const portForwardUDPIdleTimeout = 30 * time.Second
+
+// forwardUDPConn relays between the client stream and a UDP socket. Each
+// chunk read from the stream is sent as one datagram, which matches
+// request/response protocols such as DNS and the way kubectl writes UDP
+// payloads. The relay ends with the session or after the idle timeout.
+func forwardUDPConn(ctx context.Context, stream io.ReadWriteCloser, conn net.Conn) error {
+	errCh := make(chan error, 2)
+
+	// Datagrams from the service back to the client, with the idle
+	// timeout armed on every read.
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			conn.SetReadDeadline(time.Now().Add(portForwardUDPIdleTimeout))
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if _, werr := stream.Write(buf[:n]); werr != nil {
+					errCh <- werr
+					return
+				}
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	// Chunks from the client forwarded as datagrams to the service.
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			n, err := stream.Read(buf)
+			if n > 0 {
+				if _, werr := conn.Write(buf[:n]); werr != nil {
+					errCh <- werr
+					return
+				}
+			}
+			if err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		if err == nil || errors.Is(err, io.EOF) || errors.Is(err, os.ErrDeadlineExceeded) {
+			// Idle sessions and client EOF are normal terminations.
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
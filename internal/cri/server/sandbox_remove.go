@@ -91,6 +91,9 @@ func (c *criService) RemovePodSandbox(ctx context.Context, r *runtime.RemovePodS
 		log.G(ctx).WithError(err).Errorf("NRI pod removal notification failed")
 	}
 
+	// Free host ports claimed by the sandbox.
+	c.hostPorts.release(id)
+
 	// Remove sandbox from sandbox store. Note that once the sandbox is successfully
 	// deleted:
 	// 1) ListPodSandbox will not include this sandbox.
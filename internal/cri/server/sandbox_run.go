@@ -49,6 +49,10 @@ func init() {
 // RunPodSandbox creates and starts a pod-level sandbox. Runtimes should ensure
 // the sandbox is in ready state.
 func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandboxRequest) (_ *runtime.RunPodSandboxResponse, retErr error) {
+	if c.IsDraining() {
+		return nil, errDraining()
+	}
+
 	config := r.GetConfig()
 	log.G(ctx).Debugf("Sandbox config %+v", config)
 
@@ -58,6 +62,17 @@ func (c *criService) RunPodSandbox(ctx context.Context, r *runtime.RunPodSandbox
 	if metadata == nil {
 		return nil, errors.New("sandbox config must include metadata")
 	}
+
+	// Fail fast on host port conflicts with other sandboxes or listeners
+	// already bound on the host.
+	if err := c.hostPorts.claim(id, config.GetPortMappings()); err != nil {
+		return nil, fmt.Errorf("failed to claim host ports: %w", err)
+	}
+	defer func() {
+		if retErr != nil {
+			c.hostPorts.release(id)
+		}
+	}()
 	name := makeSandboxName(metadata)
 	log.G(ctx).WithField("podsandboxid", id).Debugf("generated id for sandbox name %q", name)
 
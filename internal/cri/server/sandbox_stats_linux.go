@@ -23,10 +23,14 @@ import (
 
 	"github.com/containerd/cgroups/v3"
 	"github.com/containerd/cgroups/v3/cgroup1"
+	cg1 "github.com/containerd/cgroups/v3/cgroup1/stats"
 	cgroupsv2 "github.com/containerd/cgroups/v3/cgroup2"
+	cg2 "github.com/containerd/cgroups/v3/cgroup2/stats"
 	sandboxstore "github.com/containerd/containerd/v2/internal/cri/store/sandbox"
+	"github.com/containerd/containerd/v2/pkg/protobuf"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/vishvananda/netlink"
 	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
@@ -84,28 +88,33 @@ func (c *criService) podSandboxStats(
 			}
 		}
 
-		var pidCount uint64
-		for _, cntr := range c.containerStore.List() {
-			if cntr.SandboxID != sandbox.ID {
-				continue
-			}
+		// Prefer the pids count accounted by the sandbox cgroup, which
+		// covers every process in the pod in a single read. Fall back to
+		// querying each task for cgroup drivers without pids accounting.
+		pidCount, ok := pidsStat(stats)
+		if !ok {
+			for _, cntr := range c.containerStore.List() {
+				if cntr.SandboxID != sandbox.ID {
+					continue
+				}
 
-			state := cntr.Status.Get().State()
-			if state != runtime.ContainerState_CONTAINER_RUNNING {
-				continue
-			}
+				state := cntr.Status.Get().State()
+				if state != runtime.ContainerState_CONTAINER_RUNNING {
+					continue
+				}
 
-			task, err := cntr.Container.Task(ctx, nil)
-			if err != nil {
-				return nil, err
-			}
+				task, err := cntr.Container.Task(ctx, nil)
+				if err != nil {
+					return nil, err
+				}
 
-			processes, err := task.Pids(ctx)
-			if err != nil {
-				return nil, err
-			}
-			pidCount += uint64(len(processes))
+				processes, err := task.Pids(ctx)
+				if err != nil {
+					return nil, err
+				}
+				pidCount += uint64(len(processes))
 
+			}
 		}
 		podSandboxStats.Linux.Process = &runtime.ProcessUsage{
 			Timestamp:    timestamp.UnixNano(),
@@ -118,11 +127,88 @@ func (c *criService) podSandboxStats(
 			return nil, fmt.Errorf("failed to obtain container stats during podSandboxStats call: %w", err)
 		}
 		podSandboxStats.Linux.Containers = resp.GetStats()
+
+		// Include the sandbox (pause) container itself in the per-container
+		// breakdown so callers get the complete pod in one call instead of
+		// issuing a separate stats request per cgroup.
+		pauseStats, err := c.pauseContainerStats(ctx, sandbox)
+		if err != nil {
+			log.G(ctx).WithError(err).Warnf("Failed to get pause container stats for sandbox %q", meta.ID)
+		} else if pauseStats != nil {
+			podSandboxStats.Linux.Containers = append(podSandboxStats.Linux.Containers, pauseStats)
+		}
 	}
 
 	return podSandboxStats, nil
 }
 
+// pidsStat returns the pids.current count from cgroup metrics. The second
+// return value is false when the metrics do not include pids accounting.
+func pidsStat(stats interface{}) (uint64, bool) {
+	switch metrics := stats.(type) {
+	case *cg1.Metrics:
+		if metrics.Pids != nil {
+			return metrics.Pids.Current, true
+		}
+	case *cg2.Metrics:
+		if metrics.Pids != nil {
+			return metrics.Pids.Current, true
+		}
+	}
+	return 0, false
+}
+
+// pauseContainerStats returns the stats of the sandbox container itself,
+// which is not covered by ListContainerStats.
+func (c *criService) pauseContainerStats(ctx context.Context, sandbox sandboxstore.Sandbox) (*runtime.ContainerStats, error) {
+	if sandbox.Container == nil {
+		return nil, nil
+	}
+	task, err := sandbox.Container.Task(ctx, nil)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load sandbox container task: %w", err)
+	}
+	metric, err := task.Metrics(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sandbox container metrics: %w", err)
+	}
+
+	var data interface{}
+	switch {
+	case typeurl.Is(metric.Data, (*cg1.Metrics)(nil)):
+		data = &cg1.Metrics{}
+	case typeurl.Is(metric.Data, (*cg2.Metrics)(nil)):
+		data = &cg2.Metrics{}
+	default:
+		return nil, fmt.Errorf("cannot convert metric data to cgroups.Metrics")
+	}
+	if err := typeurl.UnmarshalTo(metric.Data, data); err != nil {
+		return nil, fmt.Errorf("failed to extract sandbox container metrics: %w", err)
+	}
+
+	meta := sandbox.Metadata
+	cs := &runtime.ContainerStats{
+		Attributes: &runtime.ContainerAttributes{
+			Id:          meta.ID,
+			Labels:      meta.Config.GetLabels(),
+			Annotations: meta.Config.GetAnnotations(),
+		},
+	}
+	timestamp := protobuf.FromTimestamp(metric.Timestamp)
+	cs.Cpu, err = c.cpuContainerStats(meta.ID, true /* isSandbox */, data, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain cpu stats: %w", err)
+	}
+	cs.Memory, err = c.memoryContainerStats(meta.ID, data, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain memory stats: %w", err)
+	}
+	return cs, nil
+}
+
 // https://github.com/cri-o/cri-o/blob/74a5cf8dffd305b311eb1c7f43a4781738c388c1/internal/oci/stats.go#L32
 func getContainerNetIO(ctx context.Context, netNsPath string) (rxBytes, rxErrors, txBytes, txErrors uint64) {
 	ns.WithNetNSPath(netNsPath, func(_ ns.NetNS) error {
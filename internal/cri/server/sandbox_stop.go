@@ -42,6 +42,15 @@ func (c *criService) StopPodSandbox(ctx context.Context, r *runtime.StopPodSandb
 		return nil, err
 	}
 
+	// A stopped sandbox has nothing bound anymore; free its host ports so
+	// a replacement pod can claim them before sandbox removal.
+	c.hostPorts.release(sandbox.ID)
+
+	// Emit the stopped event from the synchronous stop path as well, so
+	// evented PLEG consumers observe the transition even when the async
+	// TaskExit event is delayed or dropped.
+	c.generateAndSendContainerEvent(ctx, sandbox.ID, sandbox.ID, runtime.ContainerEventType_CONTAINER_STOPPED_EVENT)
+
 	return &runtime.StopPodSandboxResponse{}, nil
 }
 
@@ -55,6 +55,8 @@ import (
 	osinterface "github.com/containerd/containerd/v2/pkg/os"
 	"github.com/containerd/containerd/v2/pkg/protobuf"
 	"github.com/containerd/containerd/v2/plugins"
+	"github.com/containerd/containerd/v2/plugins/services/healthcheck"
+	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 var kernelSupportsRRO bool
@@ -155,6 +157,15 @@ type criService struct {
 	sandboxService sandboxService
 	// runtimeHandlers contains runtime handler info
 	runtimeHandlers []*runtime.RuntimeHandler
+	// execSessions tracks live exec sessions per container and enforces
+	// the configured concurrency limit.
+	execSessions *execSessionTracker
+	// draining indicates whether the plugin is in drain mode, rejecting
+	// new RunPodSandbox requests while allowing existing pods to run.
+	draining atomic.Bool
+	// hostPorts tracks host ports claimed by sandboxes to detect
+	// conflicts at sandbox creation.
+	hostPorts *hostPortManager
 }
 
 type CRIServiceOptions struct {
@@ -195,6 +206,8 @@ func NewCRIService(options *CRIServiceOptions) (CRIService, runtime.RuntimeServi
 		containerNameIndex: registrar.NewRegistrar(),
 		netPlugin:          make(map[string]cni.CNI),
 		sandboxService:     newCriSandboxService(&config, options.SandboxControllers),
+		execSessions:       newExecSessionTracker(config.MaxConcurrentExecs),
+		hostPorts:          newHostPortManager(),
 	}
 
 	// TODO: Make discard time configurable
@@ -301,8 +314,17 @@ func (c *criService) Run(ready func()) error {
 		return fmt.Errorf("failed to set up NRI for CRI service: %w", err)
 	}
 
+	// Enforce pod ephemeral storage limits in the background.
+	monitorCtx, monitorCancel := context.WithCancel(ctrdutil.NamespacedContext())
+	defer monitorCancel()
+	go c.ephemeralStorageMonitor(monitorCtx)
+
 	// Set the server as initialized. GRPC services could start serving traffic.
 	c.initialized.Store(true)
+	// Reflect readiness in the gRPC health service so tooling such as
+	// grpc_health_probe observes CRI state on the containerd socket.
+	healthcheck.SetServingStatus("runtime.v1.RuntimeService", grpc_health_v1.HealthCheckResponse_SERVING)
+	healthcheck.SetServingStatus("runtime.v1.ImageService", grpc_health_v1.HealthCheckResponse_SERVING)
 	ready()
 
 	var eventMonitorErr, streamServerErr, cniNetConfMonitorErr error
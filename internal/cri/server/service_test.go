@@ -143,6 +143,8 @@ func newTestCRIService(opts ...testOpt) *criService {
 			defaultNetworkPlugin: servertesting.NewFakeCNIPlugin(),
 		},
 		sandboxService: &fakeSandboxService{},
+		execSessions:   newExecSessionTracker(testConfig.MaxConcurrentExecs),
+		hostPorts:      newHostPortManager(),
 	}
 	for _, opt := range opts {
 		opt(service)
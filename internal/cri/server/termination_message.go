@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package server
+
+import (
+	"os"
+	"strings"
+
+	containerstore "github.com/containerd/containerd/v2/internal/cri/store/container"
+)
+
+const (
+	// terminationMessagePathAnnotation is set by the kubelet on container
+	// configs with the in-container termination message path.
+	terminationMessagePathAnnotation = "io.kubernetes.container.terminationMessagePath"
+	// terminationMessagePolicyAnnotation carries the policy, either
+	// "File" or "FallbackToLogsOnError".
+	terminationMessagePolicyAnnotation = "io.kubernetes.container.terminationMessagePolicy"
+
+	// maxTerminationMessageSize matches the kubelet's 4KiB cap on
+	// termination messages.
+	maxTerminationMessageSize = 4096
+)
+
+// terminationMessage captures the container's termination message at exit
+// per terminationMessagePath/terminationMessagePolicy semantics: the
+// message file is read through the host path the kubelet bind mounted for
+// it, and with the fallback policy the log tail substitutes for an empty
+// message on failure. This way the kubelet does not have to reach into
+// dead containers' filesystems via the host.
+func terminationMessage(cntr containerstore.Container, exitCode int32) string {
+	config := cntr.Config
+	msgPath := config.GetAnnotations()[terminationMessagePathAnnotation]
+	if msgPath == "" {
+		return ""
+	}
+
+	// The kubelet bind mounts a host path over the termination message
+	// path; read the message through it.
+	var message string
+	for _, m := range config.GetMounts() {
+		if m.GetContainerPath() != msgPath {
+			continue
+		}
+		data, err := os.ReadFile(m.GetHostPath())
+		if err == nil {
+			message = string(data)
+			if len(message) > maxTerminationMessageSize {
+				message = message[:maxTerminationMessageSize]
+			}
+		}
+		break
+	}
+
+	policy := config.GetAnnotations()[terminationMessagePolicyAnnotation]
+	if message == "" && exitCode != 0 && policy == string("FallbackToLogsOnError") {
+		message = startFailureLogTail(cntr.LogPath)
+	}
+	return strings.TrimSpace(message)
+}
@@ -175,7 +175,8 @@ func (s *Store) getImage(ctx context.Context, i images.Image) (*Image, error) {
 		return nil, fmt.Errorf("unmarshal image config %s: %w", blob, err)
 	}
 
-	pinned := i.Labels[labels.PinnedImageLabelKey] == labels.PinnedImageLabelValue
+	pinned := i.Labels[labels.PinnedImageLabelKey] == labels.PinnedImageLabelValue ||
+		images.IsPinned(i)
 
 	return &Image{
 		ID:         id,
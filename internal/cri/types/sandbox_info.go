@@ -44,4 +44,7 @@ type SandboxInfo struct {
 	RuntimeSpec *specs.Spec       `json:"runtimeSpec"`
 	CNIResult   *cni.Result       `json:"cniResult"`
 	Metadata    *sandbox.Metadata `json:"sandboxMetadata"`
+	// AppliedOverhead is the RuntimeClass pod overhead accounted into the
+	// sandbox sizing, so scheduling and billing match reality.
+	AppliedOverhead *runtime.LinuxContainerResources `json:"appliedOverhead,omitempty"`
 }
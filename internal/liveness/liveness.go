@@ -0,0 +1,113 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package liveness aggregates heartbeats from internal loops, so the
+// daemon only pets the systemd watchdog while every registered loop is
+// making progress and a deadlocked reconcile loop becomes detectable and
+// restartable by systemd.
+package liveness
+
+import (
+	"sync"
+	"time"
+)
+
+// Probe is a single registered loop heartbeat.
+type Probe struct {
+	name       string
+	staleAfter time.Duration
+
+	mu       sync.Mutex
+	lastBeat time.Time
+}
+
+// Beat records that the loop made progress.
+func (p *Probe) Beat() {
+	p.mu.Lock()
+	p.lastBeat = time.Now()
+	p.mu.Unlock()
+}
+
+// healthy reports whether the loop has beaten recently enough.
+func (p *Probe) healthy() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Since(p.lastBeat) < p.staleAfter
+}
+
+// Status describes one probe for introspection.
+type Status struct {
+	// Name of the loop.
+	Name string `json:"name"`
+	// LastBeat is when the loop last reported progress.
+	LastBeat time.Time `json:"lastBeat"`
+	// StaleAfter is how long without a beat the loop counts as stale.
+	StaleAfter time.Duration `json:"staleAfter"`
+	// Healthy is false when the loop is stale.
+	Healthy bool `json:"healthy"`
+}
+
+var (
+	mu     sync.Mutex
+	probes []*Probe
+)
+
+// Register adds a loop heartbeat to the process wide registry. The loop
+// counts as stale when it has not beaten within staleAfter. The returned
+// probe starts out healthy.
+func Register(name string, staleAfter time.Duration) *Probe {
+	p := &Probe{
+		name:       name,
+		staleAfter: staleAfter,
+		lastBeat:   time.Now(),
+	}
+	mu.Lock()
+	probes = append(probes, p)
+	mu.Unlock()
+	return p
+}
+
+// Healthy reports whether every registered loop is making progress, and
+// the names of the stale loops otherwise.
+func Healthy() (bool, []string) {
+	mu.Lock()
+	defer mu.Unlock()
+	var stale []string
+	for _, p := range probes {
+		if !p.healthy() {
+			stale = append(stale, p.name)
+		}
+	}
+	return len(stale) == 0, stale
+}
+
+// Statuses returns the state of every registered probe for introspection.
+func Statuses() []Status {
+	mu.Lock()
+	defer mu.Unlock()
+	statuses := make([]Status, 0, len(probes))
+	for _, p := range probes {
+		p.mu.Lock()
+		statuses = append(statuses, Status{
+			Name:       p.name,
+			LastBeat:   p.lastBeat,
+			StaleAfter: p.staleAfter,
+			Healthy:    time.Since(p.lastBeat) < p.staleAfter,
+		})
+		p.mu.Unlock()
+	}
+	return statuses
+}
@@ -19,11 +19,14 @@ package archive
 import (
 	"archive/tar"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"syscall"
@@ -34,6 +37,7 @@ import (
 	"github.com/containerd/containerd/v2/pkg/userns"
 	"github.com/containerd/continuity/fs"
 	"github.com/containerd/log"
+	"golang.org/x/sync/errgroup"
 )
 
 var bufPool = &sync.Pool{
@@ -88,6 +92,9 @@ func WriteDiff(ctx context.Context, w io.Writer, a, b string, opts ...WriteDiffO
 
 	if options.writeDiffFunc == nil {
 		options.writeDiffFunc = writeDiffNaive
+		if options.ParallelWalk {
+			options.writeDiffFunc = writeDiffParallel
+		}
 	}
 
 	return options.writeDiffFunc(ctx, w, a, b, options)
@@ -108,13 +115,143 @@ func writeDiffNaive(ctx context.Context, w io.Writer, a, b string, o WriteDiffOp
 		// not to the source date epoch
 	}
 	cw := NewChangeWriter(w, b, opts...)
-	err := fs.Changes(ctx, a, b, cw.HandleChange)
+	err := fs.Changes(ctx, a, b, filteredChangeFunc(o, cw.HandleChange))
 	if err != nil {
 		return fmt.Errorf("failed to create diff tar stream: %w", err)
 	}
 	return cw.Close()
 }
 
+// filteredChangeFunc applies the configured ChangeFilter in front of the
+// change handler.
+func filteredChangeFunc(o WriteDiffOptions, fn fs.ChangeFunc) fs.ChangeFunc {
+	if o.ChangeFilter == nil {
+		return fn
+	}
+	return func(k fs.ChangeKind, p string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !o.ChangeFilter(k, p) {
+			return nil
+		}
+		return fn(k, p, f, err)
+	}
+}
+
+// writeDiffParallel writes the same diff tar stream as writeDiffNaive but
+// computes the upper/lower comparison per top level directory
+// concurrently. Changes are buffered per subtree and emitted in
+// deterministic order, so only the walk is parallel while the tar stream
+// stays serial.
+func writeDiffParallel(ctx context.Context, w io.Writer, a, b string, o WriteDiffOptions) error {
+	var opts []ChangeWriterOpt
+	if o.SourceDateEpoch != nil {
+		opts = append(opts, WithModTimeUpperBound(*o.SourceDateEpoch))
+	}
+	cw := NewChangeWriter(w, b, opts...)
+	emit := filteredChangeFunc(o, cw.HandleChange)
+
+	nameSet := make(map[string]struct{})
+	for _, dir := range []string{a, b} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+		for _, e := range entries {
+			nameSet[e.Name()] = struct{}{}
+		}
+	}
+	names := make([]string, 0, len(nameSet))
+	for name := range nameSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	type bufferedChange struct {
+		kind fs.ChangeKind
+		path string
+		info os.FileInfo
+	}
+	results := make([][]bufferedChange, len(names))
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(runtime.NumCPU())
+	for i, name := range names {
+		i, name := i, name
+		eg.Go(func() error {
+			var (
+				changes []bufferedChange
+				lower   = filepath.Join(a, name)
+				upper   = filepath.Join(b, name)
+			)
+			collect := func(k fs.ChangeKind, p string, f os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				changes = append(changes, bufferedChange{kind: k, path: filepath.Join(string(os.PathSeparator), name, p), info: f})
+				return nil
+			}
+
+			lfi, lerr := os.Lstat(lower)
+			ufi, uerr := os.Lstat(upper)
+			switch {
+			case lerr == nil && uerr == nil:
+				if !sameFileInfo(lfi, ufi) {
+					changes = append(changes, bufferedChange{kind: fs.ChangeKindModify, path: string(os.PathSeparator) + name, info: ufi})
+				}
+				if lfi.IsDir() && ufi.IsDir() {
+					if err := fs.Changes(egCtx, lower, upper, collect); err != nil {
+						return err
+					}
+				}
+			case uerr == nil:
+				changes = append(changes, bufferedChange{kind: fs.ChangeKindAdd, path: string(os.PathSeparator) + name, info: ufi})
+				if ufi.IsDir() {
+					if err := fs.Changes(egCtx, "", upper, collect); err != nil {
+						return err
+					}
+				}
+			case lerr == nil:
+				// A single whiteout of the top level entry covers the
+				// entire deleted subtree.
+				changes = append(changes, bufferedChange{kind: fs.ChangeKindDelete, path: string(os.PathSeparator) + name})
+			}
+			results[i] = changes
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("failed to create diff tar stream: %w", err)
+	}
+
+	for _, changes := range results {
+		for _, c := range changes {
+			if err := emit(c.kind, c.path, c.info, nil); err != nil {
+				return fmt.Errorf("failed to create diff tar stream: %w", err)
+			}
+		}
+	}
+	return cw.Close()
+}
+
+// sameFileInfo is a conservative comparison of two directory entries used
+// by the parallel walker for the top level entries; subtree contents are
+// compared by fs.Changes. Spurious modifications only re-add an entry to
+// the diff and keep the result correct.
+func sameFileInfo(a, b os.FileInfo) bool {
+	if a.Mode() != b.Mode() {
+		return false
+	}
+	if a.IsDir() {
+		return true
+	}
+	return a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
+
 const (
 	// whiteoutPrefix prefix means file is a whiteout. If this is followed by a
 	// filename this means that file has been removed from the base layer.
@@ -349,7 +486,9 @@ func createTarFile(ctx context.Context, path, extractDir string, hdr *tar.Header
 			return err
 		}
 
-		_, err = copyBuffered(ctx, file, reader)
+		// Write holes instead of zero runs so sparse files in the
+		// archive are not fully materialized on apply.
+		_, err = copySparse(ctx, file, reader)
 		if err1 := file.Close(); err == nil {
 			err = err1
 		}
@@ -768,6 +907,72 @@ func copyBuffered(ctx context.Context, dst io.Writer, src io.Reader) (written in
 
 }
 
+// copySparse copies src into the file like copyBuffered, but skips over
+// runs of zero chunks and sets the final size with truncate, producing
+// holes instead of materializing them. Go's tar reader presents sparse
+// entries as zero filled streams, so this keeps sparse files (VM disks,
+// databases) sparse on apply.
+func copySparse(ctx context.Context, dst *os.File, src io.Reader) (written int64, err error) {
+	buf := bufPool.Get().(*[]byte)
+	defer bufPool.Put(buf)
+
+	var size int64
+	for {
+		select {
+		case <-ctx.Done():
+			return written, ctx.Err()
+		default:
+		}
+
+		nr, er := src.Read(*buf)
+		if nr > 0 {
+			chunk := (*buf)[:nr]
+			if isAllZeroes(chunk) {
+				// Leave a hole; the final truncate extends the file.
+				size += int64(nr)
+			} else {
+				nw, ew := dst.WriteAt(chunk, size)
+				if nw > 0 {
+					written += int64(nw)
+				}
+				if ew != nil {
+					return written, ew
+				}
+				if nr != nw {
+					return written, io.ErrShortWrite
+				}
+				size += int64(nw)
+			}
+		}
+		if er != nil {
+			if er != io.EOF {
+				err = er
+			}
+			break
+		}
+	}
+	if err == nil {
+		err = dst.Truncate(size)
+	}
+	return written, err
+}
+
+// isAllZeroes reports whether the chunk contains only zero bytes.
+func isAllZeroes(p []byte) bool {
+	for len(p) >= 8 {
+		if binary.LittleEndian.Uint64(p) != 0 {
+			return false
+		}
+		p = p[8:]
+	}
+	for _, b := range p {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // hardlinkRootPath returns target linkname, evaluating and bounding any
 // symlink to the parent directory.
 //
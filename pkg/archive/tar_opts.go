@@ -21,6 +21,8 @@ import (
 	"context"
 	"io"
 	"time"
+
+	"github.com/containerd/continuity/fs"
 )
 
 // ApplyOptions provides additional options for an Apply operation
@@ -97,11 +99,38 @@ type WriteDiffOptions struct {
 	//
 	// See also https://reproducible-builds.org/docs/source-date-epoch/ .
 	SourceDateEpoch *time.Time
+
+	// ParallelWalk computes the upper/lower comparison per top level
+	// directory concurrently, which speeds up diffs of very large rootfs
+	// trees. The tar stream itself is still written serially.
+	ParallelWalk bool
+
+	// ChangeFilter, when set, selects which changes are written to the
+	// diff. Returning false drops the change.
+	ChangeFilter func(kind fs.ChangeKind, path string) bool
 }
 
 // WriteDiffOpt allows setting mutable archive write properties on creation
 type WriteDiffOpt func(options *WriteDiffOptions) error
 
+// WithParallelWalk computes the upper/lower comparison with a parallel
+// walker, see WriteDiffOptions.ParallelWalk.
+func WithParallelWalk() WriteDiffOpt {
+	return func(options *WriteDiffOptions) error {
+		options.ParallelWalk = true
+		return nil
+	}
+}
+
+// WithChangeFilter filters the changes written to the diff, see
+// WriteDiffOptions.ChangeFilter.
+func WithChangeFilter(f func(kind fs.ChangeKind, path string) bool) WriteDiffOpt {
+	return func(options *WriteDiffOptions) error {
+		options.ChangeFilter = f
+		return nil
+	}
+}
+
 // WithSourceDateEpoch specifies the SOURCE_DATE_EPOCH without touching the env vars.
 func WithSourceDateEpoch(tm *time.Time) WriteDiffOpt {
 	return func(options *WriteDiffOptions) error {
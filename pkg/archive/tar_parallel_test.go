@@ -0,0 +1,101 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/containerd/continuity/fs"
+	"github.com/containerd/continuity/fs/fstest"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriteDiffParallel checks that the parallel walker produces a diff
+// which applies to the same result as the serial walker.
+func TestWriteDiffParallel(t *testing.T) {
+	ctx := context.Background()
+
+	baseApplier := fstest.Apply(
+		fstest.CreateDir("/dir1", 0755),
+		fstest.CreateFile("/dir1/file1", []byte("file1"), 0644),
+		fstest.CreateDir("/dir2", 0755),
+		fstest.CreateFile("/dir2/file2", []byte("file2"), 0644),
+		fstest.CreateFile("/rootfile", []byte("root"), 0644),
+	)
+	upperApplier := fstest.Apply(
+		fstest.CreateFile("/dir1/file1", []byte("changed"), 0644),
+		fstest.RemoveAll("/dir2"),
+		fstest.CreateDir("/dir3", 0700),
+		fstest.CreateFile("/dir3/file3", []byte("file3"), 0600),
+		fstest.RemoveAll("/rootfile"),
+		fstest.CreateFile("/rootfile2", []byte("root2"), 0644),
+	)
+
+	lower := t.TempDir()
+	require.NoError(t, baseApplier.Apply(lower))
+	upper := t.TempDir()
+	require.NoError(t, baseApplier.Apply(upper))
+	require.NoError(t, upperApplier.Apply(upper))
+
+	for _, tc := range []struct {
+		name string
+		opts []WriteDiffOpt
+	}{
+		{name: "naive"},
+		{name: "parallel", opts: []WriteDiffOpt{WithParallelWalk()}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, WriteDiff(ctx, &buf, lower, upper, tc.opts...))
+
+			dest := t.TempDir()
+			require.NoError(t, baseApplier.Apply(dest))
+			_, err := Apply(ctx, dest, &buf)
+			require.NoError(t, err)
+
+			require.NoError(t, fstest.CheckDirectoryEqual(upper, dest))
+		})
+	}
+}
+
+// TestWriteDiffChangeFilter checks that filtered changes are dropped from
+// the diff stream.
+func TestWriteDiffChangeFilter(t *testing.T) {
+	ctx := context.Background()
+
+	lower := t.TempDir()
+	upper := t.TempDir()
+	require.NoError(t, fstest.Apply(
+		fstest.CreateFile("/keep", []byte("keep"), 0644),
+		fstest.CreateFile("/drop", []byte("drop"), 0644),
+	).Apply(upper))
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteDiff(ctx, &buf, lower, upper, WithChangeFilter(func(kind fs.ChangeKind, path string) bool {
+		return !strings.HasPrefix(path, "/drop")
+	})))
+
+	dest := t.TempDir()
+	_, err := Apply(ctx, dest, &buf)
+	require.NoError(t, err)
+
+	require.FileExists(t, dest+"/keep")
+	require.NoFileExists(t, dest+"/drop")
+}
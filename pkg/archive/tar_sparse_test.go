@@ -0,0 +1,69 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package archive
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAllZeroes(t *testing.T) {
+	assert.True(t, isAllZeroes(nil))
+	assert.True(t, isAllZeroes(make([]byte, 4096)))
+	nonZero := make([]byte, 4096)
+	nonZero[4095] = 1
+	assert.False(t, isAllZeroes(nonZero))
+	assert.False(t, isAllZeroes([]byte{0, 0, 1}))
+}
+
+func TestApplySparseFile(t *testing.T) {
+	// Build a tar containing a file that is mostly zeros.
+	content := make([]byte, 1<<20)
+	copy(content, []byte("head"))
+	copy(content[len(content)-4:], []byte("tail"))
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	require.NoError(t, tw.WriteHeader(&tar.Header{
+		Name:     "sparse",
+		Typeflag: tar.TypeReg,
+		Mode:     0644,
+		Size:     int64(len(content)),
+	}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	dest := t.TempDir()
+	_, err = Apply(context.Background(), dest, &buf)
+	require.NoError(t, err)
+
+	applied, err := os.ReadFile(filepath.Join(dest, "sparse"))
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(content, applied), "applied sparse file differs from source")
+
+	fi, err := os.Stat(filepath.Join(dest, "sparse"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), fi.Size())
+}
@@ -25,6 +25,10 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/containerd/log"
 
 	"github.com/containerd/containerd/v2/defaults"
 )
@@ -92,6 +96,28 @@ func NewFIFOSet(config Config, close func() error) *FIFOSet {
 	return &FIFOSet{Config: config, close: close}
 }
 
+// StdinEOFMode controls what happens to the process's stdin when the
+// client side stream reaches EOF.
+type StdinEOFMode int
+
+const (
+	// StdinEOFClose closes the process's stdin when the client stream
+	// reaches EOF. This is the default behavior.
+	StdinEOFClose StdinEOFMode = iota
+	// StdinEOFKeepOpen leaves the process's stdin open after the client
+	// stream reaches EOF so the stream can be reattached later.
+	StdinEOFKeepOpen
+	// StdinEOFSendEOT writes an EOT sequence to the process's stdin after
+	// the client stream reaches EOF and leaves stdin open. This is mainly
+	// useful for TTY sessions where the foreground process interprets the
+	// sequence, e.g. a shell exiting on ^D.
+	StdinEOFSendEOT
+)
+
+// defaultEOTSequence is written for StdinEOFSendEOT when no sequence has
+// been configured with WithStdinEOT.
+var defaultEOTSequence = []byte{0x04}
+
 // Streams used to configure a Creator or Attach
 type Streams struct {
 	Stdin    io.Reader
@@ -99,6 +125,17 @@ type Streams struct {
 	Stderr   io.Writer
 	Terminal bool
 	FIFODir  string
+	// StdinEOFMode controls the handling of the process's stdin once
+	// Stdin reaches EOF, see StdinEOFMode for the available behaviors.
+	StdinEOFMode StdinEOFMode
+	// StdinEOT is the sequence written for StdinEOFSendEOT. When empty,
+	// a single EOT character (0x04) is written.
+	StdinEOT []byte
+	// IdleTimeout closes an attach session when no data has flowed in
+	// either direction for the duration, so abandoned sessions do not pin
+	// FIFO readers and copy goroutines forever. Zero disables idle
+	// detection.
+	IdleTimeout time.Duration
 }
 
 // Opt customize options for creating a Creator or Attach
@@ -123,6 +160,31 @@ func WithStreams(stdin io.Reader, stdout, stderr io.Writer) Opt {
 	}
 }
 
+// WithIdleTimeout closes the attach session when it has been idle for the
+// duration, see Streams.IdleTimeout.
+func WithIdleTimeout(d time.Duration) Opt {
+	return func(opt *Streams) {
+		opt.IdleTimeout = d
+	}
+}
+
+// WithStdinEOFMode sets the behavior applied to the process's stdin when
+// the client stream reaches EOF.
+func WithStdinEOFMode(mode StdinEOFMode) Opt {
+	return func(opt *Streams) {
+		opt.StdinEOFMode = mode
+	}
+}
+
+// WithStdinEOT sets the sequence written to the process's stdin when the
+// client stream reaches EOF and implies StdinEOFSendEOT.
+func WithStdinEOT(seq []byte) Opt {
+	return func(opt *Streams) {
+		opt.StdinEOFMode = StdinEOFSendEOT
+		opt.StdinEOT = seq
+	}
+}
+
 // WithFIFODir sets the fifo directory.
 // e.g. "/run/containerd/fifo", "/run/users/1001/containerd/fifo"
 func WithFIFODir(dir string) Opt {
@@ -177,7 +239,65 @@ func NewAttach(opts ...Opt) Attach {
 		if streams.Stderr == nil {
 			fifos.Stderr = ""
 		}
-		return copyIO(fifos, streams)
+		var tracker *idleTracker
+		if streams.IdleTimeout > 0 {
+			tracker = newIdleTracker(streams)
+		}
+		io, err := copyIO(fifos, streams)
+		if err == nil && tracker != nil {
+			go tracker.watch(streams.IdleTimeout, io)
+		}
+		return io, err
+	}
+}
+
+// FileSetIO binds the task's stdio directly to pre-existing host paths
+// (regular files, FIFOs or unix sockets). The runtime opens the paths
+// itself, so no FIFOs are created and no copy goroutines run, giving
+// embedders with their own IO transport zero-copy wiring. Empty paths
+// leave the corresponding stream unconnected.
+func FileSetIO(stdin, stdout, stderr string) Creator {
+	return func(_ string) (IO, error) {
+		return &cio{
+			config: Config{
+				Stdin:  stdin,
+				Stdout: stdout,
+				Stderr: stderr,
+			},
+		}, nil
+	}
+}
+
+// FilesIO binds the task's stdio directly to the provided files, which
+// must refer to host paths the runtime can open (see FileSetIO). The
+// files are closed when the IO is closed.
+func FilesIO(stdin, stdout, stderr *os.File) Creator {
+	return func(_ string) (IO, error) {
+		var (
+			config  Config
+			closers []io.Closer
+		)
+		for _, f := range []struct {
+			file *os.File
+			path *string
+		}{
+			{stdin, &config.Stdin},
+			{stdout, &config.Stdout},
+			{stderr, &config.Stderr},
+		} {
+			if f.file == nil {
+				continue
+			}
+			if f.file.Name() == "" {
+				return nil, fmt.Errorf("file for direct IO has no path")
+			}
+			*f.path = f.file.Name()
+			closers = append(closers, f.file)
+		}
+		return &cio{
+			config:  config,
+			closers: closers,
+		}, nil
 	}
 }
 
@@ -323,6 +443,27 @@ func LogFile(path string) Creator {
 	}
 }
 
+// LogFileCRI creates a file log URI like LogFile, but the shim frames
+// each line in the CRI log format
+// ("<RFC3339Nano> <stream> <P|F> <content>"), so standalone containerd
+// logs are ingestible by existing Kubernetes log pipelines.
+func LogFileCRI(path string) Creator {
+	return func(_ string) (IO, error) {
+		uri, err := LogURIGenerator("file", path, map[string]string{"framing": "cri"})
+		if err != nil {
+			return nil, err
+		}
+
+		res := uri.String()
+		return &logURI{
+			config: Config{
+				Stdout: res,
+				Stderr: res,
+			},
+		}, nil
+	}
+}
+
 // LogURIGenerator is the helper to generate log uri with specific scheme.
 func LogURIGenerator(scheme string, path string, args map[string]string) (*url.URL, error) {
 	path = filepath.Clean(path)
@@ -388,3 +529,98 @@ func Load(set *FIFOSet) (IO, error) {
 func (p *pipes) closers() []io.Closer {
 	return []io.Closer{p.Stdin, p.Stdout, p.Stderr}
 }
+
+// handleStdinEOF applies the configured StdinEOFMode to the process's
+// stdin after the client stream has reached EOF. It reports whether stdin
+// has been closed.
+func handleStdinEOF(stdin io.WriteCloser, streams *Streams) bool {
+	switch streams.StdinEOFMode {
+	case StdinEOFKeepOpen:
+		return false
+	case StdinEOFSendEOT:
+		eot := streams.StdinEOT
+		if len(eot) == 0 {
+			eot = defaultEOTSequence
+		}
+		stdin.Write(eot)
+		return false
+	default:
+		stdin.Close()
+		return true
+	}
+}
+
+// idleTracker wraps the attach streams to record when data last flowed,
+// cancelling the session once it has been idle beyond the timeout.
+type idleTracker struct {
+	last atomic.Int64
+}
+
+func newIdleTracker(streams *Streams) *idleTracker {
+	t := &idleTracker{}
+	t.touch()
+	if streams.Stdin != nil {
+		streams.Stdin = &activityReader{Reader: streams.Stdin, tracker: t}
+	}
+	if streams.Stdout != nil {
+		streams.Stdout = &activityWriter{Writer: streams.Stdout, tracker: t}
+	}
+	if streams.Stderr != nil {
+		streams.Stderr = &activityWriter{Writer: streams.Stderr, tracker: t}
+	}
+	return t
+}
+
+func (t *idleTracker) touch() {
+	t.last.Store(time.Now().UnixNano())
+}
+
+func (t *idleTracker) idleFor() time.Duration {
+	return time.Since(time.Unix(0, t.last.Load()))
+}
+
+// watch cancels and closes the IO once the session has been idle beyond
+// the timeout.
+func (t *idleTracker) watch(timeout time.Duration, io IO) {
+	interval := timeout / 4
+	if interval < time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if t.idleFor() < timeout {
+			continue
+		}
+		log.L.Warnf("closing attach session idle for more than %s", timeout)
+		io.Cancel()
+		io.Close()
+		return
+	}
+}
+
+type activityReader struct {
+	io.Reader
+	tracker *idleTracker
+}
+
+func (r *activityReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	if n > 0 {
+		r.tracker.touch()
+	}
+	return n, err
+}
+
+type activityWriter struct {
+	io.Writer
+	tracker *idleTracker
+}
+
+func (w *activityWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	if n > 0 {
+		w.tracker.touch()
+	}
+	return n, err
+}
@@ -19,6 +19,8 @@ package cio
 import (
 	"fmt"
 	"net/url"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
@@ -105,3 +107,80 @@ func baseTestLogURIGenerator(t *testing.T, testCases []LogURIGeneratorTestCase)
 		assert.Equal(t, tc.expected, uri.String())
 	}
 }
+
+type stdinEOFWriteCloser struct {
+	written []byte
+	closed  bool
+}
+
+func (w *stdinEOFWriteCloser) Write(p []byte) (int, error) {
+	w.written = append(w.written, p...)
+	return len(p), nil
+}
+
+func (w *stdinEOFWriteCloser) Close() error {
+	w.closed = true
+	return nil
+}
+
+func TestHandleStdinEOF(t *testing.T) {
+	t.Run("close", func(t *testing.T) {
+		w := &stdinEOFWriteCloser{}
+		assert.True(t, handleStdinEOF(w, &Streams{}))
+		assert.True(t, w.closed)
+		assert.Empty(t, w.written)
+	})
+	t.Run("keep open", func(t *testing.T) {
+		w := &stdinEOFWriteCloser{}
+		assert.False(t, handleStdinEOF(w, &Streams{StdinEOFMode: StdinEOFKeepOpen}))
+		assert.False(t, w.closed)
+		assert.Empty(t, w.written)
+	})
+	t.Run("default EOT", func(t *testing.T) {
+		w := &stdinEOFWriteCloser{}
+		streams := &Streams{}
+		WithStdinEOFMode(StdinEOFSendEOT)(streams)
+		assert.False(t, handleStdinEOF(w, streams))
+		assert.False(t, w.closed)
+		assert.Equal(t, []byte{0x04}, w.written)
+	})
+	t.Run("custom EOT", func(t *testing.T) {
+		w := &stdinEOFWriteCloser{}
+		streams := &Streams{}
+		WithStdinEOT([]byte("exit\n"))(streams)
+		assert.Equal(t, StdinEOFSendEOT, streams.StdinEOFMode)
+		assert.False(t, handleStdinEOF(w, streams))
+		assert.False(t, w.closed)
+		assert.Equal(t, []byte("exit\n"), w.written)
+	})
+}
+
+func TestFileSetIO(t *testing.T) {
+	res, err := FileSetIO(prefix+"/in", prefix+"/out", prefix+"/err")("task")
+	require.NoError(t, err)
+	cfg := res.Config()
+	assert.Equal(t, prefix+"/in", cfg.Stdin)
+	assert.Equal(t, prefix+"/out", cfg.Stdout)
+	assert.Equal(t, prefix+"/err", cfg.Stderr)
+	assert.NoError(t, res.Close())
+}
+
+func TestFilesIO(t *testing.T) {
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	require.NoError(t, err)
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	require.NoError(t, err)
+
+	res, err := FilesIO(nil, stdout, stderr)("task")
+	require.NoError(t, err)
+	cfg := res.Config()
+	assert.Empty(t, cfg.Stdin)
+	assert.Equal(t, stdout.Name(), cfg.Stdout)
+	assert.Equal(t, stderr.Name(), cfg.Stderr)
+
+	// Close closes the provided files.
+	require.NoError(t, res.Close())
+	_, err = stdout.Write([]byte("x"))
+	assert.Error(t, err)
+}
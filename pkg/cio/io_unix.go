@@ -66,7 +66,7 @@ func copyIO(fifos *FIFOSet, ioset *Streams) (*cio, error) {
 			defer bufPool.Put(p)
 
 			io.CopyBuffer(pipes.Stdin, ioset.Stdin, *p)
-			pipes.Stdin.Close()
+			handleStdinEOF(pipes.Stdin, ioset)
 		}()
 	}
 
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 
 	winio "github.com/Microsoft/go-winio"
 	"github.com/containerd/log"
@@ -68,8 +69,9 @@ func copyIO(fifos *FIFOSet, ioset *Streams) (_ *cio, retErr error) {
 			defer bufPool.Put(p)
 
 			io.CopyBuffer(c, ioset.Stdin, *p)
-			c.Close()
-			l.Close()
+			if handleStdinEOF(c, ioset) {
+				l.Close()
+			}
 		}()
 	}
 
@@ -80,20 +82,7 @@ func copyIO(fifos *FIFOSet, ioset *Streams) (_ *cio, retErr error) {
 		}
 		cios.closers = append(cios.closers, l)
 
-		go func() {
-			c, err := l.Accept()
-			if err != nil {
-				log.L.WithError(err).Errorf("failed to accept stdout connection on %s", fifos.Stdout)
-				return
-			}
-
-			p := bufPool.Get().(*[]byte)
-			defer bufPool.Put(p)
-
-			io.CopyBuffer(ioset.Stdout, c, *p)
-			c.Close()
-			l.Close()
-		}()
+		go copyOutputReconnect(l, ioset.Stdout, fifos.Stdout)
 	}
 
 	if fifos.Stderr != "" {
@@ -103,20 +92,7 @@ func copyIO(fifos *FIFOSet, ioset *Streams) (_ *cio, retErr error) {
 		}
 		cios.closers = append(cios.closers, l)
 
-		go func() {
-			c, err := l.Accept()
-			if err != nil {
-				log.L.WithError(err).Errorf("failed to accept stderr connection on %s", fifos.Stderr)
-				return
-			}
-
-			p := bufPool.Get().(*[]byte)
-			defer bufPool.Put(p)
-
-			io.CopyBuffer(ioset.Stderr, c, *p)
-			c.Close()
-			l.Close()
-		}()
+		go copyOutputReconnect(l, ioset.Stderr, fifos.Stderr)
 	}
 
 	return cios, nil
@@ -155,3 +131,29 @@ func NewDirectIOFromFIFOSet(ctx context.Context, stdin io.WriteCloser, stdout, s
 		},
 	}
 }
+
+// copyOutputReconnect copies an output pipe to the destination writer,
+// re-accepting when the writer side reconnects. This mirrors the FIFO
+// reopen behavior on Linux, so a restarted log collector (e.g. during log
+// rotation) resumes receiving output instead of dropping it; data written
+// while no client is connected stays buffered in the pipe.
+func copyOutputReconnect(l net.Listener, dst io.Writer, name string) {
+	defer l.Close()
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			// The listener was closed by the IO owner.
+			return
+		}
+
+		p := bufPool.Get().(*[]byte)
+		_, err = io.CopyBuffer(dst, c, *p)
+		bufPool.Put(p)
+		c.Close()
+		if err != nil {
+			log.L.WithError(err).Errorf("error copying output from %s", name)
+			return
+		}
+		// EOF: the peer disconnected, wait for a reconnect.
+	}
+}
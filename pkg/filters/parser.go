@@ -38,13 +38,21 @@ test for the presence of a value, as defined by the target object.
 
 The formal grammar is as follows:
 
-selectors := selector ("," selector)*
+selectors := clause ("," clause)*
+clause    := unit ("||" unit)*
+unit      := "(" selectors ")" | selector
 selector  := fieldpath (operator value)
 fieldpath := field ('.' field)*
 field     := quoted | [A-Za-z] [A-Za-z0-9_]+
 operator  := "==" | "!=" | "~="
-value     := quoted | [^\s,]+
+value     := quoted | [^\s,|)]+
 quoted    := <go string syntax>
+
+Clauses separated by "," must all match, while units separated by "||"
+within a clause form an OR expression, and parentheses group selectors, so
+queries like `(labels.a==x||labels.b==y),runtime.name==io.containerd.runc.v2`
+can be expressed in one filter. Unquoted values cannot contain `|` or `)`;
+quote such values.
 */
 func Parse(s string) (Filter, error) {
 	// special case empty to match all
@@ -91,11 +99,15 @@ func (p *parser) parse() (Filter, error) {
 		return nil, fmt.Errorf("filters: %w", err)
 	}
 
+	if pos, tok, _ := p.scanner.scan(); tok != tokenEOF {
+		return nil, fmt.Errorf("filters: %w", p.mkerr(pos, "unexpected trailing input"))
+	}
+
 	return ss, nil
 }
 
 func (p *parser) selectors() (Filter, error) {
-	s, err := p.selector()
+	s, err := p.clause()
 	if err != nil {
 		return nil, err
 	}
@@ -112,12 +124,15 @@ loop:
 				return nil, p.mkerr(pos, "expected a separator")
 			}
 
-			s, err := p.selector()
+			s, err := p.clause()
 			if err != nil {
 				return nil, err
 			}
 
 			ss = append(ss, s)
+		case ')':
+			// closing a parenthesized group, handled by the caller
+			break loop
 		case tokenEOF:
 			break loop
 		default:
@@ -128,6 +143,53 @@ loop:
 	return ss, nil
 }
 
+// clause parses a unit optionally followed by "||" separated alternatives.
+func (p *parser) clause() (Filter, error) {
+	f, err := p.unit()
+	if err != nil {
+		return nil, err
+	}
+
+	or := Any{f}
+	for p.scanner.peek() == '|' {
+		// "||" scans as an empty alternative quoted token
+		pos, tok, text := p.scanner.scan()
+		if tok != tokenQuoted || text != "||" {
+			return nil, p.mkerr(pos, "expected '||'")
+		}
+
+		u, err := p.unit()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, u)
+	}
+	if len(or) == 1 {
+		return f, nil
+	}
+	return or, nil
+}
+
+// unit parses either a parenthesized group of selectors or a single
+// selector.
+func (p *parser) unit() (Filter, error) {
+	if p.scanner.peek() != '(' {
+		return p.selector()
+	}
+	p.scanner.scan() // consume '('
+
+	f, err := p.selectors()
+	if err != nil {
+		return nil, err
+	}
+
+	pos, tok, _ := p.scanner.scan()
+	if tok != ')' {
+		return nil, p.mkerr(pos, "expected ')'")
+	}
+	return f, nil
+}
+
 func (p *parser) selector() (selector, error) {
 	fieldpath, err := p.fieldpath()
 	if err != nil {
@@ -135,7 +197,7 @@ func (p *parser) selector() (selector, error) {
 	}
 
 	switch p.scanner.peek() {
-	case ',', tokenSeparator, tokenEOF:
+	case ',', tokenSeparator, tokenEOF, '|', ')':
 		return selector{
 			fieldpath: fieldpath,
 			operator:  operatorPresent,
@@ -0,0 +1,110 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package filters
+
+import (
+	"testing"
+)
+
+func TestParseOrGrouping(t *testing.T) {
+	type entry struct {
+		Name    string
+		Runtime string
+		Labels  map[string]string
+	}
+	corpus := []entry{
+		{Name: "c1", Runtime: "io.containerd.runc.v2", Labels: map[string]string{"a": "x"}},
+		{Name: "c2", Runtime: "io.containerd.runc.v2", Labels: map[string]string{"b": "y"}},
+		{Name: "c3", Runtime: "io.containerd.runc.v2", Labels: map[string]string{"a": "z"}},
+		{Name: "c4", Runtime: "io.containerd.kata.v2", Labels: map[string]string{"a": "x"}},
+	}
+	adapt := func(e entry) Adaptor {
+		return AdapterFunc(func(fieldpath []string) (string, bool) {
+			switch fieldpath[0] {
+			case "name":
+				return e.Name, true
+			case "runtime":
+				if len(fieldpath) > 1 && fieldpath[1] == "name" {
+					return e.Runtime, true
+				}
+			case "labels":
+				v, ok := e.Labels[fieldpath[1]]
+				return v, ok
+			}
+			return "", false
+		})
+	}
+
+	for _, tc := range []struct {
+		filter string
+		want   []string
+	}{
+		{
+			filter: "labels.a==x||labels.b==y",
+			want:   []string{"c1", "c2", "c4"},
+		},
+		{
+			filter: "(labels.a==x||labels.b==y),runtime.name==io.containerd.runc.v2",
+			want:   []string{"c1", "c2"},
+		},
+		{
+			filter: "(name==c1),(name==c1||name==c4)",
+			want:   []string{"c1"},
+		},
+		{
+			filter: "labels.a||labels.b",
+			want:   []string{"c1", "c2", "c3", "c4"},
+		},
+		{
+			filter: "(labels.a==x,runtime.name==io.containerd.kata.v2)||name==c2",
+			want:   []string{"c2", "c4"},
+		},
+	} {
+		t.Run(tc.filter, func(t *testing.T) {
+			f, err := Parse(tc.filter)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tc.filter, err)
+			}
+			var got []string
+			for _, e := range corpus {
+				if f.Match(adapt(e)) {
+					got = append(got, e.Name)
+				}
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Fatalf("expected %v, got %v", tc.want, got)
+				}
+			}
+		})
+	}
+
+	for _, invalid := range []string{
+		"(name==c1",
+		"name==c1)",
+		"name==c1||",
+		"||name==c1",
+		"(|name==c1)",
+	} {
+		if _, err := Parse(invalid); err == nil {
+			t.Errorf("expected error parsing %q", invalid)
+		}
+	}
+}
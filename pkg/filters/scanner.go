@@ -288,7 +288,7 @@ func isSeparatorRune(r rune) bool {
 }
 
 func isValueRune(r rune) bool {
-	return r != ',' && !unicode.IsSpace(r) &&
+	return r != ',' && r != '|' && r != ')' && !unicode.IsSpace(r) &&
 		(unicode.IsLetter(r) ||
 			unicode.IsDigit(r) ||
 			unicode.IsNumber(r) ||
@@ -18,6 +18,9 @@ package labels
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/containerd/errdefs"
 )
@@ -28,7 +31,9 @@ const (
 	keyMaxLen = 64
 )
 
-// Validate a label's key and value are under 4096 bytes
+// Validate a label's key and value: the combined size must be under 4096
+// bytes, the key must be non-empty without whitespace or control
+// characters, and both must be valid UTF-8.
 func Validate(k, v string) error {
 	total := len(k) + len(v)
 	if total > maxSize {
@@ -37,5 +42,26 @@ func Validate(k, v string) error {
 		}
 		return fmt.Errorf("label key and value length (%d bytes) greater than maximum size (%d bytes), key: %s: %w", total, maxSize, k, errdefs.ErrInvalidArgument)
 	}
+	if k == "" {
+		return fmt.Errorf("label key must not be empty: %w", errdefs.ErrInvalidArgument)
+	}
+	if !utf8.ValidString(k) || !utf8.ValidString(v) {
+		return fmt.Errorf("label key and value must be valid UTF-8, key: %s: %w", truncateKey(k), errdefs.ErrInvalidArgument)
+	}
+	if strings.IndexFunc(k, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	}) >= 0 {
+		return fmt.Errorf("label key must not contain whitespace or control characters, key: %s: %w", truncateKey(k), errdefs.ErrInvalidArgument)
+	}
+	if strings.ContainsFunc(v, unicode.IsControl) {
+		return fmt.Errorf("label value must not contain control characters, key: %s: %w", truncateKey(k), errdefs.ErrInvalidArgument)
+	}
 	return nil
 }
+
+func truncateKey(k string) string {
+	if len(k) > keyMaxLen {
+		return k[:keyMaxLen]
+	}
+	return k
+}
@@ -78,3 +78,18 @@ func TestLongKey(t *testing.T) {
 	err = Validate(key, value)
 	assert.Equal(t, err, nil)
 }
+
+func TestValidateCharset(t *testing.T) {
+	// Empty keys and keys with whitespace or control characters are
+	// rejected.
+	for _, k := range []string{"", "foo bar", "foo\tbar", "foo\nbar", "foo\x00"} {
+		assert.Error(t, Validate(k, "value"), k)
+	}
+
+	// Values must be UTF-8 without control characters.
+	assert.Error(t, Validate("key", "bad\x00value"))
+	assert.Error(t, Validate("key", string([]byte{0xff, 0xfe})))
+
+	assert.NoError(t, Validate("containerd.io/restart.status", "running"))
+	assert.NoError(t, Validate("key", "value with spaces"))
+}
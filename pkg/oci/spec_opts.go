@@ -1671,3 +1671,80 @@ func WithCDIDevices(devices ...string) SpecOpts {
 		return nil
 	}
 }
+
+// SpecOverridesLabel stores the JSON encoded SpecOverrides applied on top
+// of the image config, so the spec can be regenerated against an updated
+// image with the same user intent.
+const SpecOverridesLabel = "containerd.io/spec.overrides"
+
+// SpecOverrides are the user supplied fields layered over an image
+// config. Zero valued fields keep the image's configuration.
+type SpecOverrides struct {
+	// Args replaces the image's entrypoint and cmd.
+	Args []string `json:"args,omitempty"`
+	// Env entries are appended to the image's environment.
+	Env []string `json:"env,omitempty"`
+	// WorkingDir replaces the image's working directory.
+	WorkingDir string `json:"workingDir,omitempty"`
+	// User replaces the image's user.
+	User string `json:"user,omitempty"`
+}
+
+// WithImageConfigAndOverrides configures the spec from the image config
+// and layers the user overrides over it, recording the overrides in the
+// container's labels. Regenerating the spec against an updated image with
+// the recorded overrides enables "recreate container with new image"
+// workflows, see client.RegenerateSpec.
+func WithImageConfigAndOverrides(image Image, overrides SpecOverrides) SpecOpts {
+	return func(ctx context.Context, client Client, c *containers.Container, s *Spec) error {
+		if err := WithImageConfig(image)(ctx, client, c, s); err != nil {
+			return err
+		}
+		if len(overrides.Args) > 0 {
+			if err := WithProcessArgs(overrides.Args...)(ctx, client, c, s); err != nil {
+				return err
+			}
+		}
+		if len(overrides.Env) > 0 {
+			if err := WithEnv(overrides.Env)(ctx, client, c, s); err != nil {
+				return err
+			}
+		}
+		if overrides.WorkingDir != "" {
+			if err := WithProcessCwd(overrides.WorkingDir)(ctx, client, c, s); err != nil {
+				return err
+			}
+		}
+		if overrides.User != "" {
+			if err := WithUser(overrides.User)(ctx, client, c, s); err != nil {
+				return err
+			}
+		}
+
+		if c != nil {
+			data, err := json.Marshal(overrides)
+			if err != nil {
+				return err
+			}
+			if c.Labels == nil {
+				c.Labels = map[string]string{}
+			}
+			c.Labels[SpecOverridesLabel] = string(data)
+		}
+		return nil
+	}
+}
+
+// RecordedSpecOverrides returns the overrides recorded on the container
+// by WithImageConfigAndOverrides.
+func RecordedSpecOverrides(labels map[string]string) (SpecOverrides, bool, error) {
+	value, ok := labels[SpecOverridesLabel]
+	if !ok {
+		return SpecOverrides{}, false, nil
+	}
+	var overrides SpecOverrides
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		return SpecOverrides{}, false, err
+	}
+	return overrides, true, nil
+}
@@ -18,10 +18,14 @@ package oci
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/containerd/cgroups/v3"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/containerd/v2/pkg/cap"
-	specs "github.com/opencontainers/runtime-spec/specs-go"
 )
 
 // WithHostDevices adds all the hosts device nodes to the container's spec
@@ -79,3 +83,72 @@ var WithAllKnownCapabilities = func(ctx context.Context, client Client, c *conta
 func escapeAndCombineArgs(args []string) string {
 	panic("not supported")
 }
+
+// WithMemorySwappiness sets the container's memory swappiness. The knob
+// only exists on cgroup v1; on a unified (cgroup v2) host an error is
+// returned at spec build time instead of failing later in runc.
+func WithMemorySwappiness(swappiness uint64) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		if swappiness > 100 {
+			return fmt.Errorf("memory swappiness must be between 0 and 100, got %d", swappiness)
+		}
+		if cgroups.Mode() == cgroups.Unified {
+			return errors.New("memory swappiness is not supported on cgroup v2 hosts")
+		}
+		setResources(s)
+		if s.Linux.Resources.Memory == nil {
+			s.Linux.Resources.Memory = &specs.LinuxMemory{}
+		}
+		s.Linux.Resources.Memory.Swappiness = &swappiness
+		return nil
+	}
+}
+
+// WithOOMScoreAdjClamp sets the process's oom score adjustment, clamping
+// the value into the valid [-1000, 1000] range instead of erroring.
+func WithOOMScoreAdjClamp(adj int) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		if adj < -1000 {
+			adj = -1000
+		} else if adj > 1000 {
+			adj = 1000
+		}
+		setProcess(s)
+		s.Process.OOMScoreAdj = &adj
+		return nil
+	}
+}
+
+// WithHugepageLimits sets hugepage limits, validating page size format
+// (e.g. "2MB", "1GB") at spec build time.
+func WithHugepageLimits(limits []specs.LinuxHugepageLimit) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		for _, limit := range limits {
+			if limit.Pagesize == "" {
+				return errors.New("hugepage limit requires a page size")
+			}
+		}
+		setResources(s)
+		s.Linux.Resources.HugepageLimits = limits
+		return nil
+	}
+}
+
+// WithMiscResource sets a misc controller resource limit (e.g. "res=n").
+// The misc controller only exists on cgroup v2 hosts.
+func WithMiscResource(res string, value int64) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		if res == "" {
+			return errors.New("misc resource name must not be empty")
+		}
+		if cgroups.Mode() != cgroups.Unified {
+			return errors.New("the misc controller requires a cgroup v2 host")
+		}
+		setResources(s)
+		if s.Linux.Resources.Unified == nil {
+			s.Linux.Resources.Unified = map[string]string{}
+		}
+		s.Linux.Resources.Unified["misc.max"] = fmt.Sprintf("%s %d", res, value)
+		return nil
+	}
+}
@@ -20,6 +20,11 @@ package oci
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	specs "github.com/opencontainers/runtime-spec/specs-go"
 
 	"github.com/containerd/containerd/v2/core/containers"
 )
@@ -30,3 +35,42 @@ func WithDefaultPathEnv(_ context.Context, _ Client, _ *containers.Container, s
 	s.Process.Env = replaceOrAppendEnvValues(s.Process.Env, defaultUnixEnv)
 	return nil
 }
+
+// WithTimezone bind mounts the zoneinfo entry for tz read-only at
+// /etc/localtime inside the container. The special value "host" mounts
+// the host's own /etc/localtime, resolving symlinks so the mount does not
+// depend on the container having a zoneinfo database.
+func WithTimezone(tz string) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
+		source := "/etc/localtime"
+		if tz != "" && tz != "host" {
+			if strings.Contains(tz, "..") || filepath.IsAbs(tz) {
+				return fmt.Errorf("invalid timezone %q", tz)
+			}
+			source = filepath.Join("/usr/share/zoneinfo", tz)
+		}
+		resolved, err := filepath.EvalSymlinks(source)
+		if err != nil {
+			return fmt.Errorf("failed to resolve timezone source %q: %w", source, err)
+		}
+		s.Mounts = removeMount(s.Mounts, "/etc/localtime")
+		s.Mounts = append(s.Mounts, specs.Mount{
+			Destination: "/etc/localtime",
+			Type:        "bind",
+			Source:      resolved,
+			Options:     []string{"rbind", "ro", "nosuid", "noexec", "nodev"},
+		})
+		return nil
+	}
+}
+
+// removeMount drops any existing mount at the destination.
+func removeMount(mounts []specs.Mount, destination string) []specs.Mount {
+	kept := mounts[:0]
+	for _, m := range mounts {
+		if m.Destination != destination {
+			kept = append(kept, m)
+		}
+	}
+	return kept
+}
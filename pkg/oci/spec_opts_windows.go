@@ -67,3 +67,11 @@ func WithDevices(devicePath, containerPath, permissions string) SpecOpts {
 func WithDefaultPathEnv(_ context.Context, _ Client, _ *containers.Container, s *Spec) error {
 	return nil
 }
+
+// WithTimezone is a no-op on Windows, where /etc/localtime has no
+// equivalent; the container uses the host clock and timezone services.
+func WithTimezone(tz string) SpecOpts {
+	return func(_ context.Context, _ Client, _ *containers.Container, _ *Spec) error {
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package pagination implements the page token handling shared by the
+// paginated List endpoints, so dense nodes do not have to hold tens of
+// thousands of records in one gRPC message.
+//
+// Items are expected to be sorted by a stable key; the page token is the
+// opaque encoding of the last returned key and listing resumes strictly
+// after it. Tokens stay valid across mutations since they only name a
+// position in key order.
+package pagination
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/containerd/errdefs"
+)
+
+// Page slices the sorted items into one page. The key function returns
+// the stable sort key of an item. It returns the page and the token for
+// the next page; the token is empty when no items remain.
+func Page[T any](items []T, key func(T) string, pageSize int32, pageToken string) ([]T, string, error) {
+	if pageToken != "" {
+		after, err := decodeToken(pageToken)
+		if err != nil {
+			return nil, "", err
+		}
+		start := 0
+		for start < len(items) && key(items[start]) <= after {
+			start++
+		}
+		items = items[start:]
+	}
+	if pageSize <= 0 || int(pageSize) >= len(items) {
+		return items, "", nil
+	}
+	items = items[:pageSize]
+	return items, encodeToken(key(items[len(items)-1])), nil
+}
+
+func encodeToken(lastKey string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(lastKey))
+}
+
+func decodeToken(token string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid page token: %w", errdefs.ErrInvalidArgument)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,68 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package pagination
+
+import (
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPage(t *testing.T) {
+	items := []string{"a", "b", "c", "d", "e"}
+	self := func(s string) string { return s }
+
+	// Zero page size returns everything.
+	page, token, err := Page(items, self, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, items, page)
+	assert.Empty(t, token)
+
+	// Walk through in pages of two.
+	var got []string
+	token = ""
+	for {
+		page, next, err := Page(items, self, 2, token)
+		require.NoError(t, err)
+		got = append(got, page...)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	assert.Equal(t, items, got)
+
+	// A page size covering the remainder returns no token.
+	page, token, err = Page(items, self, 5, "")
+	require.NoError(t, err)
+	assert.Len(t, page, 5)
+	assert.Empty(t, token)
+
+	// Tokens survive removal of the item they point at.
+	page, token, err = Page(items, self, 2, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, page)
+	shrunk := []string{"a", "c", "d"}
+	page, _, err = Page(shrunk, self, 2, token)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "d"}, page)
+
+	_, _, err = Page(items, self, 2, "!!notbase64!!")
+	assert.True(t, errdefs.IsInvalidArgument(err))
+}
@@ -63,9 +63,26 @@ type BootstrapParams struct {
 	// Version is the version of shim parameters (expected 2 for shim v2)
 	Version int `json:"version"`
 	// Address is a address containerd should use to connect to shim.
+	// Besides unix socket paths, "vsock://<cid>:<port>" and
+	// "tcp://<host>:<port>" addresses are supported for shims running
+	// inside VMs or on remote hosts.
 	Address string `json:"address"`
 	// Protocol is either TTRPC or GRPC.
 	Protocol string `json:"protocol"`
+	// TLS optionally configures mutual TLS for tcp addresses.
+	TLS *TLSParams `json:"tls,omitempty"`
+}
+
+// TLSParams holds the mTLS material used to connect to a shim serving a
+// tcp address.
+type TLSParams struct {
+	// CertFile is the path to the client certificate.
+	CertFile string `json:"certFile"`
+	// KeyFile is the path to the client key.
+	KeyFile string `json:"keyFile"`
+	// CAFile is the path to the CA certificate used to verify the shim's
+	// serving certificate.
+	CAFile string `json:"caFile"`
 }
 
 type StopStatus struct {
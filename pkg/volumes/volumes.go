@@ -0,0 +1,184 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package volumes provides a minimal native volume service for standalone
+// containerd users: named local directories that can be created, listed,
+// deleted and attached to containers through a SpecOpt, replacing ad-hoc
+// host-path bind mounts.
+package volumes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/containerd/v2/core/containers"
+	"github.com/containerd/containerd/v2/pkg/oci"
+	"github.com/containerd/errdefs"
+)
+
+// metaFile is the per-volume metadata file name.
+const metaFile = "volume.json"
+
+// dataDir is the per-volume data directory mounted into containers.
+const dataDir = "data"
+
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Volume is a named local directory managed by the volume store.
+type Volume struct {
+	// Name of the volume.
+	Name string `json:"name"`
+	// Path is the host directory holding the volume data.
+	Path string `json:"path"`
+	// Labels attached to the volume.
+	Labels map[string]string `json:"labels,omitempty"`
+	// CreatedAt is when the volume was created.
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Store manages volumes under a root directory.
+type Store struct {
+	mu   sync.Mutex
+	root string
+}
+
+// NewStore returns a volume store rooted at root.
+func NewStore(root string) (*Store, error) {
+	if err := os.MkdirAll(root, 0711); err != nil {
+		return nil, err
+	}
+	return &Store{root: root}, nil
+}
+
+// Create creates a named volume with the labels.
+func (s *Store) Create(name string, labels map[string]string) (Volume, error) {
+	if !nameRe.MatchString(name) {
+		return Volume{}, fmt.Errorf("invalid volume name %q: %w", name, errdefs.ErrInvalidArgument)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.root, name)
+	if _, err := os.Stat(dir); err == nil {
+		return Volume{}, fmt.Errorf("volume %q: %w", name, errdefs.ErrAlreadyExists)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, dataDir), 0755); err != nil {
+		return Volume{}, err
+	}
+	v := Volume{
+		Name:      name,
+		Path:      filepath.Join(dir, dataDir),
+		Labels:    labels,
+		CreatedAt: time.Now(),
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Volume{}, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, metaFile), data, 0600); err != nil {
+		os.RemoveAll(dir)
+		return Volume{}, err
+	}
+	return v, nil
+}
+
+// Get returns the named volume.
+func (s *Store) Get(name string) (Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.get(name)
+}
+
+func (s *Store) get(name string) (Volume, error) {
+	data, err := os.ReadFile(filepath.Join(s.root, name, metaFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Volume{}, fmt.Errorf("volume %q: %w", name, errdefs.ErrNotFound)
+		}
+		return Volume{}, err
+	}
+	var v Volume
+	if err := json.Unmarshal(data, &v); err != nil {
+		return Volume{}, fmt.Errorf("corrupt metadata of volume %q: %w", name, err)
+	}
+	return v, nil
+}
+
+// List returns all volumes sorted by name.
+func (s *Store) List() ([]Volume, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries, err := os.ReadDir(s.root)
+	if err != nil {
+		return nil, err
+	}
+	var volumes []Volume
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v, err := s.get(e.Name())
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				continue
+			}
+			return nil, err
+		}
+		volumes = append(volumes, v)
+	}
+	return volumes, nil
+}
+
+// Delete removes the named volume and its data.
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.get(name); err != nil {
+		return err
+	}
+	return os.RemoveAll(filepath.Join(s.root, name))
+}
+
+// WithVolume returns a SpecOpts bind mounting the named volume at dst.
+func WithVolume(s *Store, name, dst string, readonly bool) oci.SpecOpts {
+	return func(ctx context.Context, client oci.Client, c *containers.Container, spec *oci.Spec) error {
+		v, err := s.Get(name)
+		if err != nil {
+			return err
+		}
+		options := []string{"rbind"}
+		if readonly {
+			options = append(options, "ro")
+		} else {
+			options = append(options, "rw")
+		}
+		spec.Mounts = append(spec.Mounts, specs.Mount{
+			Destination: dst,
+			Type:        "bind",
+			Source:      v.Path,
+			Options:     options,
+		})
+		return nil
+	}
+}
@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package volumes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/containerd/errdefs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/containerd/v2/pkg/oci"
+)
+
+func TestStoreLifecycle(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+
+	v, err := store.Create("data", map[string]string{"app": "db"})
+	require.NoError(t, err)
+	assert.DirExists(t, v.Path)
+
+	_, err = store.Create("data", nil)
+	assert.True(t, errdefs.IsAlreadyExists(err))
+
+	_, err = store.Create("../escape", nil)
+	assert.True(t, errdefs.IsInvalidArgument(err))
+
+	got, err := store.Get("data")
+	require.NoError(t, err)
+	assert.Equal(t, v.Path, got.Path)
+	assert.Equal(t, "db", got.Labels["app"])
+
+	vols, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, vols, 1)
+
+	require.NoError(t, store.Delete("data"))
+	_, err = store.Get("data")
+	assert.True(t, errdefs.IsNotFound(err))
+	assert.True(t, errdefs.IsNotFound(store.Delete("data")))
+}
+
+func TestWithVolume(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	require.NoError(t, err)
+	v, err := store.Create("data", nil)
+	require.NoError(t, err)
+
+	spec := &oci.Spec{}
+	require.NoError(t, WithVolume(store, "data", "/var/lib/data", true)(context.Background(), nil, nil, spec))
+	require.Len(t, spec.Mounts, 1)
+	assert.Equal(t, v.Path, spec.Mounts[0].Source)
+	assert.Equal(t, "/var/lib/data", spec.Mounts[0].Destination)
+	assert.Contains(t, spec.Mounts[0].Options, "ro")
+
+	err = WithVolume(store, "missing", "/data", false)(context.Background(), nil, nil, spec)
+	assert.True(t, errdefs.IsNotFound(err))
+}
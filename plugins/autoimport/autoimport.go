@@ -0,0 +1,205 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package autoimport registers the auto-import plugin, which watches a
+// directory for OCI/tar image archives and imports them into a target
+// namespace, replacing the external scripts air-gapped deployments use
+// today.
+//
+// An archive "foo.tar" is imported once a matching checksum file
+// "foo.tar.sha256" exists and verifies (the checksum file guards against
+// importing half-copied archives). Imported archives and their checksum
+// files are removed afterwards.
+package autoimport
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/internal/tomlext"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// Config for the auto-import plugin.
+type Config struct {
+	// Path is the watched directory. The plugin is disabled when empty.
+	Path string `toml:"path"`
+	// Namespace images are imported into. Defaults to "default".
+	Namespace string `toml:"namespace"`
+	// Interval between directory scans.
+	Interval tomlext.Duration `toml:"interval"`
+	// KeepArchives disables post-import removal of archives and checksum
+	// files.
+	KeepArchives bool `toml:"keep_archives"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "auto-import",
+		Requires: []plugin.Type{
+			plugins.ServicePlugin,
+		},
+		Config: &Config{
+			Namespace: "default",
+			Interval:  tomlext.FromStdTime(30 * time.Second),
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			if config.Path == "" {
+				return nil, plugin.ErrSkipPlugin
+			}
+			if err := os.MkdirAll(config.Path, 0700); err != nil {
+				return nil, err
+			}
+			client, err := containerd.New("", containerd.WithInMemoryServices(ic))
+			if err != nil {
+				return nil, err
+			}
+			im := &importer{
+				client: client,
+				config: config,
+			}
+			go im.run(ic.Context)
+			return im, nil
+		},
+	})
+}
+
+type importer struct {
+	client *containerd.Client
+	config *Config
+}
+
+func (im *importer) run(ctx context.Context) {
+	interval := tomlext.ToStdTime(im.config.Interval)
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	for {
+		im.scan(ctx)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// scan imports every verified archive in the watched directory.
+func (im *importer) scan(ctx context.Context) {
+	entries, err := os.ReadDir(im.config.Path)
+	if err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to scan auto-import directory %q", im.config.Path)
+		return
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".tar") {
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		archive := filepath.Join(im.config.Path, e.Name())
+		if err := im.importArchive(ctx, archive); err != nil {
+			log.G(ctx).WithError(err).Errorf("failed to auto-import %q", archive)
+		}
+	}
+}
+
+// importArchive verifies and imports one archive.
+func (im *importer) importArchive(ctx context.Context, archive string) error {
+	checksumFile := archive + ".sha256"
+	expected, err := os.ReadFile(checksumFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			// The checksum arrives after the archive; wait for it.
+			return nil
+		}
+		return err
+	}
+	if err := verifyChecksum(archive, strings.TrimSpace(string(expected))); err != nil {
+		return err
+	}
+
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	nsCtx := namespaces.WithNamespace(ctx, im.config.Namespace)
+	imgs, err := im.client.Import(nsCtx, f, containerd.WithAllPlatforms(true))
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(imgs))
+	for _, img := range imgs {
+		names = append(names, img.Name)
+	}
+	log.G(ctx).Infof("auto-imported %q into namespace %q: %v", archive, im.config.Namespace, names)
+
+	if im.config.KeepArchives {
+		return nil
+	}
+	if err := os.Remove(archive); err != nil {
+		return err
+	}
+	return os.Remove(checksumFile)
+}
+
+// verifyChecksum checks the archive against the hex sha256 in the
+// checksum file (first whitespace separated field, matching sha256sum
+// output).
+func verifyChecksum(archive, expected string) error {
+	if fields := strings.Fields(expected); len(fields) > 0 {
+		expected = fields[0]
+	}
+	f, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != expected {
+		return &checksumError{archive: archive, expected: expected, actual: actual}
+	}
+	return nil
+}
+
+type checksumError struct {
+	archive, expected, actual string
+}
+
+func (e *checksumError) Error() string {
+	return "checksum mismatch for " + e.archive + ": expected " + e.expected + ", got " + e.actual
+}
@@ -0,0 +1,85 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/log"
+)
+
+// CleanupIngests removes ingest leftovers older than the age which have
+// no active writer, returning the number of reclaimed bytes. Aborted
+// pulls leave such partial blobs behind consuming disk until cleaned.
+func (s *store) CleanupIngests(ctx context.Context, olderThan time.Duration) (int64, error) {
+	ingestRoot := filepath.Join(s.root, "ingest")
+	entries, err := os.ReadDir(ingestRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var reclaimed int64
+	cutoff := time.Now().Add(-olderThan)
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := filepath.Join(ingestRoot, e.Name())
+
+		ref, err := readFileString(filepath.Join(path, "ref"))
+		if err == nil {
+			// Skip ingests with an active in-process writer.
+			if err := tryLock(ref); err != nil {
+				continue
+			}
+			unlock(ref)
+		}
+
+		status, err := s.status(path)
+		if err == nil && status.UpdatedAt.After(cutoff) {
+			continue
+		}
+		if err != nil {
+			// Corrupt or partial ingest directory; judge by the
+			// directory's own mtime.
+			if fi, serr := e.Info(); serr != nil || fi.ModTime().After(cutoff) {
+				continue
+			}
+		}
+
+		var size int64
+		if fi, err := os.Stat(filepath.Join(path, "data")); err == nil {
+			size = fi.Size()
+		}
+		if err := os.RemoveAll(path); err != nil {
+			log.G(ctx).WithError(err).Warnf("failed to remove stale ingest %q", path)
+			continue
+		}
+		reclaimed += size
+		log.G(ctx).Debugf("removed stale ingest %q, reclaimed %d bytes", path, size)
+	}
+	if reclaimed > 0 {
+		log.G(ctx).Infof("ingest sweep reclaimed %d bytes", reclaimed)
+	}
+	return reclaimed, nil
+}
@@ -64,11 +64,62 @@ type LabelStore interface {
 type store struct {
 	root string
 	ls   LabelStore
+	// shard is the number of leading hex characters of the encoded digest
+	// used as an intermediate directory level under blobs/<algorithm>.
+	// Zero keeps the flat layout.
+	shard int
+	// algorithms restricts which digest algorithms the store accepts.
+	// Empty accepts every algorithm registered with go-digest.
+	algorithms map[digest.Algorithm]struct{}
+}
+
+// StoreConfig is the toml configuration of the local content store plugin.
+type StoreConfig struct {
+	// ShardPrefixLength shards the blobs directory by the first n
+	// characters of the encoded digest, see WithShardPrefixLength.
+	ShardPrefixLength int `toml:"shard_prefix_length"`
+	// AcceptedDigestAlgorithms restricts which digest algorithms the
+	// store accepts, e.g. ["sha256", "sha512"]. Algorithms must be
+	// registered with go-digest; an empty list accepts every registered
+	// algorithm.
+	AcceptedDigestAlgorithms []string `toml:"accepted_digest_algorithms"`
+	// IngestSweepAge removes ingest leftovers with no active writer that
+	// are older than this duration, on startup and periodically. A zero
+	// value disables the sweep.
+	//
+	// The string is in the golang duration format, e.g. "24h".
+	IngestSweepAge string `toml:"ingest_sweep_age"`
+}
+
+// StoreOpt configures a local content store.
+type StoreOpt func(*store)
+
+// WithShardPrefixLength shards the blobs directory by the first n
+// characters of the encoded digest, i.e. blobs/<algorithm>/<prefix>/<encoded>,
+// keeping directory sizes manageable on hosts with millions of blobs.
+// Reading falls back to the flat layout for blobs written before sharding
+// was enabled.
+func WithShardPrefixLength(n int) StoreOpt {
+	return func(s *store) {
+		s.shard = n
+	}
+}
+
+// WithAcceptedAlgorithms restricts the digest algorithms the store
+// accepts, preparing for registries adopting stronger or faster digests
+// without silently admitting unvetted ones.
+func WithAcceptedAlgorithms(algorithms []digest.Algorithm) StoreOpt {
+	return func(s *store) {
+		s.algorithms = make(map[digest.Algorithm]struct{}, len(algorithms))
+		for _, alg := range algorithms {
+			s.algorithms[alg] = struct{}{}
+		}
+	}
 }
 
 // NewStore returns a local content store
-func NewStore(root string) (content.Store, error) {
-	return NewLabeledStore(root, nil)
+func NewStore(root string, opts ...StoreOpt) (content.Store, error) {
+	return NewLabeledStore(root, nil, opts...)
 }
 
 // NewLabeledStore returns a new content store using the provided label store
@@ -76,15 +127,19 @@ func NewStore(root string) (content.Store, error) {
 // Note: content stores which are used underneath a metadata store may not
 // require labels and should use `NewStore`. `NewLabeledStore` is primarily
 // useful for tests or standalone implementations.
-func NewLabeledStore(root string, ls LabelStore) (content.Store, error) {
+func NewLabeledStore(root string, ls LabelStore, opts ...StoreOpt) (content.Store, error) {
 	if err := os.MkdirAll(filepath.Join(root, "ingest"), 0777); err != nil {
 		return nil, err
 	}
 
-	return &store{
+	s := &store{
 		root: root,
 		ls:   ls,
-	}, nil
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s, nil
 }
 
 func (s *store) Info(ctx context.Context, dgst digest.Digest) (content.Info, error) {
@@ -260,6 +315,11 @@ func (s *store) Walk(ctx context.Context, fn content.WalkFunc, fs ...string) err
 			return nil
 		}
 
+		if fi.IsDir() {
+			// descending into a shard directory
+			return nil
+		}
+
 		dgst := digest.NewDigestFromEncoded(alg, filepath.Base(path))
 		if err := dgst.Validate(); err != nil {
 			// log error but don't report
@@ -518,8 +578,15 @@ func (s *store) writer(ctx context.Context, ref string, total int64, expected di
 
 	path, refp, data := s.ingestPaths(ref)
 
+	// Hash with the algorithm of the expected digest so non-canonical
+	// algorithms such as sha512 verify end-to-end.
+	algorithm := digest.Canonical
+	if expected != "" {
+		algorithm = expected.Algorithm()
+	}
+
 	var (
-		digester  = digest.Canonical.Digester()
+		digester  = algorithm.Digester()
 		offset    int64
 		startedAt time.Time
 		updatedAt time.Time
@@ -610,8 +677,36 @@ func (s *store) blobPath(dgst digest.Digest) (string, error) {
 	if err := dgst.Validate(); err != nil {
 		return "", fmt.Errorf("cannot calculate blob path from invalid digest: %v: %w", err, errdefs.ErrInvalidArgument)
 	}
+	if err := s.validateAlgorithm(dgst.Algorithm()); err != nil {
+		return "", err
+	}
+
+	encoded := dgst.Encoded()
+	if s.shard > 0 && s.shard < len(encoded) {
+		sharded := filepath.Join(s.root, "blobs", dgst.Algorithm().String(), encoded[:s.shard], encoded)
+		// Blobs written before sharding was enabled remain at the flat
+		// location, so prefer it when it exists.
+		if _, err := os.Stat(sharded); err == nil {
+			return sharded, nil
+		}
+		flat := filepath.Join(s.root, "blobs", dgst.Algorithm().String(), encoded)
+		if _, err := os.Stat(flat); err == nil {
+			return flat, nil
+		}
+		return sharded, nil
+	}
+	return filepath.Join(s.root, "blobs", dgst.Algorithm().String(), encoded), nil
+}
 
-	return filepath.Join(s.root, "blobs", dgst.Algorithm().String(), dgst.Encoded()), nil
+// validateAlgorithm rejects digest algorithms not on the accepted list.
+func (s *store) validateAlgorithm(alg digest.Algorithm) error {
+	if len(s.algorithms) == 0 {
+		return nil
+	}
+	if _, ok := s.algorithms[alg]; !ok {
+		return fmt.Errorf("digest algorithm %q is not accepted by this content store: %w", alg, errdefs.ErrInvalidArgument)
+	}
+	return nil
 }
 
 func (s *store) ingestRoot(ref string) string {
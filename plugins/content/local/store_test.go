@@ -22,6 +22,7 @@ import (
 	"context"
 	"crypto/rand"
 	_ "crypto/sha256" // required for digest package
+	_ "crypto/sha512" // required for digest algorithm agility tests
 	"fmt"
 	"io"
 	"os"
@@ -387,3 +388,91 @@ func TestWriteReadEmptyFileTimestamp(t *testing.T) {
 		t.Errorf("read empty timestamp file should return time.Time{}, but got: %v", timestamp)
 	}
 }
+
+func TestAcceptedDigestAlgorithms(t *testing.T) {
+	ctx := context.Background()
+	cs, err := NewStore(t.TempDir(), WithAcceptedAlgorithms([]digest.Algorithm{digest.SHA256, digest.SHA512}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blob := []byte("accepted algorithms")
+	for _, alg := range []digest.Algorithm{digest.SHA256, digest.SHA512} {
+		dgst := alg.FromBytes(blob)
+		if err := content.WriteBlob(ctx, cs, "test-"+alg.String(), bytes.NewReader(blob), ocispec.Descriptor{Digest: dgst, Size: int64(len(blob))}); err != nil {
+			t.Fatalf("expected %s digest to be accepted: %v", alg, err)
+		}
+		if _, err := cs.Info(ctx, dgst); err != nil {
+			t.Fatalf("expected %s blob to be readable: %v", alg, err)
+		}
+	}
+
+	// sha384 is registered with go-digest but not on the accepted list.
+	dgst := digest.SHA384.FromBytes(blob)
+	err = content.WriteBlob(ctx, cs, "test-sha384", bytes.NewReader(blob), ocispec.Descriptor{Digest: dgst, Size: int64(len(blob))})
+	if err == nil {
+		t.Fatal("expected sha384 digest to be rejected")
+	}
+	if !errdefs.IsInvalidArgument(err) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
+
+func TestCleanupIngests(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	cs, err := NewStore(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := cs.(*store)
+
+	// An aborted ingest with old timestamps is removed.
+	w, err := content.OpenWriter(ctx, cs, content.WithRef("stale-ref"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte("partial data")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	stalePath := s.ingestRoot("stale-ref")
+	old := time.Now().Add(-48 * time.Hour)
+	for _, f := range []string{"startedat", "updatedat"} {
+		if err := writeTimestampFile(filepath.Join(stalePath, f), old); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []string{"data", "ref", "startedat", "updatedat"} {
+		os.Chtimes(filepath.Join(stalePath, f), old, old)
+	}
+	os.Chtimes(stalePath, old, old)
+
+	// A fresh ingest stays.
+	w2, err := content.OpenWriter(ctx, cs, content.WithRef("fresh-ref"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w2.Write([]byte("fresh")); err != nil {
+		t.Fatal(err)
+	}
+	if err := w2.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	reclaimed, err := s.CleanupIngests(ctx, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reclaimed != int64(len("partial data")) {
+		t.Fatalf("expected %d reclaimed bytes, got %d", len("partial data"), reclaimed)
+	}
+	if _, err := os.Stat(stalePath); !os.IsNotExist(err) {
+		t.Fatal("expected stale ingest to be removed")
+	}
+	if _, err := os.Stat(s.ingestRoot("fresh-ref")); err != nil {
+		t.Fatal("expected fresh ingest to remain")
+	}
+}
@@ -192,6 +192,9 @@ func (c *criGRPCServer) register(s *grpc.Server) error {
 	instrumented := instrument.NewService(c)
 	runtime.RegisterRuntimeServiceServer(s, instrumented)
 	runtime.RegisterImageServiceServer(s, instrumented)
+	if d, ok := c.RuntimeServiceServer.(interface{ RegisterDrainService(*grpc.Server) }); ok {
+		d.RegisterDrainService(s)
+	}
 	return nil
 }
 
@@ -0,0 +1,202 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package eventsoutbox registers the event outbox plugin, forwarding
+// events from the exchange to an external webhook sink with at-least-once
+// semantics.
+package eventsoutbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	"github.com/containerd/containerd/v2/core/events"
+	"github.com/containerd/containerd/v2/core/events/exchange"
+	"github.com/containerd/containerd/v2/core/events/outbox"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// Config for the events outbox.
+type Config struct {
+	// Address is the webhook URL events are POSTed to as JSON. The
+	// outbox is disabled when empty.
+	Address string `toml:"address"`
+	// JournalDir, when set, additionally appends every matching event to
+	// daily NDJSON files under the directory, which `ctr events --since`
+	// replays for post-incident forensics.
+	JournalDir string `toml:"journal_dir"`
+	// JournalMaxFiles bounds the kept daily journal files. Defaults
+	// to 14.
+	JournalMaxFiles int `toml:"journal_max_files"`
+	// Filters restricts the forwarded events, using the event subscribe
+	// filter syntax. Empty forwards everything.
+	Filters []string `toml:"filters"`
+	// MaxRetries bounds delivery attempts per event before it moves to
+	// the dead-letter directory.
+	MaxRetries int `toml:"max_retries"`
+	// RetryInterval is the pause after a failed delivery, in the golang
+	// duration format.
+	RetryInterval string `toml:"retry_interval"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "event-outbox",
+		Requires: []plugin.Type{
+			plugins.EventPlugin,
+		},
+		Config: &Config{},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			if config.Address == "" && config.JournalDir == "" {
+				return nil, plugin.ErrSkipPlugin
+			}
+
+			ep, err := ic.GetByID(plugins.EventPlugin, "exchange")
+			if err != nil {
+				return nil, err
+			}
+
+			var ob *outbox.Outbox
+			if config.Address != "" {
+				var opts []outbox.Opt
+				if config.MaxRetries > 0 {
+					opts = append(opts, outbox.WithMaxRetries(config.MaxRetries))
+				}
+				if config.RetryInterval != "" {
+					d, err := time.ParseDuration(config.RetryInterval)
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse retry_interval %q: %w", config.RetryInterval, err)
+					}
+					opts = append(opts, outbox.WithRetryInterval(d))
+				}
+
+				ob, err = outbox.New(
+					filepath.Join(ic.Properties[plugins.PropertyRootDir]),
+					&webhookSink{address: config.Address},
+					opts...,
+				)
+				if err != nil {
+					return nil, err
+				}
+				go ob.Run(ic.Context)
+			}
+
+			var journal *outbox.Journal
+			if config.JournalDir != "" {
+				journal, err = outbox.NewJournal(config.JournalDir, config.JournalMaxFiles)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			go forward(ic.Context, ep.(*exchange.Exchange), ob, journal, config.Filters)
+			return &struct{}{}, nil
+		},
+	})
+}
+
+// forward subscribes to the exchange, enqueues every matching event for
+// sink delivery and appends it to the journal.
+func forward(ctx context.Context, ex *exchange.Exchange, ob *outbox.Outbox, journal *outbox.Journal, filters []string) {
+	for ctx.Err() == nil {
+		ch, errs := ex.Subscribe(ctx, filters...)
+	subscription:
+		for {
+			select {
+			case envelope := <-ch:
+				if envelope == nil {
+					continue
+				}
+				if ob != nil {
+					if err := ob.Enqueue(envelope); err != nil {
+						log.G(ctx).WithError(err).Error("failed to enqueue event for outbox")
+					}
+				}
+				if journal != nil {
+					if err := journal.Append(envelope); err != nil {
+						log.G(ctx).WithError(err).Error("failed to append event to journal")
+					}
+				}
+			case err := <-errs:
+				if ctx.Err() != nil {
+					return
+				}
+				log.G(ctx).WithError(err).Error("event outbox subscription failed, resubscribing")
+				break subscription
+			case <-ctx.Done():
+				return
+			}
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// webhookSink POSTs envelopes as JSON to the configured address.
+type webhookSink struct {
+	address string
+	client  http.Client
+}
+
+func (s *webhookSink) Deliver(ctx context.Context, envelope *events.Envelope) error {
+	payload := struct {
+		Timestamp time.Time `json:"timestamp"`
+		Namespace string    `json:"namespace"`
+		Topic     string    `json:"topic"`
+		TypeURL   string    `json:"typeUrl,omitempty"`
+		Value     []byte    `json:"value,omitempty"`
+	}{
+		Timestamp: envelope.Timestamp,
+		Namespace: envelope.Namespace,
+		Topic:     envelope.Topic,
+	}
+	if envelope.Event != nil {
+		payload.TypeURL = envelope.Event.GetTypeUrl()
+		payload.Value = envelope.Event.GetValue()
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.address, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sink returned status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package imagewatch registers the image watch plugin, which periodically
+// re-resolves configured floating tags (e.g. ":latest") and pulls new
+// content when the registry digest drifts. The image store update emits
+// the usual /images/update event, which tools such as the restart monitor
+// can act on for auto-redeploy workflows.
+package imagewatch
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/remotes/docker"
+	"github.com/containerd/containerd/v2/internal/tomlext"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// Config for the image watch plugin.
+type Config struct {
+	// Images maps a namespace to the floating tag references watched for
+	// digest drift. The plugin is disabled when empty.
+	Images map[string][]string `toml:"images"`
+	// Interval between re-resolve passes.
+	Interval tomlext.Duration `toml:"interval"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "image-watch",
+		Requires: []plugin.Type{
+			plugins.ServicePlugin,
+		},
+		Config: &Config{
+			Interval: tomlext.FromStdTime(15 * time.Minute),
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			if len(config.Images) == 0 {
+				return nil, plugin.ErrSkipPlugin
+			}
+			client, err := containerd.New("", containerd.WithInMemoryServices(ic))
+			if err != nil {
+				return nil, err
+			}
+			w := &watcher{
+				client: client,
+				config: config,
+			}
+			go w.run(ic.Context)
+			return w, nil
+		},
+	})
+}
+
+type watcher struct {
+	client *containerd.Client
+	config *Config
+}
+
+func (w *watcher) run(ctx context.Context) {
+	interval := tomlext.ToStdTime(w.config.Interval)
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+		w.check(ctx)
+	}
+}
+
+// check re-resolves every watched tag and pulls when the digest moved.
+func (w *watcher) check(ctx context.Context) {
+	resolver := docker.NewResolver(docker.ResolverOptions{})
+	for ns, refs := range w.config.Images {
+		nsCtx := namespaces.WithNamespace(ctx, ns)
+		for _, ref := range refs {
+			if ctx.Err() != nil {
+				return
+			}
+			_, desc, err := resolver.Resolve(nsCtx, ref)
+			if err != nil {
+				log.G(ctx).WithError(err).Warnf("failed to re-resolve watched image %q", ref)
+				continue
+			}
+			current, err := w.client.GetImage(nsCtx, ref)
+			if err == nil && current.Target().Digest == desc.Digest {
+				continue
+			}
+			if err != nil && !errdefs.IsNotFound(err) {
+				log.G(ctx).WithError(err).Warnf("failed to check watched image %q", ref)
+				continue
+			}
+
+			log.G(ctx).Infof("digest of %q in namespace %q drifted to %s, pulling", ref, ns, desc.Digest)
+			if _, err := w.client.Pull(nsCtx, ref, containerd.WithPullUnpack); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to re-pull drifted image %q", ref)
+			}
+		}
+	}
+}
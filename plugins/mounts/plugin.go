@@ -0,0 +1,69 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package mounts registers the mount manager plugin, which tracks mounts
+// performed by containerd and cleans up orphans left behind by crashes.
+package mounts
+
+import (
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	"github.com/containerd/containerd/v2/core/metadata"
+	"github.com/containerd/containerd/v2/core/mount"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "mount-manager",
+		Requires: []plugin.Type{
+			plugins.MetadataPlugin,
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			mgr, err := mount.NewManager(ic.Properties[plugins.PropertyStateDir])
+			if err != nil {
+				return nil, err
+			}
+
+			m, err := ic.GetSingle(plugins.MetadataPlugin)
+			if err != nil {
+				return nil, err
+			}
+			cs := metadata.NewContainerStore(m.(*metadata.DB))
+
+			// Clean up mounts recorded for containers that no longer
+			// exist, e.g. after an unclean shutdown between container
+			// deletion and unmount.
+			alive := func(ns, owner string) bool {
+				if owner == "" {
+					return false
+				}
+				_, err := cs.Get(namespaces.WithNamespace(ic.Context, ns), owner)
+				return err == nil
+			}
+			if cleaned, err := mgr.Cleanup(ic.Context, alive); err != nil {
+				log.G(ic.Context).WithError(err).Error("mount manager cleanup")
+			} else if len(cleaned) > 0 {
+				log.G(ic.Context).Infof("mount manager cleaned up %d orphaned mounts", len(cleaned))
+			}
+			return mgr, nil
+		},
+	})
+}
@@ -0,0 +1,135 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package prefetch registers the image prefetch plugin, which keeps a
+// configured list of images per namespace pulled and unpacked, so large
+// clusters can pre-warm nodes before rollouts.
+package prefetch
+
+import (
+	"context"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/internal/tomlext"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// Config for the image prefetch plugin.
+type Config struct {
+	// Images maps a namespace to the image references kept pulled and
+	// unpacked on this node. The plugin is disabled when empty.
+	Images map[string][]string `toml:"images"`
+	// Interval between reconcile passes checking and re-pulling missing
+	// images.
+	Interval tomlext.Duration `toml:"interval"`
+	// MaxConcurrentDownloads bounds the bandwidth used by prefetch pulls.
+	MaxConcurrentDownloads int `toml:"max_concurrent_downloads"`
+	// Snapshotter used for unpacking. Empty uses the default.
+	Snapshotter string `toml:"snapshotter"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "image-prefetch",
+		Requires: []plugin.Type{
+			plugins.ServicePlugin,
+		},
+		Config: &Config{
+			Interval:               tomlext.FromStdTime(time.Hour),
+			MaxConcurrentDownloads: 3,
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			if len(config.Images) == 0 {
+				return nil, plugin.ErrSkipPlugin
+			}
+			client, err := containerd.New("", containerd.WithInMemoryServices(ic))
+			if err != nil {
+				return nil, err
+			}
+			p := &prefetcher{
+				client: client,
+				config: config,
+			}
+			go p.run(ic.Context)
+			return p, nil
+		},
+	})
+}
+
+type prefetcher struct {
+	client *containerd.Client
+	config *Config
+}
+
+// run reconciles the prefetch list on the configured interval.
+func (p *prefetcher) run(ctx context.Context) {
+	interval := tomlext.ToStdTime(p.config.Interval)
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	for {
+		p.reconcile(ctx)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reconcile pulls and unpacks every configured image that is missing.
+func (p *prefetcher) reconcile(ctx context.Context) {
+	for ns, refs := range p.config.Images {
+		nsCtx := namespaces.WithNamespace(ctx, ns)
+		for _, ref := range refs {
+			if ctx.Err() != nil {
+				return
+			}
+			if img, err := p.client.GetImage(nsCtx, ref); err == nil {
+				if unpacked, err := img.IsUnpacked(nsCtx, p.config.Snapshotter); err == nil && unpacked {
+					continue
+				}
+			} else if !errdefs.IsNotFound(err) {
+				log.G(ctx).WithError(err).Errorf("failed to check prefetch image %q in namespace %q", ref, ns)
+				continue
+			}
+
+			log.G(ctx).Infof("prefetching image %q in namespace %q", ref, ns)
+			start := time.Now()
+			opts := []containerd.RemoteOpt{
+				containerd.WithPullUnpack,
+				containerd.WithMaxConcurrentDownloads(p.config.MaxConcurrentDownloads),
+			}
+			if p.config.Snapshotter != "" {
+				opts = append(opts, containerd.WithPullSnapshotter(p.config.Snapshotter))
+			}
+			if _, err := p.client.Pull(nsCtx, ref, opts...); err != nil {
+				log.G(ctx).WithError(err).Errorf("failed to prefetch image %q in namespace %q", ref, ns)
+				continue
+			}
+			log.G(ctx).Infof("prefetched image %q in namespace %q in %s", ref, ns, time.Since(start))
+		}
+	}
+}
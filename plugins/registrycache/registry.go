@@ -0,0 +1,256 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package registrycache serves a read-only OCI registry API on localhost
+// backed by containerd's content and image stores, so sibling tools
+// (buildkit, other namespaces) can pull layers that are already present
+// on the node instead of going to the network.
+package registrycache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/containerd/errdefs"
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/containerd/containerd/v2/core/content"
+	"github.com/containerd/containerd/v2/core/images"
+	"github.com/containerd/containerd/v2/core/metadata"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// Config for the registry cache.
+type Config struct {
+	// Address is the localhost address the read-only registry listens
+	// on, e.g. "127.0.0.1:5010". The registry is disabled when empty.
+	Address string `toml:"address"`
+	// Namespace is the containerd namespace served. Defaults to
+	// "default".
+	Namespace string `toml:"namespace"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.InternalPlugin,
+		ID:   "registry-cache",
+		Requires: []plugin.Type{
+			plugins.MetadataPlugin,
+		},
+		Config: &Config{
+			Namespace: "default",
+		},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			if config.Address == "" {
+				return nil, plugin.ErrSkipPlugin
+			}
+			if !strings.HasPrefix(config.Address, "127.0.0.1:") && !strings.HasPrefix(config.Address, "localhost:") {
+				return nil, fmt.Errorf("registry cache must listen on localhost, got %q", config.Address)
+			}
+
+			m, err := ic.GetSingle(plugins.MetadataPlugin)
+			if err != nil {
+				return nil, err
+			}
+			db := m.(*metadata.DB)
+			srv := &server{
+				cs:        db.ContentStore(),
+				is:        metadata.NewImageStore(db),
+				namespace: config.Namespace,
+			}
+
+			l, err := net.Listen("tcp", config.Address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to listen on %q: %w", config.Address, err)
+			}
+			httpSrv := &http.Server{
+				Handler:           srv,
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+			go func() {
+				log.G(ic.Context).Infof("serving read-only registry cache on %s", config.Address)
+				if err := httpSrv.Serve(l); err != nil && err != http.ErrServerClosed {
+					log.G(ic.Context).WithError(err).Error("registry cache server failed")
+				}
+			}()
+			go func() {
+				<-ic.Context.Done()
+				httpSrv.Close()
+			}()
+			return srv, nil
+		},
+	})
+}
+
+var (
+	blobsRe     = regexp.MustCompile(`^/v2/(.+)/blobs/([a-z0-9]+:[a-f0-9]+)$`)
+	manifestsRe = regexp.MustCompile(`^/v2/(.+)/manifests/(.+)$`)
+)
+
+type server struct {
+	cs        content.Store
+	is        images.Store
+	namespace string
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	ctx := namespaces.WithNamespace(r.Context(), s.namespace)
+
+	switch {
+	case r.URL.Path == "/v2/" || r.URL.Path == "/v2":
+		w.WriteHeader(http.StatusOK)
+	case blobsRe.MatchString(r.URL.Path):
+		m := blobsRe.FindStringSubmatch(r.URL.Path)
+		s.serveBlob(ctx, w, r, m[2], "")
+	case manifestsRe.MatchString(r.URL.Path):
+		m := manifestsRe.FindStringSubmatch(r.URL.Path)
+		s.serveManifest(ctx, w, r, m[1], m[2])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveManifest serves a manifest by digest or resolves a tag through the
+// image store.
+func (s *server) serveManifest(ctx context.Context, w http.ResponseWriter, r *http.Request, name, ref string) {
+	var (
+		dgst      digest.Digest
+		mediaType string
+	)
+	if parsed, err := digest.Parse(ref); err == nil {
+		dgst = parsed
+	} else {
+		img, err := s.resolveTag(ctx, name, ref)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		dgst = img.Target.Digest
+		mediaType = img.Target.MediaType
+	}
+	s.serveBlob(ctx, w, r, dgst.String(), mediaType)
+}
+
+// resolveTag maps the repository name from the request path onto an image
+// record. Clients request `/v2/library/busybox/manifests/1.36` while
+// normally pulled images are stored under registry-qualified references
+// ("docker.io/library/busybox:1.36"), so the verbatim name is tried
+// first, then common registry qualifications, and finally a suffix match
+// over the image store.
+func (s *server) resolveTag(ctx context.Context, name, tag string) (images.Image, error) {
+	candidates := []string{
+		name + ":" + tag,
+		"docker.io/" + name + ":" + tag,
+	}
+	if !strings.Contains(name, "/") {
+		// Official images live under library/ on Docker Hub.
+		candidates = append(candidates, "docker.io/library/"+name+":"+tag)
+	}
+	for _, candidate := range candidates {
+		if img, err := s.is.Get(ctx, candidate); err == nil {
+			return img, nil
+		}
+	}
+
+	// Fall back to matching the repository path under any registry.
+	imgs, err := s.is.List(ctx)
+	if err != nil {
+		return images.Image{}, err
+	}
+	suffix := "/" + name + ":" + tag
+	for _, img := range imgs {
+		if strings.HasSuffix(img.Name, suffix) {
+			return img, nil
+		}
+	}
+	return images.Image{}, fmt.Errorf("no image matches %s:%s: %w", name, tag, errdefs.ErrNotFound)
+}
+
+// serveBlob streams a blob from the content store.
+func (s *server) serveBlob(ctx context.Context, w http.ResponseWriter, r *http.Request, dgstStr, mediaType string) {
+	dgst, err := digest.Parse(dgstStr)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	info, err := s.cs.Info(ctx, dgst)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if mediaType == "" {
+		mediaType = sniffMediaType(ctx, s.cs, dgst, info.Size)
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", info.Size))
+	w.Header().Set("Docker-Content-Digest", dgst.String())
+	if r.Method == http.MethodHead {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ra, err := s.cs.ReaderAt(ctx, ocispec.Descriptor{Digest: dgst, Size: info.Size})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer ra.Close()
+	w.WriteHeader(http.StatusOK)
+	io.Copy(w, io.NewSectionReader(ra, 0, info.Size))
+}
+
+// sniffMediaType extracts the mediaType field of a JSON blob, falling
+// back to a generic octet stream for layers.
+func sniffMediaType(ctx context.Context, cs content.Store, dgst digest.Digest, size int64) string {
+	const sniffLimit = 1 << 20
+	if size > sniffLimit {
+		return "application/octet-stream"
+	}
+	ra, err := cs.ReaderAt(ctx, ocispec.Descriptor{Digest: dgst, Size: size})
+	if err != nil {
+		return "application/octet-stream"
+	}
+	defer ra.Close()
+	data, err := io.ReadAll(io.NewSectionReader(ra, 0, size))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	var mt struct {
+		MediaType string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(data, &mt); err == nil && mt.MediaType != "" {
+		return mt.MediaType
+	}
+	return "application/octet-stream"
+}
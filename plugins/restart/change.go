@@ -79,7 +79,11 @@ func (s *startChange) apply(ctx context.Context, client *containerd.Client) erro
 }
 
 func killTask(ctx context.Context, container containerd.Container) error {
-	task, err := container.Task(ctx, nil)
+	// Attach to the existing IO set when loading the task so that deleting
+	// it below closes the FIFOs and removes their directory. This lets the
+	// shim reap a binary logger still holding the pipes, preventing
+	// duplicated loggers and pipe leaks after repeated restarts.
+	task, err := container.Task(ctx, cio.Load)
 	if err == nil {
 		wait, err := task.Wait(ctx)
 		if err != nil {
@@ -98,6 +102,11 @@ func killTask(ctx context.Context, container containerd.Container) error {
 		if _, err := task.Delete(ctx); err != nil {
 			return err
 		}
+		// Release the stale IO resources attached above; Delete only
+		// cancels outstanding copies.
+		if io := task.IO(); io != nil {
+			io.Close()
+		}
 	}
 	return nil
 }
@@ -0,0 +1,74 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/runtime/restart"
+	"github.com/containerd/log"
+)
+
+// historyLabel holds the JSON encoded ring buffer of recent restart
+// attempts on the container, so crash-loop debugging does not depend on
+// scraping daemon logs. It is visible through `ctr containers info`.
+const historyLabel = "containerd.io/restart.history"
+
+// historySize bounds the recorded attempts per container.
+const historySize = 10
+
+// restartAttempt is one recorded restart.
+type restartAttempt struct {
+	// Timestamp of the restart attempt.
+	Timestamp time.Time `json:"timestamp"`
+	// ExitCode the container previously exited with.
+	ExitCode int `json:"exitCode"`
+	// Count is the restart counter at the time of the attempt.
+	Count int `json:"count"`
+}
+
+// recordRestartAttempt appends the attempt to the container's history
+// ring buffer.
+func recordRestartAttempt(ctx context.Context, container containerd.Container, labels map[string]string, count int) {
+	var history []restartAttempt
+	if v := labels[historyLabel]; v != "" {
+		if err := json.Unmarshal([]byte(v), &history); err != nil {
+			log.G(ctx).WithError(err).Warnf("resetting corrupt restart history of %q", container.ID())
+			history = nil
+		}
+	}
+	exitCode, _ := strconv.Atoi(labels[restart.LastExitStatusLabel])
+	history = append(history, restartAttempt{
+		Timestamp: time.Now().UTC(),
+		ExitCode:  exitCode,
+		Count:     count,
+	})
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	if _, err := container.SetLabels(ctx, map[string]string{historyLabel: string(data)}); err != nil {
+		log.G(ctx).WithError(err).Warnf("failed to record restart history of %q", container.ID())
+	}
+}
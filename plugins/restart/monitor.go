@@ -23,21 +23,29 @@ import (
 	"sync"
 	"time"
 
+	eventstypes "github.com/containerd/containerd/api/events"
 	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/events"
 	"github.com/containerd/containerd/v2/core/runtime/restart"
+	"github.com/containerd/containerd/v2/internal/liveness"
 	"github.com/containerd/containerd/v2/internal/tomlext"
 	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/plugins"
 	"github.com/containerd/containerd/v2/version"
+	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
+	"github.com/containerd/typeurl/v2"
 )
 
 // Config for the restart monitor
 type Config struct {
 	// Interval for how long to wait to check for state changes
 	Interval tomlext.Duration `toml:"interval"`
+	// OnGiveUp configures the notification delivered when a container
+	// exceeds its restart retry budget.
+	OnGiveUp GiveUpConfig `toml:"on_give_up"`
 }
 
 func init() {
@@ -57,10 +65,14 @@ func init() {
 			if err != nil {
 				return nil, err
 			}
+			config := ic.Config.(*Config)
 			m := &monitor{
-				client: client,
+				client:     client,
+				lastErrors: make(map[string]string),
+				giveUp:     config.OnGiveUp,
 			}
-			go m.run(tomlext.ToStdTime(ic.Config.(*Config).Interval))
+			go m.run(tomlext.ToStdTime(config.Interval))
+			go m.watchExitEvents(ic.Context)
 			return m, nil
 		},
 		ConfigMigration: func(ctx context.Context, configVersion int, pluginConfigs map[string]interface{}) error {
@@ -85,16 +97,56 @@ type change interface {
 
 type monitor struct {
 	client *containerd.Client
+
+	mu sync.Mutex
+	// lastErrors tracks the last logged task error per container so the
+	// same error is not logged again every reconcile interval.
+	lastErrors map[string]string
+	// giveUp configures notification of containers that exceeded their
+	// restart retry budget.
+	giveUp GiveUpConfig
+}
+
+// logTaskError logs the container's task error unless the same error was
+// already logged on a previous reconcile pass.
+func (m *monitor) logTaskError(ctx context.Context, id string, err error) {
+	key := id
+	if ns, nerr := namespaces.NamespaceRequired(ctx); nerr == nil {
+		key = ns + "/" + id
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lastErrors[key] == err.Error() {
+		return
+	}
+	m.lastErrors[key] = err.Error()
+	log.G(ctx).WithError(err).WithField("container", id).Error("monitor")
+}
+
+// clearTaskError forgets a previously logged error so a reoccurrence is
+// logged again.
+func (m *monitor) clearTaskError(ctx context.Context, id string) {
+	key := id
+	if ns, nerr := namespaces.NamespaceRequired(ctx); nerr == nil {
+		key = ns + "/" + id
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.lastErrors, key)
 }
 
 func (m *monitor) run(interval time.Duration) {
 	if interval == 0 {
 		interval = 10 * time.Second
 	}
+	// Report reconcile progress so a deadlocked loop fails the daemon's
+	// systemd watchdog aggregation.
+	probe := liveness.Register("restart-monitor", 3*interval)
 	for {
 		if err := m.reconcile(context.Background()); err != nil {
 			log.L.WithError(err).Error("reconcile")
 		}
+		probe.Beat()
 		time.Sleep(interval)
 	}
 }
@@ -134,6 +186,69 @@ func (m *monitor) reconcile(ctx context.Context) error {
 	return nil
 }
 
+// watchExitEvents persists the exit status of exiting tasks into the
+// last-exit-status label of monitored containers, so that the on-failure
+// policy can be evaluated even when the task is deleted before the next
+// reconcile pass observes its status.
+func (m *monitor) watchExitEvents(ctx context.Context) {
+	for {
+		evCh, errCh := m.client.Subscribe(ctx, `topic=="/tasks/exit"`)
+		for stop := false; !stop; {
+			select {
+			case ev := <-evCh:
+				if err := m.handleExitEvent(ctx, ev); err != nil {
+					log.G(ctx).WithError(err).Error("handle task exit event")
+				}
+			case err := <-errCh:
+				if ctx.Err() != nil {
+					return
+				}
+				log.G(ctx).WithError(err).Error("restart monitor event subscription failed, retrying")
+				stop = true
+			}
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (m *monitor) handleExitEvent(ctx context.Context, ev *events.Envelope) error {
+	if ev == nil || ev.Event == nil {
+		return nil
+	}
+	v, err := typeurl.UnmarshalAny(ev.Event)
+	if err != nil {
+		return err
+	}
+	e, ok := v.(*eventstypes.TaskExit)
+	if !ok {
+		return nil
+	}
+	// Only the init process exit reflects the container status.
+	if e.ID != e.ContainerID {
+		return nil
+	}
+	ctx = namespaces.WithNamespace(ctx, ev.Namespace)
+	c, err := m.client.LoadContainer(ctx, e.ContainerID)
+	if err != nil {
+		return nil
+	}
+	labels, err := c.Labels(ctx)
+	if err != nil {
+		return err
+	}
+	if _, ok := labels[restart.StatusLabel]; !ok {
+		return nil
+	}
+	_, err = c.SetLabels(ctx, map[string]string{
+		restart.LastExitStatusLabel: strconv.Itoa(int(e.ExitStatus)),
+	})
+	return err
+}
+
 func (m *monitor) monitor(ctx context.Context) ([]change, error) {
 	containers, err := m.client.Containers(ctx, fmt.Sprintf("labels.%q", restart.StatusLabel))
 	if err != nil {
@@ -154,6 +269,7 @@ func (m *monitor) monitor(ctx context.Context) ([]change, error) {
 		if task, err = c.Task(ctx, nil); err == nil {
 			if status, err = task.Status(ctx); err == nil {
 				if desiredStatus == status.Status {
+					m.clearTaskError(ctx, c.ID())
 					continue
 				}
 			}
@@ -161,15 +277,23 @@ func (m *monitor) monitor(ctx context.Context) ([]change, error) {
 
 		// Task or Status return error, only desired to running
 		if err != nil {
-			log.G(ctx).WithError(err).Error("monitor")
 			if desiredStatus == containerd.Stopped {
+				// A container without a task that should be stopped is in
+				// its expected steady state, not an error worth logging.
+				if errdefs.IsNotFound(err) {
+					m.clearTaskError(ctx, c.ID())
+				} else {
+					m.logTaskError(ctx, c.ID(), err)
+				}
 				continue
 			}
+			m.logTaskError(ctx, c.ID(), err)
 		}
 
-		// Known issue:
-		// The status may be empty when task failed but was deleted,
-		// which will result in an `on-failure` restart policy reconcile error.
+		// The status may be empty when the task failed but was already
+		// deleted; restart.Reconcile falls back to the exit status
+		// persisted in the last-exit-status label by the exit event
+		// handler in that case.
 		switch desiredStatus {
 		case containerd.Running:
 			switch status.Status {
@@ -178,10 +302,12 @@ func (m *monitor) monitor(ctx context.Context) ([]change, error) {
 			default:
 			}
 			if !restart.Reconcile(status, labels) {
+				m.maybeNotifyGiveUp(ctx, c, labels)
 				continue
 			}
 
 			restartCount, _ := strconv.Atoi(labels[restart.CountLabel])
+			recordRestartAttempt(ctx, c, labels, restartCount+1)
 			if labels["containerd.io/restart.logpath"] != "" {
 				log.G(ctx).Warn(`Label "containerd.io/restart.logpath" is no longer supported since containerd v2.0. Use "containerd.io/restart.loguri" instead.`)
 			}
@@ -0,0 +1,155 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	containerd "github.com/containerd/containerd/v2/client"
+	"github.com/containerd/containerd/v2/core/runtime/restart"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
+	"github.com/containerd/log"
+)
+
+// gaveUpNotifiedLabel marks containers whose give-up notification has
+// already been delivered, so operators are notified exactly once per
+// crash loop.
+const gaveUpNotifiedLabel = "containerd.io/restart.gave-up-notified"
+
+// GiveUpConfig configures the notification delivered when a container
+// exhausts its restart retry budget.
+type GiveUpConfig struct {
+	// WebhookURL receives the notification payload as a JSON POST.
+	WebhookURL string `toml:"webhook_url"`
+	// ExecCommand is run with the JSON payload on stdin.
+	ExecCommand []string `toml:"exec_command"`
+	// LogTailBytes bounds how much of the container's log file is
+	// included in the payload. Zero includes no log tail.
+	LogTailBytes int64 `toml:"log_tail_bytes"`
+}
+
+func (c GiveUpConfig) enabled() bool {
+	return c.WebhookURL != "" || len(c.ExecCommand) > 0
+}
+
+// giveUpPayload is the notification payload.
+type giveUpPayload struct {
+	Namespace    string `json:"namespace"`
+	ContainerID  string `json:"containerID"`
+	RestartCount int    `json:"restartCount"`
+	LastExitCode int    `json:"lastExitCode"`
+	LogTail      string `json:"logTail,omitempty"`
+	Timestamp    string `json:"timestamp"`
+}
+
+// maybeNotifyGiveUp notifies the configured sink once when the container
+// has exhausted its on-failure retry budget.
+func (m *monitor) maybeNotifyGiveUp(ctx context.Context, container containerd.Container, labels map[string]string) {
+	if !m.giveUp.enabled() {
+		return
+	}
+	if labels[gaveUpNotifiedLabel] != "" {
+		return
+	}
+	policy, err := restart.NewPolicy(labels[restart.PolicyLabel])
+	if err != nil || policy.Name() != "on-failure" || policy.MaximumRetryCount() == 0 {
+		return
+	}
+	count, _ := strconv.Atoi(labels[restart.CountLabel])
+	if count < policy.MaximumRetryCount() {
+		return
+	}
+
+	ns, _ := namespaces.Namespace(ctx)
+	exitCode, _ := strconv.Atoi(labels[restart.LastExitStatusLabel])
+	payload := giveUpPayload{
+		Namespace:    ns,
+		ContainerID:  container.ID(),
+		RestartCount: count,
+		LastExitCode: exitCode,
+		LogTail:      m.logTail(labels),
+		Timestamp:    time.Now().UTC().Format(time.RFC3339),
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+
+	log.G(ctx).Warnf("container %q exceeded its restart retry budget (%d), notifying", container.ID(), count)
+	if m.giveUp.WebhookURL != "" {
+		resp, err := http.Post(m.giveUp.WebhookURL, "application/json", bytes.NewReader(data))
+		if err != nil {
+			log.G(ctx).WithError(err).Error("give-up webhook failed")
+		} else {
+			resp.Body.Close()
+		}
+	}
+	if len(m.giveUp.ExecCommand) > 0 {
+		cmd := exec.Command(m.giveUp.ExecCommand[0], m.giveUp.ExecCommand[1:]...)
+		cmd.Stdin = bytes.NewReader(data)
+		if err := cmd.Run(); err != nil {
+			log.G(ctx).WithError(err).Error("give-up exec notification failed")
+		}
+	}
+
+	// Record delivery so the notification fires once.
+	if _, err := container.SetLabels(ctx, map[string]string{
+		gaveUpNotifiedLabel: payload.Timestamp,
+	}); err != nil {
+		log.G(ctx).WithError(err).Errorf("failed to mark give-up notification of %q", container.ID())
+	}
+}
+
+// logTail reads the tail of the container's file log, when it logs to a
+// file URI.
+func (m *monitor) logTail(labels map[string]string) string {
+	if m.giveUp.LogTailBytes <= 0 {
+		return ""
+	}
+	uri := labels[restart.LogURILabel]
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return ""
+	}
+	f, err := os.Open(u.Path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return ""
+	}
+	offset := fi.Size() - m.giveUp.LogTailBytes
+	if offset < 0 {
+		offset = 0
+	}
+	buf := make([]byte, fi.Size()-offset)
+	if _, err := f.ReadAt(buf, offset); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(buf))
+}
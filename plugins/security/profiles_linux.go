@@ -0,0 +1,90 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package security registers the security profile manager plugin, which
+// ensures the host's default security profiles are usable at startup
+// instead of surfacing as cryptic container start errors later. Its
+// findings are exported through the introspection API as plugin exports.
+package security
+
+import (
+	"github.com/containerd/log"
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+	"github.com/opencontainers/selinux/go-selinux"
+
+	"github.com/containerd/containerd/v2/contrib/apparmor"
+	aautil "github.com/containerd/containerd/v2/pkg/apparmor"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// DefaultAppArmorProfile is the name of the default profile loaded for
+// containers that do not request a specific one. It matches the profile
+// name applied by the CRI plugin.
+const DefaultAppArmorProfile = "cri-containerd.apparmor.d"
+
+// Config for the security profile manager.
+type Config struct {
+	// DisableAppArmorLoad skips generating and loading the default
+	// AppArmor profile at startup.
+	DisableAppArmorLoad bool `toml:"disable_apparmor_load"`
+}
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type:   plugins.InternalPlugin,
+		ID:     "security-profiles",
+		Config: &Config{},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			config := ic.Config.(*Config)
+			m := &Manager{}
+
+			if !aautil.HostSupports() {
+				m.AppArmor = "unsupported"
+			} else if config.DisableAppArmorLoad {
+				m.AppArmor = "load disabled"
+			} else if err := apparmor.LoadDefaultProfile(DefaultAppArmorProfile); err != nil {
+				m.AppArmor = "load failed: " + err.Error()
+				log.G(ic.Context).WithError(err).Errorf("failed to load default AppArmor profile %q", DefaultAppArmorProfile)
+			} else {
+				m.AppArmor = "loaded " + DefaultAppArmorProfile
+			}
+
+			if selinux.GetEnabled() {
+				m.SELinux = "enforcing"
+				if selinux.EnforceMode() != selinux.Enforcing {
+					m.SELinux = "permissive"
+				}
+			} else {
+				m.SELinux = "disabled"
+			}
+
+			// Surface the status through the introspection API.
+			ic.Meta.Exports["apparmor"] = m.AppArmor
+			ic.Meta.Exports["selinux"] = m.SELinux
+			log.G(ic.Context).Debugf("security profiles: apparmor=%q selinux=%q", m.AppArmor, m.SELinux)
+			return m, nil
+		},
+	})
+}
+
+// Manager reports the state of the host's security profile setup.
+type Manager struct {
+	// AppArmor describes the default AppArmor profile state.
+	AppArmor string
+	// SELinux describes the SELinux mode of the host.
+	SELinux string
+}
@@ -17,6 +17,8 @@
 package healthcheck
 
 import (
+	"sync"
+
 	"github.com/containerd/containerd/v2/plugins"
 	"github.com/containerd/plugin"
 	"github.com/containerd/plugin/registry"
@@ -24,29 +26,70 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 )
 
 type service struct {
-	serve *health.Server
+	serve            *health.Server
+	enableReflection bool
+}
+
+// Config for the healthcheck service.
+type Config struct {
+	// EnableReflection registers the gRPC server reflection service on
+	// the containerd socket, so standard tooling such as grpcurl works
+	// against the daemon for debugging. Disabled by default.
+	EnableReflection bool `toml:"enable_reflection"`
+}
+
+// global is the health server registered on the containerd socket, so
+// other plugins can flip per-service serving status (e.g. the CRI plugin
+// marking itself degraded while CNI is not ready).
+var (
+	globalMu sync.Mutex
+	global   *health.Server
+)
+
+// SetServingStatus updates the serving status of a service, creating the
+// entry on first use. It is safe to call before the gRPC server starts
+// serving.
+func SetServingStatus(service string, status grpc_health_v1.HealthCheckResponse_ServingStatus) {
+	globalMu.Lock()
+	defer globalMu.Unlock()
+	if global == nil {
+		global = health.NewServer()
+	}
+	global.SetServingStatus(service, status)
 }
 
 func init() {
 	registry.Register(&plugin.Registration{
-		Type: plugins.GRPCPlugin,
-		ID:   "healthcheck",
-		InitFn: func(*plugin.InitContext) (interface{}, error) {
-			return newService()
+		Type:   plugins.GRPCPlugin,
+		ID:     "healthcheck",
+		Config: &Config{},
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			return newService(ic.Config.(*Config))
 		},
 	})
 }
 
-func newService() (*service, error) {
+func newService(config *Config) (*service, error) {
+	globalMu.Lock()
+	if global == nil {
+		global = health.NewServer()
+	}
+	serve := global
+	globalMu.Unlock()
 	return &service{
-		health.NewServer(),
+		serve:            serve,
+		enableReflection: config.EnableReflection,
 	}, nil
 }
 
 func (s *service) Register(server *grpc.Server) error {
 	grpc_health_v1.RegisterHealthServer(server, s.serve)
+	if s.enableReflection {
+		reflection.Register(server)
+	}
 	return nil
 }
@@ -0,0 +1,106 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+// Package streamprocessors exposes runtime registration of stream
+// processors over the containerd API, so new layer media types can be
+// rolled out without a daemon restart.
+package streamprocessors
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/containerd/plugin"
+	"github.com/containerd/plugin/registry"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/containerd/containerd/v2/core/diff"
+	"github.com/containerd/containerd/v2/plugins"
+)
+
+// ServiceName is the full gRPC service name of the stream processors
+// admin service. The service is hand-rolled on top of well-known protobuf
+// types: payloads are JSON encoded diff.ProcessorInfo values carried in
+// StringValue messages.
+const ServiceName = "containerd.services.streamprocessors.v1.StreamProcessors"
+
+func init() {
+	registry.Register(&plugin.Registration{
+		Type: plugins.GRPCPlugin,
+		ID:   "stream-processors",
+		InitFn: func(ic *plugin.InitContext) (interface{}, error) {
+			return &service{}, nil
+		},
+	})
+}
+
+type service struct{}
+
+// Register registers the stream processors service onto the gRPC server.
+func (s *service) Register(server *grpc.Server) error {
+	server.RegisterService(&grpc.ServiceDesc{
+		ServiceName: ServiceName,
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{
+			{
+				MethodName: "Register",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					req := &wrapperspb.StringValue{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+					var info diff.ProcessorInfo
+					if err := json.Unmarshal([]byte(req.GetValue()), &info); err != nil {
+						return nil, err
+					}
+					if err := diff.RegisterDynamicProcessor(info); err != nil {
+						return nil, err
+					}
+					return &emptypb.Empty{}, nil
+				},
+			},
+			{
+				MethodName: "Remove",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					req := &wrapperspb.StringValue{}
+					if err := dec(req); err != nil {
+						return nil, err
+					}
+					if err := diff.RemoveDynamicProcessor(req.GetValue()); err != nil {
+						return nil, err
+					}
+					return &emptypb.Empty{}, nil
+				},
+			},
+			{
+				MethodName: "List",
+				Handler: func(_ interface{}, _ context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+					if err := dec(&emptypb.Empty{}); err != nil {
+						return nil, err
+					}
+					data, err := json.Marshal(diff.DynamicProcessors())
+					if err != nil {
+						return nil, err
+					}
+					return wrapperspb.String(string(data)), nil
+				},
+			},
+		},
+	}, s)
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/containerd/log"
+	"github.com/containerd/typeurl/v2"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+// HookPolicy injects an OCI hook into every container matching the
+// namespace and label selector, so node agents (GPU setup, audit) do not
+// need every client to add hooks individually.
+type HookPolicy struct {
+	// Type selects the hook list: "prestart", "createRuntime",
+	// "createContainer", "startContainer", "poststart" or "poststop".
+	Type string `toml:"type" json:"type"`
+	// Path is the absolute path of the hook binary.
+	Path string `toml:"path" json:"path"`
+	// Args passed to the hook binary.
+	Args []string `toml:"args" json:"args"`
+	// Env passed to the hook binary.
+	Env []string `toml:"env" json:"env"`
+	// Timeout in seconds for the hook. Zero means no timeout.
+	Timeout int `toml:"timeout" json:"timeout"`
+	// Order sorts injected hooks of the same type, lowest first.
+	Order int `toml:"order" json:"order"`
+	// Namespace restricts injection to containers of the namespace. An
+	// empty value matches every namespace.
+	Namespace string `toml:"namespace" json:"namespace"`
+	// LabelSelector restricts injection to containers carrying all the
+	// listed labels, given as "key" or "key=value" entries.
+	LabelSelector []string `toml:"label_selector" json:"labelSelector"`
+	// FailurePolicy controls what happens when the hook cannot be
+	// injected: "fail" (default) fails task creation, "ignore" logs and
+	// continues without the hook.
+	FailurePolicy string `toml:"failure_policy" json:"failurePolicy"`
+}
+
+// matches reports whether the policy applies to the container.
+func (p HookPolicy) matches(namespace string, container containers.Container) bool {
+	if p.Namespace != "" && p.Namespace != namespace {
+		return false
+	}
+	for _, selector := range p.LabelSelector {
+		key, value, hasValue := strings.Cut(selector, "=")
+		v, ok := container.Labels[key]
+		if !ok {
+			return false
+		}
+		if hasValue && v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// injectHooks applies the matching hook policies to the container's spec,
+// returning the original spec when no policy matches.
+func injectHooks(ctx context.Context, policies []HookPolicy, namespace string, container containers.Container) (typeurl.Any, error) {
+	var matching []HookPolicy
+	for _, p := range policies {
+		if p.matches(namespace, container) {
+			matching = append(matching, p)
+		}
+	}
+	if len(matching) == 0 {
+		return container.Spec, nil
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].Order < matching[j].Order })
+
+	var spec specs.Spec
+	if err := json.Unmarshal(container.Spec.GetValue(), &spec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal spec of container %q for hook injection: %w", container.ID, err)
+	}
+	if spec.Hooks == nil {
+		spec.Hooks = &specs.Hooks{}
+	}
+
+	for _, p := range matching {
+		hook := specs.Hook{
+			Path: p.Path,
+			Args: p.Args,
+			Env:  p.Env,
+		}
+		if p.Timeout > 0 {
+			timeout := p.Timeout
+			hook.Timeout = &timeout
+		}
+		if err := appendHook(spec.Hooks, p.Type, hook); err != nil {
+			if p.FailurePolicy == "ignore" {
+				log.G(ctx).WithError(err).Warnf("skipping hook injection for container %q", container.ID)
+				continue
+			}
+			return nil, err
+		}
+		log.G(ctx).Debugf("injected %s hook %q into container %q", p.Type, p.Path, container.ID)
+	}
+
+	return typeurl.MarshalAny(&spec)
+}
+
+func appendHook(hooks *specs.Hooks, hookType string, hook specs.Hook) error {
+	switch hookType {
+	case "prestart":
+		hooks.Prestart = append(hooks.Prestart, hook) //nolint:staticcheck // Prestart is deprecated but still supported
+	case "createRuntime":
+		hooks.CreateRuntime = append(hooks.CreateRuntime, hook)
+	case "createContainer":
+		hooks.CreateContainer = append(hooks.CreateContainer, hook)
+	case "startContainer":
+		hooks.StartContainer = append(hooks.StartContainer, hook)
+	case "poststart":
+		hooks.Poststart = append(hooks.Poststart, hook)
+	case "poststop":
+		hooks.Poststop = append(hooks.Poststop, hook)
+	default:
+		return fmt.Errorf("unknown hook type %q", hookType)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+/*
+   Copyright The containerd Authors.
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+*/
+
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/containerd/typeurl/v2"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/containerd/containerd/v2/core/containers"
+)
+
+func hookTestContainer(t *testing.T, labels map[string]string) containers.Container {
+	spec, err := typeurl.MarshalAny(&specs.Spec{Version: specs.Version})
+	require.NoError(t, err)
+	return containers.Container{
+		ID:     "c1",
+		Labels: labels,
+		Spec:   spec,
+	}
+}
+
+func unmarshalSpec(t *testing.T, anySpec typeurl.Any) *specs.Spec {
+	var spec specs.Spec
+	require.NoError(t, json.Unmarshal(anySpec.GetValue(), &spec))
+	return &spec
+}
+
+func TestInjectHooks(t *testing.T) {
+	ctx := context.Background()
+	policies := []HookPolicy{
+		{Type: "poststop", Path: "/usr/local/bin/audit", Order: 2},
+		{Type: "createRuntime", Path: "/usr/local/bin/gpu-setup", Args: []string{"gpu-setup", "--all"}, Timeout: 10, Order: 1},
+	}
+
+	anySpec, err := injectHooks(ctx, policies, "default", hookTestContainer(t, nil))
+	require.NoError(t, err)
+	spec := unmarshalSpec(t, anySpec)
+	require.NotNil(t, spec.Hooks)
+	require.Len(t, spec.Hooks.CreateRuntime, 1)
+	assert.Equal(t, "/usr/local/bin/gpu-setup", spec.Hooks.CreateRuntime[0].Path)
+	require.NotNil(t, spec.Hooks.CreateRuntime[0].Timeout)
+	assert.Equal(t, 10, *spec.Hooks.CreateRuntime[0].Timeout)
+	require.Len(t, spec.Hooks.Poststop, 1)
+}
+
+func TestInjectHooksSelectors(t *testing.T) {
+	ctx := context.Background()
+	policies := []HookPolicy{
+		{Type: "prestart", Path: "/hook", Namespace: "k8s.io"},
+		{Type: "poststart", Path: "/hook2", LabelSelector: []string{"gpu=true"}},
+	}
+
+	// No matching policy leaves the spec untouched.
+	container := hookTestContainer(t, nil)
+	anySpec, err := injectHooks(ctx, policies, "default", container)
+	require.NoError(t, err)
+	assert.Equal(t, container.Spec, anySpec)
+
+	// Namespace selector.
+	anySpec, err = injectHooks(ctx, policies, "k8s.io", hookTestContainer(t, nil))
+	require.NoError(t, err)
+	spec := unmarshalSpec(t, anySpec)
+	require.Len(t, spec.Hooks.Prestart, 1) //nolint:staticcheck // Prestart is deprecated but still supported
+	assert.Empty(t, spec.Hooks.Poststart)
+
+	// Label selector.
+	anySpec, err = injectHooks(ctx, policies, "default", hookTestContainer(t, map[string]string{"gpu": "true"}))
+	require.NoError(t, err)
+	spec = unmarshalSpec(t, anySpec)
+	require.Len(t, spec.Hooks.Poststart, 1)
+}
+
+func TestInjectHooksFailurePolicy(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := injectHooks(ctx, []HookPolicy{{Type: "bogus", Path: "/hook"}}, "default", hookTestContainer(t, nil))
+	assert.Error(t, err)
+
+	anySpec, err := injectHooks(ctx, []HookPolicy{{Type: "bogus", Path: "/hook", FailurePolicy: "ignore"}}, "default", hookTestContainer(t, nil))
+	require.NoError(t, err)
+	spec := unmarshalSpec(t, anySpec)
+	assert.Empty(t, spec.Hooks.Poststop)
+}
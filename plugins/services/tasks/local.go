@@ -40,6 +40,7 @@ import (
 	"github.com/containerd/containerd/v2/pkg/archive"
 	"github.com/containerd/containerd/v2/pkg/blockio"
 	"github.com/containerd/containerd/v2/pkg/filters"
+	"github.com/containerd/containerd/v2/pkg/namespaces"
 	"github.com/containerd/containerd/v2/pkg/protobuf"
 	"github.com/containerd/containerd/v2/pkg/protobuf/proto"
 	ptypes "github.com/containerd/containerd/v2/pkg/protobuf/types"
@@ -74,6 +75,9 @@ type Config struct {
 	BlockIOConfigFile string `toml:"blockio_config_file" json:"blockioConfigFile"`
 	// RdtConfigFile specifies the path to RDT configuration file
 	RdtConfigFile string `toml:"rdt_config_file" json:"rdtConfigFile"`
+	// Hooks are OCI hooks injected into containers matching each policy's
+	// namespace and label selector.
+	Hooks []HookPolicy `toml:"hooks" json:"hooks"`
 }
 
 func init() {
@@ -116,11 +120,12 @@ func initFunc(ic *plugin.InitContext) (interface{}, error) {
 
 	db := m.(*metadata.DB)
 	l := &local{
-		containers: metadata.NewContainerStore(db),
-		store:      db.ContentStore(),
-		publisher:  ep.(events.Publisher),
-		monitor:    monitor.(runtime.TaskMonitor),
-		v2Runtime:  v2r.(runtime.PlatformRuntime),
+		containers:   metadata.NewContainerStore(db),
+		store:        db.ContentStore(),
+		publisher:    ep.(events.Publisher),
+		monitor:      monitor.(runtime.TaskMonitor),
+		v2Runtime:    v2r.(runtime.PlatformRuntime),
+		hookPolicies: config.Hooks,
 	}
 
 	v2Tasks, err := l.v2Runtime.Tasks(ic.Context, true)
@@ -148,6 +153,9 @@ type local struct {
 
 	monitor   runtime.TaskMonitor
 	v2Runtime runtime.PlatformRuntime
+	// hookPolicies are host level OCI hooks injected into matching
+	// containers at task creation.
+	hookPolicies []HookPolicy
 }
 
 func (l *local) Create(ctx context.Context, r *api.CreateTaskRequest, _ ...grpc.CallOption) (*api.CreateTaskResponse, error) {
@@ -196,8 +204,18 @@ func (l *local) Create(ctx context.Context, r *api.CreateTaskRequest, _ ...grpc.
 			return nil, err
 		}
 	}
+	spec := container.Spec
+	if len(l.hookPolicies) > 0 {
+		ns, err := namespaces.NamespaceRequired(ctx)
+		if err != nil {
+			return nil, errdefs.ToGRPC(err)
+		}
+		if spec, err = injectHooks(ctx, l.hookPolicies, ns, *container); err != nil {
+			return nil, errdefs.ToGRPC(err)
+		}
+	}
 	opts := runtime.CreateOpts{
-		Spec: container.Spec,
+		Spec: spec,
 		IO: runtime.IO{
 			Stdin:    r.Stdin,
 			Stdout:   r.Stdout,
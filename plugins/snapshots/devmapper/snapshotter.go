@@ -34,6 +34,7 @@ import (
 	"github.com/containerd/containerd/v2/plugins/snapshots/devmapper/dmsetup"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/log"
+	"github.com/docker/go-units"
 )
 
 type fsType string
@@ -47,6 +48,15 @@ const (
 const (
 	metadataFileName        = "metadata.db"
 	devmapperSnapshotFsType = "containerd.io/snapshot/devmapper/fstype"
+	// devmapperSnapshotSize overrides the thin device's virtual size for
+	// a single snapshot (e.g. "100GB"), so data-heavy containers can get
+	// a dedicated large writable layer without raising the global base
+	// image size.
+	devmapperSnapshotSize = "containerd.io/snapshot/devmapper/size"
+	// devmapperSnapshotDiscard forces discarding the device's blocks when
+	// the snapshot is removed, regardless of the global discard_blocks
+	// setting, giving fast space reclaim for data-heavy containers.
+	devmapperSnapshotDiscard = "containerd.io/snapshot/devmapper/discard"
 )
 
 type closeFunc func() error
@@ -300,12 +310,24 @@ func (s *Snapshotter) Remove(ctx context.Context, key string) error {
 }
 
 func (s *Snapshotter) removeDevice(ctx context.Context, key string) error {
+	var forceDiscard bool
+	if _, info, _, err := storage.GetInfo(ctx, key); err == nil {
+		forceDiscard = info.Labels[devmapperSnapshotDiscard] == "true"
+	}
+
 	snapID, _, err := storage.Remove(ctx, key)
 	if err != nil {
 		return err
 	}
 
 	deviceName := s.getDeviceName(snapID)
+	if forceDiscard && !s.config.DiscardBlocks {
+		// The snapshot opted into discard-on-delete; reclaim its blocks
+		// even though global discarding is off.
+		if err := dmsetup.DiscardBlocks(deviceName); err != nil && err != dmsetup.ErrInUse {
+			log.G(ctx).WithError(err).Warnf("failed to discard blocks of %q", deviceName)
+		}
+	}
 	if !s.config.AsyncRemove {
 		if err := s.pool.RemoveDevice(ctx, deviceName); err != nil {
 			log.G(ctx).WithError(err).Error("failed to remove device")
@@ -373,6 +395,21 @@ func (s *Snapshotter) Close() error {
 func (s *Snapshotter) createSnapshot(ctx context.Context, kind snapshots.Kind, key, parent string, opts ...snapshots.Opt) ([]mount.Mount, error) {
 	var fileSystemType fsType
 
+	var base snapshots.Info
+	for _, opt := range opts {
+		if err := opt(&base); err != nil {
+			return nil, err
+		}
+	}
+	deviceSize := s.config.BaseImageSizeBytes
+	if v := base.Labels[devmapperSnapshotSize]; v != "" {
+		parsed, err := units.RAMInBytes(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s label %q: %w", devmapperSnapshotSize, v, err)
+		}
+		deviceSize = uint64(parsed)
+	}
+
 	// For snapshots with no parents, we use file system type as configured in config.
 	// For snapshots with parents, we inherit the file system type. We use the same
 	// file system type derived here for building mount points later.
@@ -408,7 +445,7 @@ func (s *Snapshotter) createSnapshot(ctx context.Context, kind snapshots.Kind, k
 		deviceName := s.getDeviceName(snap.ID)
 		log.G(ctx).Debugf("creating new thin device '%s'", deviceName)
 
-		err := s.pool.CreateThinDevice(ctx, deviceName, s.config.BaseImageSizeBytes)
+		err := s.pool.CreateThinDevice(ctx, deviceName, deviceSize)
 		if err != nil {
 			log.G(ctx).WithError(err).Errorf("failed to create thin device for snapshot %s", snap.ID)
 			return nil, err
@@ -438,7 +475,7 @@ func (s *Snapshotter) createSnapshot(ctx context.Context, kind snapshots.Kind, k
 
 		log.G(ctx).Debugf("creating snapshot device '%s' from '%s' with fsType: '%s'", snapDeviceName, parentDeviceName, fileSystemType)
 
-		err = s.pool.CreateSnapshotDevice(ctx, parentDeviceName, snapDeviceName, s.config.BaseImageSizeBytes)
+		err = s.pool.CreateSnapshotDevice(ctx, parentDeviceName, snapDeviceName, deviceSize)
 		if err != nil {
 			log.G(ctx).WithError(err).Errorf("failed to create snapshot device from parent %s", parentDeviceName)
 			return nil, err